@@ -18,19 +18,19 @@ until mysqladmin --protocol=tcp -h "$MYSQL_HOST" -P "$MYSQL_PORT" -u "$MYSQL_USE
 done
 echo "MySQL is ready."
 
-if [ -f /app/schema.sql ]; then
-  echo "Applying schema..."
-  mysql --protocol=tcp -h "$MYSQL_HOST" -P "$MYSQL_PORT" -u "$MYSQL_USER" "$MYSQL_DATABASE" < /app/schema.sql
-  echo "Schema applied."
+unset MYSQL_PWD
+
+echo "Applying schema migrations..."
+if [ "${GO_ENV}" = "development" ]; then
+  go run . migrate up
 else
-  echo "No /app/schema.sql found; skipping schema apply."
+  ./main migrate up
 fi
-
-unset MYSQL_PWD
 echo "Database initialization completed."
+
 echo "Starting Go application..."
 if [ "${GO_ENV}" = "development" ]; then
-  exec go run main.go
+  exec go run .
 else
   exec ./main
 fi
@@ -4,8 +4,11 @@ package config
 import (
 	"fmt"
 	"log"
+	"net"
+	"net/url"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/k-zehnder/gophersignal/backend/docs"
@@ -13,12 +16,21 @@ import (
 
 // AppConfig represents the application's configuration.
 type AppConfig struct {
-	DataSourceName    string // Database connection string
-	Environment       string // Application environment (e.g., "development", "production")
-	ServerAddress     string // Address on which the server should listen
-	SwaggerHost       string // Host for Swagger documentation
-	HuggingFaceAPIKey string // API key for Hugging Face service
-	CacheMaxAge       int    // Cache-Control max-age in seconds
+	DataSourceName    string        // Database connection string
+	Environment       string        // Application environment (e.g., "development", "production")
+	ServerAddress     string        // Address on which the server should listen
+	SwaggerHost       string        // Host for Swagger documentation
+	HuggingFaceAPIKey string        // API key for Hugging Face service
+	CacheMaxAge       int           // Cache-Control max-age in seconds
+	QueryTimeout      time.Duration // Default deadline for a single store query
+	RequireSchema     bool          // Refuse to start while database migrations are pending
+	CompressMinSize   int           // Smallest response in bytes to compress; 0 leaves compression to a proxy
+	StreamHeartbeat   time.Duration // Interval of keep-alive comments on idle article streams
+	AdminToken        string        // Bearer token of the admin API; empty disables it
+	IngestToken       string        // Bearer token of the article ingest API; empty disables it
+	WebhookTimeout    time.Duration // Deadline of a single webhook delivery attempt
+	WebhookAttempts   int           // Attempts after which a webhook delivery is given up
+	WebhookBackoff    time.Duration // Delay before the first webhook retry, doubling with every further one
 }
 
 // NewConfig initializes and returns a new AppConfig, loading environment variables from .env file with defaults if not present.
@@ -37,6 +49,62 @@ func NewConfig() *AppConfig {
 		cacheMaxAge = 5400
 	}
 
+	// Parse QUERY_TIMEOUT env variable with default value of 5 seconds
+	queryTimeoutStr := GetEnv("QUERY_TIMEOUT", "5s")
+	queryTimeout, err := time.ParseDuration(queryTimeoutStr)
+	if err != nil || queryTimeout <= 0 {
+		log.Printf("Invalid QUERY_TIMEOUT value: %s, using default 5s", queryTimeoutStr)
+		queryTimeout = 5 * time.Second
+	}
+
+	// Parse REQUIRE_SCHEMA_CURRENT env variable with default value false
+	requireSchemaStr := GetEnv("REQUIRE_SCHEMA_CURRENT", "false")
+	requireSchema, err := strconv.ParseBool(requireSchemaStr)
+	if err != nil {
+		log.Printf("Invalid REQUIRE_SCHEMA_CURRENT value: %s, using default false", requireSchemaStr)
+		requireSchema = false
+	}
+
+	// Parse COMPRESS_MIN_SIZE env variable with default value 1024 bytes
+	compressMinSizeStr := GetEnv("COMPRESS_MIN_SIZE", "1024")
+	compressMinSize, err := strconv.Atoi(compressMinSizeStr)
+	if err != nil || compressMinSize < 0 {
+		log.Printf("Invalid COMPRESS_MIN_SIZE value: %s, using default 1024", compressMinSizeStr)
+		compressMinSize = 1024
+	}
+
+	// Parse STREAM_HEARTBEAT env variable with default value of 15 seconds
+	streamHeartbeatStr := GetEnv("STREAM_HEARTBEAT", "15s")
+	streamHeartbeat, err := time.ParseDuration(streamHeartbeatStr)
+	if err != nil || streamHeartbeat <= 0 {
+		log.Printf("Invalid STREAM_HEARTBEAT value: %s, using default 15s", streamHeartbeatStr)
+		streamHeartbeat = 15 * time.Second
+	}
+
+	// Parse WEBHOOK_TIMEOUT env variable with default value of 10 seconds
+	webhookTimeoutStr := GetEnv("WEBHOOK_TIMEOUT", "10s")
+	webhookTimeout, err := time.ParseDuration(webhookTimeoutStr)
+	if err != nil || webhookTimeout <= 0 {
+		log.Printf("Invalid WEBHOOK_TIMEOUT value: %s, using default 10s", webhookTimeoutStr)
+		webhookTimeout = 10 * time.Second
+	}
+
+	// Parse WEBHOOK_MAX_ATTEMPTS env variable with default value 8
+	webhookMaxAttemptsStr := GetEnv("WEBHOOK_MAX_ATTEMPTS", "8")
+	webhookMaxAttempts, err := strconv.Atoi(webhookMaxAttemptsStr)
+	if err != nil || webhookMaxAttempts < 1 {
+		log.Printf("Invalid WEBHOOK_MAX_ATTEMPTS value: %s, using default 8", webhookMaxAttemptsStr)
+		webhookMaxAttempts = 8
+	}
+
+	// Parse WEBHOOK_BACKOFF env variable with default value of 30 seconds
+	webhookBackoffStr := GetEnv("WEBHOOK_BACKOFF", "30s")
+	webhookBackoff, err := time.ParseDuration(webhookBackoffStr)
+	if err != nil || webhookBackoff <= 0 {
+		log.Printf("Invalid WEBHOOK_BACKOFF value: %s, using default 30s", webhookBackoffStr)
+		webhookBackoff = 30 * time.Second
+	}
+
 	cfg := &AppConfig{
 		DataSourceName:    GetDataSourceName(),
 		Environment:       GetEnv("GO_ENV", "development"),
@@ -44,6 +112,15 @@ func NewConfig() *AppConfig {
 		SwaggerHost:       GetDefaultSwaggerHost(GetEnv("GO_ENV", "development")),
 		HuggingFaceAPIKey: GetEnv("HUGGING_FACE_API_KEY", ""),
 		CacheMaxAge:       cacheMaxAge,
+		QueryTimeout:      queryTimeout,
+		RequireSchema:     requireSchema,
+		CompressMinSize:   compressMinSize,
+		StreamHeartbeat:   streamHeartbeat,
+		AdminToken:        GetEnv("ADMIN_TOKEN", ""),
+		IngestToken:       GetEnv("INGEST_TOKEN", ""),
+		WebhookTimeout:    webhookTimeout,
+		WebhookAttempts:   webhookMaxAttempts,
+		WebhookBackoff:    webhookBackoff,
 	}
 
 	// Configure Swagger host
@@ -70,8 +147,24 @@ func GetDefaultSwaggerHost(env string) string {
 	}
 }
 
-// GetDataSourceName constructs the MYSQL_DSN from individual environment variables.
+// GetDataSourceName constructs the data source name for the database selected by DB_DRIVER.
+// The "sqlite" driver yields a "sqlite://" DSN pointing at SQLITE_PATH, which needs no external
+// services, and "postgres" yields a "postgres://" URL built from the POSTGRES_* variables.
+// Any other value builds the MySQL DSN from individual environment variables.
 func GetDataSourceName() string {
+	switch GetEnv("DB_DRIVER", "mysql") {
+	case "sqlite":
+		return "sqlite://" + GetEnv("SQLITE_PATH", "gophersignal.db")
+	case "postgres":
+		dsn := url.URL{
+			Scheme:   "postgres",
+			User:     url.UserPassword(GetEnv("POSTGRES_USER", "user"), GetEnv("POSTGRES_PASSWORD", "password")),
+			Host:     net.JoinHostPort(GetEnv("POSTGRES_HOST", "postgres"), GetEnv("POSTGRES_PORT", "5432")),
+			Path:     "/" + GetEnv("POSTGRES_DATABASE", "gophersignal"),
+			RawQuery: "sslmode=" + GetEnv("POSTGRES_SSLMODE", "disable"),
+		}
+		return dsn.String()
+	}
 	user := GetEnv("MYSQL_USER", "user")
 	password := GetEnv("MYSQL_PASSWORD", "password")
 	host := GetEnv("MYSQL_HOST", "mysql")
@@ -15,79 +15,300 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/articles": {
+        "/admin/deliveries/{id}": {
             "get": {
-                "description": "Retrieve paginated articles with optional filters and thresholds",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Retrieve one entry of the delivery log by ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Articles"
+                    "Webhooks"
                 ],
-                "summary": "Get filtered articles",
+                "summary": "Get a webhook delivery",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "Filter by flagged status",
-                        "name": "flagged",
-                        "in": "query"
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDeliveryResponse"
+                        }
                     },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by dead status",
-                        "name": "dead",
-                        "in": "query"
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by duplicate status",
-                        "name": "dupe",
-                        "in": "query"
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/deliveries/{id}/replay": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queue a delivery to be sent again now with its original payload, whether it was delivered, failed or is still pending. Its attempt count starts over.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Replay a webhook delivery",
+                "parameters": [
                     {
-                        "maximum": 100,
                         "minimum": 1,
                         "type": "integer",
-                        "default": 30,
-                        "description": "Results per page (max 100)",
-                        "name": "limit",
-                        "in": "query"
+                        "description": "Delivery ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDeliveryResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks": {
+            "get": {
+                "security": [
                     {
-                        "minimum": 0,
-                        "type": "integer",
-                        "default": 0,
-                        "description": "Pagination offset",
-                        "name": "offset",
-                        "in": "query"
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List every registered webhook, oldest first. Secrets are not returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhooks",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhooksResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
                     {
-                        "minimum": 0,
-                        "type": "integer",
-                        "format": "int64",
-                        "default": 0,
-                        "description": "Minimum upvotes threshold",
-                        "name": "min_upvotes",
-                        "in": "query"
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Register a URL receiving a signed POST for every saved article matching the filter, such as min_upvotes\u003e=200 AND NOT flagged. Filters combine conditions on upvotes, comments, rank, domain, source, model, title, link, flagged, dead, dupe and summarized with AND, OR, NOT and parentheses. Each article is delivered at most once per webhook. Requests carry X-GopherSignal-Timestamp and X-GopherSignal-Signature, \"sha256=\" followed by the hex HMAC-SHA256 of the timestamp, a dot and the body keyed with the secret. The secret is generated unless given, and only returned here.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Register a webhook",
+                "parameters": [
+                    {
+                        "description": "Webhook to register",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookResponse"
+                        },
+                        "headers": {
+                            "Location": {
+                                "type": "string",
+                                "description": "URL of the new webhook"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProblemDetails"
+                        }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}": {
+            "get": {
+                "security": [
                     {
-                        "minimum": 0,
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve one webhook by ID. Its secret is not returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Get a webhook",
+                "parameters": [
+                    {
+                        "minimum": 1,
                         "type": "integer",
-                        "format": "int64",
-                        "default": 0,
-                        "description": "Minimum comments threshold",
-                        "name": "min_comments",
-                        "in": "query"
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.ArticlesResponse"
+                            "$ref": "#/definitions/models.WebhookResponse"
                         }
                     },
                     "400": {
@@ -96,123 +317,3048 @@ const docTemplate = `{
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the URL and filter of a webhook. The secret is rotated if one is given and kept otherwise, and the active state is kept unless given. Deactivated webhooks receive no new deliveries, and their queued ones wait until reactivation.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Update a webhook",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New settings",
+                        "name": "webhook",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookRequest"
+                        }
                     }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove a webhook together with its queued deliveries and delivery log.",
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "Delete a webhook",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/webhooks/{id}/deliveries": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Read the delivery log of a webhook, newest first: every article queued for it with its payload, status, attempts, next attempt and the outcome of the latest attempt.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks"
+                ],
+                "summary": "List webhook deliveries",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Webhook ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Deliveries to return, newest first",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "delivered",
+                            "failed"
+                        ],
+                        "type": "string",
+                        "description": "Only deliveries with this status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookDeliveriesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles": {
+            "get": {
+                "description": "Retrieve paginated articles with optional filters, thresholds and sort order. Pages are best walked with cursor, passing the next_cursor of the previous page, which stays stable while new articles arrive; offset is still accepted. Use fields or exclude to return only some article fields, e.g. exclude=content.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Articles"
+                ],
+                "summary": "Get filtered articles",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "abc1234"
+                        ],
+                        "description": "Only articles summarized by these builds",
+                        "name": "commit_hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Count all matching articles for total_count; false skips the count",
+                        "name": "count",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Resume after the page that returned this next_cursor; sort and order must not change",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by dead status",
+                        "name": "dead",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "github.com"
+                        ],
+                        "description": "Only articles linking to these sites",
+                        "name": "domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by duplicate status",
+                        "name": "dupe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "id",
+                                "hn_id",
+                                "title",
+                                "link",
+                                "domain",
+                                "article_rank",
+                                "content",
+                                "summary",
+                                "source",
+                                "commit_hash",
+                                "model_name",
+                                "created_at",
+                                "updated_at",
+                                "upvotes",
+                                "comment_count",
+                                "comment_link",
+                                "flagged",
+                                "dead",
+                                "dupe"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Leave these article fields out, e.g. content",
+                        "name": "exclude",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "medium.com"
+                        ],
+                        "description": "Exclude articles linking to these sites",
+                        "name": "exclude_domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "enum": [
+                                "id",
+                                "hn_id",
+                                "title",
+                                "link",
+                                "domain",
+                                "article_rank",
+                                "content",
+                                "summary",
+                                "source",
+                                "commit_hash",
+                                "model_name",
+                                "created_at",
+                                "updated_at",
+                                "upvotes",
+                                "comment_count",
+                                "comment_link",
+                                "flagged",
+                                "dead",
+                                "dupe"
+                            ],
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Only return these article fields",
+                        "name": "fields",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by flagged status",
+                        "name": "flagged",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum comments threshold",
+                        "name": "min_comments",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum upvotes threshold",
+                        "name": "min_upvotes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "llama3:8b"
+                        ],
+                        "description": "Only articles summarized by these models",
+                        "name": "model_name",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Pagination offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Direction of sort; defaults to asc for oldest and rank and desc otherwise",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "24h",
+                        "description": "Only articles created at or after this RFC 3339 time, date (2026-10-01) or duration ago (24h, 7d, 2w)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "newest",
+                            "oldest",
+                            "upvotes",
+                            "comments",
+                            "rank",
+                            "hot",
+                            "updated"
+                        ],
+                        "type": "string",
+                        "default": "newest",
+                        "description": "Result order: by ID, upvotes, comment count, front page rank, upvotes decayed by age, or last update",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-10-02",
+                        "description": "Only articles created before this RFC 3339 time, date or duration ago",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1h",
+                        "description": "Only articles updated at or after this RFC 3339 time, date or duration ago",
+                        "name": "updated_since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ArticlesResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the page's result set"
+                            },
+                            "Link": {
+                                "type": "string",
+                                "description": "RFC 8288 links to the next and previous pages"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Save a batch of scraped articles, upserting them by Hacker News ID or link as the scraper would. Every article is validated on its own: valid ones are saved even if others are rejected, and the response reports the outcome of each in request order. IDs sent by the client are ignored, and missing created_at and updated_at times default to now. Saved articles are announced to streams and webhooks.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Articles"
+                ],
+                "summary": "Ingest articles",
+                "parameters": [
+                    {
+                        "description": "Articles to save",
+                        "name": "articles",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Article"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Every article was saved",
+                        "schema": {
+                            "$ref": "#/definitions/models.IngestResponse"
+                        }
+                    },
+                    "207": {
+                        "description": "Some articles were rejected",
+                        "schema": {
+                            "$ref": "#/definitions/models.IngestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProblemDetails"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Every article was rejected",
+                        "schema": {
+                            "$ref": "#/definitions/models.IngestResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/hn/{hn_id}": {
+            "get": {
+                "description": "Retrieve one article by the ID of its Hacker News item. Responses carry an ETag, and requests with a matching If-None-Match header get 304 Not Modified.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Articles"
+                ],
+                "summary": "Get an article by Hacker News ID",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Hacker News item ID",
+                        "name": "hn_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ArticleResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the article"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/stream": {
+            "get": {
+                "description": "Push an \"article\" event whenever a new or updated article matching the filters is saved. The event ID is the stream position after the event, \"<highest article ID>-<latest update as Unix seconds>\". Reconnecting with Last-Event-ID first replays the matching articles stored after that article ID, oldest first, then those updated since that second, least recently updated first; articles updated within that second may be sent again. Idle streams carry comment heartbeats. Clients that fall too far behind are disconnected and should reconnect with Last-Event-ID.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "Articles"
+                ],
+                "summary": "Stream saved articles",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "abc1234"
+                        ],
+                        "description": "Only articles summarized by these builds",
+                        "name": "commit_hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by dead status",
+                        "name": "dead",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "github.com"
+                        ],
+                        "description": "Only articles linking to these sites",
+                        "name": "domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by duplicate status",
+                        "name": "dupe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "medium.com"
+                        ],
+                        "description": "Exclude articles linking to these sites",
+                        "name": "exclude_domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by flagged status",
+                        "name": "flagged",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum comments threshold",
+                        "name": "min_comments",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum upvotes threshold",
+                        "name": "min_upvotes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "llama3:8b"
+                        ],
+                        "description": "Only articles summarized by these models",
+                        "name": "model_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "24h",
+                        "description": "Only articles created at or after this RFC 3339 time, date (2026-10-01) or duration ago (24h, 7d, 2w)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-10-02",
+                        "description": "Only articles created before this RFC 3339 time, date or duration ago",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1h",
+                        "description": "Only articles updated at or after this RFC 3339 time, date or duration ago",
+                        "name": "updated_since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received, to resume after",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Stream of article events",
+                        "schema": {
+                            "$ref": "#/definitions/models.Article"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/{id}": {
+            "get": {
+                "description": "Retrieve one article by its GopherSignal ID. Responses carry an ETag, and requests with a matching If-None-Match header get 304 Not Modified.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Articles"
+                ],
+                "summary": "Get an article",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ArticleResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the article"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/articles/{id}/history": {
+            "get": {
+                "description": "Retrieve the time series of front page rank, upvotes and comment counts recorded each time the article was scraped",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Articles"
+                ],
+                "summary": "Get article history",
+                "parameters": [
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Article ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last-Modified time of a cached copy",
+                        "name": "If-Modified-Since",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.ArticleHistoryResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the history"
+                            },
+                            "Last-Modified": {
+                                "type": "string",
+                                "description": "Capture time of the latest snapshot"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/domains": {
+            "get": {
+                "description": "Count the articles linking to each registrable domain, such as github.com for gist.github.com, with their average upvotes. Domains with the most matching articles come first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Articles"
+                ],
+                "summary": "List domains",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "abc1234"
+                        ],
+                        "description": "Only articles summarized by these builds",
+                        "name": "commit_hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by dead status",
+                        "name": "dead",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "github.com"
+                        ],
+                        "description": "Only articles linking to these sites",
+                        "name": "domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by duplicate status",
+                        "name": "dupe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "medium.com"
+                        ],
+                        "description": "Exclude articles linking to these sites",
+                        "name": "exclude_domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by flagged status",
+                        "name": "flagged",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum comments threshold",
+                        "name": "min_comments",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum upvotes threshold",
+                        "name": "min_upvotes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "llama3:8b"
+                        ],
+                        "description": "Only articles summarized by these models",
+                        "name": "model_name",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Pagination offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "24h",
+                        "description": "Only articles created at or after this RFC 3339 time, date (2026-10-01) or duration ago (24h, 7d, 2w)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-10-02",
+                        "description": "Only articles created before this RFC 3339 time, date or duration ago",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1h",
+                        "description": "Only articles updated at or after this RFC 3339 time, date or duration ago",
+                        "name": "updated_since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DomainsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/facets": {
+            "get": {
+                "description": "Count the articles matching the filters per summarizing model, commit hash, source and flagged, dead and duplicate state. Each facet is counted without its own filter, so filtering by model_name still lists the counts of the other models.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Articles"
+                ],
+                "summary": "Get facet counts",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "abc1234"
+                        ],
+                        "description": "Only articles summarized by these builds",
+                        "name": "commit_hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by dead status",
+                        "name": "dead",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "github.com"
+                        ],
+                        "description": "Only articles linking to these sites",
+                        "name": "domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by duplicate status",
+                        "name": "dupe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "medium.com"
+                        ],
+                        "description": "Exclude articles linking to these sites",
+                        "name": "exclude_domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by flagged status",
+                        "name": "flagged",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum comments threshold",
+                        "name": "min_comments",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum upvotes threshold",
+                        "name": "min_upvotes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "llama3:8b"
+                        ],
+                        "description": "Only articles summarized by these models",
+                        "name": "model_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "24h",
+                        "description": "Only articles created at or after this RFC 3339 time, date (2026-10-01) or duration ago (24h, 7d, 2w)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-10-02",
+                        "description": "Only articles created before this RFC 3339 time, date or duration ago",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1h",
+                        "description": "Only articles updated at or after this RFC 3339 time, date or duration ago",
+                        "name": "updated_since",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.FacetsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/atom.xml": {
+            "get": {
+                "description": "Serve the newest articles matching the filters as an Atom 1.0 feed. Entries link the article and its Hacker News discussion, and their IDs stay stable across updates.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "Feeds"
+                ],
+                "summary": "Atom feed",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "abc1234"
+                        ],
+                        "description": "Only articles summarized by these builds",
+                        "name": "commit_hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by dead status",
+                        "name": "dead",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "github.com"
+                        ],
+                        "description": "Only articles linking to these sites",
+                        "name": "domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by duplicate status",
+                        "name": "dupe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "medium.com"
+                        ],
+                        "description": "Exclude articles linking to these sites",
+                        "name": "exclude_domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by flagged status",
+                        "name": "flagged",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Articles in the feed",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum comments threshold",
+                        "name": "min_comments",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum upvotes threshold",
+                        "name": "min_upvotes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "llama3:8b"
+                        ],
+                        "description": "Only articles summarized by these models",
+                        "name": "model_name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "daily",
+                            "weekly"
+                        ],
+                        "type": "string",
+                        "description": "Only articles of the last day or week; since takes precedence",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "24h",
+                        "description": "Only articles created at or after this RFC 3339 time, date (2026-10-01) or duration ago (24h, 7d, 2w)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-10-02",
+                        "description": "Only articles created before this RFC 3339 time, date or duration ago",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1h",
+                        "description": "Only articles updated at or after this RFC 3339 time, date or duration ago",
+                        "name": "updated_since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AtomFeed"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the feed's result set"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/feed.json": {
+            "get": {
+                "description": "Serve the newest articles matching the filters as a JSON Feed 1.1. Items point at the Hacker News discussion with the article as their external URL, and their IDs stay stable across updates.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Feeds"
+                ],
+                "summary": "JSON Feed",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "abc1234"
+                        ],
+                        "description": "Only articles summarized by these builds",
+                        "name": "commit_hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by dead status",
+                        "name": "dead",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "github.com"
+                        ],
+                        "description": "Only articles linking to these sites",
+                        "name": "domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by duplicate status",
+                        "name": "dupe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "medium.com"
+                        ],
+                        "description": "Exclude articles linking to these sites",
+                        "name": "exclude_domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by flagged status",
+                        "name": "flagged",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Articles in the feed",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum comments threshold",
+                        "name": "min_comments",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum upvotes threshold",
+                        "name": "min_upvotes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "llama3:8b"
+                        ],
+                        "description": "Only articles summarized by these models",
+                        "name": "model_name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "daily",
+                            "weekly"
+                        ],
+                        "type": "string",
+                        "description": "Only articles of the last day or week; since takes precedence",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "24h",
+                        "description": "Only articles created at or after this RFC 3339 time, date (2026-10-01) or duration ago (24h, 7d, 2w)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-10-02",
+                        "description": "Only articles created before this RFC 3339 time, date or duration ago",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1h",
+                        "description": "Only articles updated at or after this RFC 3339 time, date or duration ago",
+                        "name": "updated_since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JSONFeed"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the feed's result set"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/feeds/rss.xml": {
+            "get": {
+                "description": "Serve the newest articles matching the filters as an RSS 2.0 feed. Items describe the article with its summary and link the Hacker News discussion, and their GUIDs stay stable across updates.",
+                "produces": [
+                    "text/xml"
+                ],
+                "tags": [
+                    "Feeds"
+                ],
+                "summary": "RSS feed",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "abc1234"
+                        ],
+                        "description": "Only articles summarized by these builds",
+                        "name": "commit_hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by dead status",
+                        "name": "dead",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "github.com"
+                        ],
+                        "description": "Only articles linking to these sites",
+                        "name": "domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by duplicate status",
+                        "name": "dupe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "medium.com"
+                        ],
+                        "description": "Exclude articles linking to these sites",
+                        "name": "exclude_domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by flagged status",
+                        "name": "flagged",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Articles in the feed",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum comments threshold",
+                        "name": "min_comments",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum upvotes threshold",
+                        "name": "min_upvotes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "llama3:8b"
+                        ],
+                        "description": "Only articles summarized by these models",
+                        "name": "model_name",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "daily",
+                            "weekly"
+                        ],
+                        "type": "string",
+                        "description": "Only articles of the last day or week; since takes precedence",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "24h",
+                        "description": "Only articles created at or after this RFC 3339 time, date (2026-10-01) or duration ago (24h, 7d, 2w)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-10-02",
+                        "description": "Only articles created before this RFC 3339 time, date or duration ago",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1h",
+                        "description": "Only articles updated at or after this RFC 3339 time, date or duration ago",
+                        "name": "updated_since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RSSFeed"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the feed's result set"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "Full-text search over article titles, content and summaries. Every term must match; append * to a word to match it as a prefix, and quote words to match them as a phrase. Results are ranked by relevance and carry an HTML snippet of the summary with matches wrapped in \u003cmark\u003e.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Articles"
+                ],
+                "summary": "Search articles",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "abc1234"
+                        ],
+                        "description": "Only articles summarized by these builds",
+                        "name": "commit_hash",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by dead status",
+                        "name": "dead",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "github.com"
+                        ],
+                        "description": "Only articles linking to these sites",
+                        "name": "domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by duplicate status",
+                        "name": "dupe",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "medium.com"
+                        ],
+                        "description": "Exclude articles linking to these sites",
+                        "name": "exclude_domain",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by flagged status",
+                        "name": "flagged",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 30,
+                        "description": "Results per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum comments threshold",
+                        "name": "min_comments",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Minimum upvotes threshold",
+                        "name": "min_upvotes",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "example": [
+                            "llama3:8b"
+                        ],
+                        "description": "Only articles summarized by these models",
+                        "name": "model_name",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Pagination offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search text, e.g. rust \"memory safety\" compil*",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "24h",
+                        "description": "Only articles created at or after this RFC 3339 time, date (2026-10-01) or duration ago (24h, 7d, 2w)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "relevance",
+                            "newest",
+                            "oldest"
+                        ],
+                        "type": "string",
+                        "default": "relevance",
+                        "description": "Result order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "2026-10-02",
+                        "description": "Only articles created before this RFC 3339 time, date or duration ago",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "example": "1h",
+                        "description": "Only articles updated at or after this RFC 3339 time, date or duration ago",
+                        "name": "updated_since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SearchResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Entity tag of the result set"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Not Modified"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ProblemDetails"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Gateway Timeout",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ws": {
+            "get": {
+                "description": "Upgrade to a WebSocket speaking JSON messages with a \"type\" and an optional \"id\" echoed in replies. {\"type\":\"subscribe\",\"filters\":{...}} starts or replaces the subscription, with filters named and valued as the query parameters of GET /articles, e.g. {\"min_upvotes\":50,\"domain\":[\"github.com\"]}, and is answered with \"subscribed\" or an \"error\" listing the invalid filters. {\"type\":\"unsubscribe\"} stops events and is answered with \"unsubscribed\". {\"type\":\"ping\"} is answered with \"pong\". {\"type\":\"snapshot\",\"limit\":30} is answered with a \"snapshot\" of the newest articles matching the subscription. While subscribed, every saved article matching the filters is pushed as {\"type\":\"article\",\"event\":\"created\"|\"updated\",\"article\":{...}}. Clients that fall behind are disconnected with close code 1008 and should resubscribe and request a snapshot.",
+                "tags": [
+                    "Articles"
+                ],
+                "summary": "Subscribe over WebSocket",
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols"
+                    },
+                    "400": {
+                        "description": "Not a WebSocket handshake",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "models.Article": {
+            "type": "object",
+            "properties": {
+                "article_rank": {
+                    "type": "integer"
+                },
+                "comment_count": {
+                    "$ref": "#/definitions/models.NullableInt"
+                },
+                "comment_link": {
+                    "$ref": "#/definitions/models.NullableString"
+                },
+                "commit_hash": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "dead": {
+                    "type": "boolean"
+                },
+                "domain": {
+                    "type": "string"
+                },
+                "dupe": {
+                    "type": "boolean"
+                },
+                "flagged": {
+                    "type": "boolean"
+                },
+                "hn_id": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "model_name": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "summary": {
+                    "$ref": "#/definitions/models.NullableString"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "upvotes": {
+                    "$ref": "#/definitions/models.NullableInt"
+                }
+            }
+        },
+        "models.ArticleHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "article_id": {
+                    "description": "ID of the article",
+                    "type": "integer"
+                },
+                "code": {
+                    "description": "HTTP status code",
+                    "type": "integer"
+                },
+                "snapshots": {
+                    "description": "Snapshots in chronological order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ArticleSnapshot"
+                    }
+                },
+                "status": {
+                    "description": "Response status message",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ArticleResponse": {
+            "type": "object",
+            "properties": {
+                "article": {
+                    "description": "The requested article",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Article"
+                        }
+                    ]
+                },
+                "code": {
+                    "description": "HTTP status code",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Response status message",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ArticleSnapshot": {
+            "type": "object",
+            "properties": {
+                "article_rank": {
+                    "description": "Front page rank when captured",
+                    "type": "integer"
+                },
+                "captured_at": {
+                    "description": "When the article was seen",
+                    "type": "string"
+                },
+                "comment_count": {
+                    "description": "Comment count when captured",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NullableInt"
+                        }
+                    ]
+                },
+                "upvotes": {
+                    "description": "Upvotes when captured",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.NullableInt"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.ArticlesResponse": {
+            "type": "object",
+            "properties": {
+                "articles": {
+                    "description": "List of articles",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Article"
+                    }
+                },
+                "code": {
+                    "description": "HTTP status code",
+                    "type": "integer"
+                },
+                "has_more": {
+                    "description": "Whether more articles follow this page",
+                    "type": "boolean"
+                },
+                "limit": {
+                    "description": "Page size",
+                    "type": "integer"
+                },
+                "next": {
+                    "description": "URL of the next page, if any",
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "description": "Cursor for the next page, if any",
+                    "type": "string"
+                },
+                "offset": {
+                    "description": "Offset of this page",
+                    "type": "integer"
+                },
+                "prev": {
+                    "description": "URL of the previous page, if any",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Response status message",
+                    "type": "string"
+                },
+                "total_count": {
+                    "description": "Number of matching articles across all pages; omitted with count=false",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.AtomEntry": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Stable identifier",
+                    "type": "string"
+                },
+                "links": {
+                    "description": "Linked article and Hacker News discussion",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AtomLink"
+                    }
+                },
+                "published": {
+                    "description": "RFC 3339 creation time",
+                    "type": "string"
+                },
+                "summary": {
+                    "description": "HTML summary and metadata",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AtomText"
+                        }
+                    ]
+                },
+                "title": {
+                    "description": "Article title",
+                    "type": "string"
+                },
+                "updated": {
+                    "description": "RFC 3339 time of the last update",
+                    "type": "string"
+                }
+            }
+        },
+        "models.AtomFeed": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "Feed author, inherited by every entry",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AtomPerson"
+                        }
+                    ]
+                },
+                "entries": {
+                    "description": "Articles, newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AtomEntry"
+                    }
+                },
+                "id": {
+                    "description": "Permanent feed IRI",
+                    "type": "string"
+                },
+                "links": {
+                    "description": "The feed itself and the site it belongs to",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.AtomLink"
+                    }
+                },
+                "title": {
+                    "description": "Feed title",
+                    "type": "string"
+                },
+                "updated": {
+                    "description": "RFC 3339 time of the latest article update",
+                    "type": "string"
+                }
+            }
+        },
+        "models.AtomLink": {
+            "type": "object",
+            "properties": {
+                "href": {
+                    "type": "string"
+                },
+                "rel": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AtomPerson": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AtomText": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DomainStats": {
+            "type": "object",
+            "properties": {
+                "article_count": {
+                    "description": "Number of matching articles",
+                    "type": "integer"
+                },
+                "average_upvotes": {
+                    "description": "Mean upvotes of those articles, counting unknown as zero",
+                    "type": "number"
+                },
+                "domain": {
+                    "description": "Registrable domain, e.g. github.com",
+                    "type": "string"
+                }
+            }
+        },
+        "models.DomainsResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "HTTP status code",
+                    "type": "integer"
+                },
+                "domains": {
+                    "description": "Domains with the most articles first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.DomainStats"
+                    }
+                },
+                "status": {
+                    "description": "Response status message",
+                    "type": "string"
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "HTTP status code",
+                    "type": "integer"
+                },
+                "message": {
+                    "description": "Detailed error message",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Error status message",
+                    "type": "string"
+                }
+            }
+        },
+        "models.FacetCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Number of matching articles with the value",
+                    "type": "integer"
+                },
+                "value": {
+                    "description": "Facet value, empty when unrecorded",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Facets": {
+            "type": "object",
+            "properties": {
+                "commit_hash": {
+                    "description": "Counts per summarizing build, most first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FacetCount"
+                    }
+                },
+                "dead": {
+                    "description": "Counts by dead status",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.FlagCounts"
+                        }
+                    ]
+                },
+                "dupe": {
+                    "description": "Counts by duplicate status",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.FlagCounts"
+                        }
+                    ]
+                },
+                "flagged": {
+                    "description": "Counts by flagged status",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.FlagCounts"
+                        }
+                    ]
+                },
+                "model_name": {
+                    "description": "Counts per summarizing model, most first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FacetCount"
+                    }
+                },
+                "source": {
+                    "description": "Counts per source, most first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FacetCount"
+                    }
+                }
+            }
+        },
+        "models.FacetsResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "HTTP status code",
+                    "type": "integer"
+                },
+                "facets": {
+                    "description": "Counts per facet value",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Facets"
+                        }
+                    ]
+                },
+                "status": {
+                    "description": "Response status message",
+                    "type": "string"
+                },
+                "total_count": {
+                    "description": "Number of articles matching every filter",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.FlagCounts": {
+            "type": "object",
+            "properties": {
+                "false": {
+                    "description": "Number of matching articles without the flag",
+                    "type": "integer"
+                },
+                "true": {
+                    "description": "Number of matching articles with the flag set",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.IngestResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "HTTP status code",
+                    "type": "integer"
+                },
+                "rejected": {
+                    "description": "Number of articles rejected",
+                    "type": "integer"
+                },
+                "results": {
+                    "description": "Outcome of every article in request order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.IngestResult"
+                    }
+                },
+                "saved": {
+                    "description": "Number of articles saved",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "success if every article was saved, partial if some were, error if none were",
+                    "type": "string"
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.Article": {
+        },
+        "models.IngestResult": {
             "type": "object",
             "properties": {
-                "article_rank": {
-                    "description": "Article rank extracted from the source.",
+                "error": {
+                    "description": "Why the article was rejected",
+                    "type": "string"
+                },
+                "hn_id": {
+                    "description": "Hacker News ID of the article, or 0 if unknown",
                     "type": "integer"
                 },
-                "comment_count": {
-                    "description": "Number of comments.",
+                "index": {
+                    "description": "Position of the article in the request body",
                     "type": "integer"
                 },
-                "comment_link": {
-                    "description": "Link to the comment thread (if any).",
+                "status": {
+                    "description": "saved or rejected",
+                    "type": "string"
+                }
+            }
+        },
+        "models.InvalidParam": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "description": "Parameter name",
                     "type": "string"
                 },
-                "content": {
-                    "description": "Full content of the article.",
+                "reason": {
+                    "description": "Why the value was rejected",
+                    "type": "string"
+                }
+            }
+        },
+        "models.JSONFeed": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Feed description",
                     "type": "string"
                 },
-                "created_at": {
-                    "description": "Timestamp when the article was created.",
+                "feed_url": {
+                    "description": "URL of the feed itself",
                     "type": "string"
                 },
-                "dead": {
-                    "description": "Whether the article is dead.",
-                    "type": "boolean"
+                "home_page_url": {
+                    "description": "Site the feed belongs to",
+                    "type": "string"
                 },
-                "dupe": {
-                    "description": "Whether the article is marked as duplicate.",
-                    "type": "boolean"
+                "items": {
+                    "description": "Articles, newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.JSONFeedItem"
+                    }
                 },
-                "flagged": {
-                    "description": "Whether the article is flagged.",
-                    "type": "boolean"
+                "title": {
+                    "description": "Feed title",
+                    "type": "string"
                 },
-                "id": {
-                    "description": "Unique identifier for the article.",
-                    "type": "integer"
+                "version": {
+                    "description": "JSON Feed version URL",
+                    "type": "string"
+                }
+            }
+        },
+        "models.JSONFeedItem": {
+            "type": "object",
+            "properties": {
+                "content_html": {
+                    "description": "HTML summary and metadata",
+                    "type": "string"
                 },
-                "link": {
-                    "description": "URL link to the article.",
+                "date_modified": {
+                    "description": "RFC 3339 time of the last update",
                     "type": "string"
                 },
-                "source": {
-                    "description": "Source from where the article was fetched.",
+                "date_published": {
+                    "description": "RFC 3339 creation time",
+                    "type": "string"
+                },
+                "external_url": {
+                    "description": "Linked article",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Stable identifier",
                     "type": "string"
                 },
                 "summary": {
-                    "description": "Summary of the article, nullable.",
+                    "description": "Plain text summary",
                     "type": "string"
                 },
+                "tags": {
+                    "description": "Linked site",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "title": {
-                    "description": "Title of the article.",
+                    "description": "Article title",
                     "type": "string"
                 },
-                "updated_at": {
-                    "description": "Timestamp when the article was last updated.",
+                "url": {
+                    "description": "Hacker News discussion",
+                    "type": "string"
+                }
+            }
+        },
+        "models.NullableInt": {
+            "type": "object"
+        },
+        "models.NullableString": {
+            "type": "object"
+        },
+        "models.ProblemDetails": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "description": "Explanation of this occurrence",
                     "type": "string"
                 },
-                "upvotes": {
-                    "description": "Upvote count.",
+                "instance": {
+                    "description": "Request URI of this occurrence",
+                    "type": "string"
+                },
+                "invalid_params": {
+                    "description": "Every rejected request parameter",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.InvalidParam"
+                    }
+                },
+                "status": {
+                    "description": "HTTP status code",
                     "type": "integer"
+                },
+                "title": {
+                    "description": "Short summary of the problem type",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "URI identifying the problem type",
+                    "type": "string"
                 }
             }
         },
-        "models.ArticlesResponse": {
+        "models.RSSChannel": {
             "type": "object",
             "properties": {
-                "articles": {
-                    "description": "List of articles.",
+                "description": {
+                    "description": "Feed description",
+                    "type": "string"
+                },
+                "items": {
+                    "description": "Articles, newest first",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/models.Article"
+                        "$ref": "#/definitions/models.RSSItem"
                     }
                 },
+                "lastBuildDate": {
+                    "description": "RFC 1123 time of the latest article update",
+                    "type": "string"
+                },
+                "link": {
+                    "description": "Site the feed belongs to",
+                    "type": "string"
+                },
+                "selfLink": {
+                    "description": "URL of the feed itself",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.AtomLink"
+                        }
+                    ]
+                },
+                "title": {
+                    "description": "Feed title",
+                    "type": "string"
+                }
+            }
+        },
+        "models.RSSFeed": {
+            "type": "object",
+            "properties": {
+                "atomXMLNS": {
+                    "type": "string"
+                },
+                "channel": {
+                    "$ref": "#/definitions/models.RSSChannel"
+                },
+                "version": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RSSGUID": {
+            "type": "object",
+            "properties": {
+                "isPermaLink": {
+                    "type": "boolean"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RSSItem": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "description": "Hacker News discussion",
+                    "type": "string"
+                },
+                "description": {
+                    "description": "HTML summary and metadata",
+                    "type": "string"
+                },
+                "guid": {
+                    "description": "Stable identifier",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.RSSGUID"
+                        }
+                    ]
+                },
+                "link": {
+                    "description": "Linked article",
+                    "type": "string"
+                },
+                "pubDate": {
+                    "description": "RFC 1123 creation time",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "Article title",
+                    "type": "string"
+                }
+            }
+        },
+        "models.SearchResponse": {
+            "type": "object",
+            "properties": {
                 "code": {
-                    "description": "HTTP status code.",
+                    "description": "HTTP status code",
                     "type": "integer"
                 },
+                "query": {
+                    "description": "The search text",
+                    "type": "string"
+                },
+                "results": {
+                    "description": "Matching articles, best first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SearchResult"
+                    }
+                },
                 "status": {
-                    "description": "Status message.",
+                    "description": "Response status message",
                     "type": "string"
                 },
                 "total_count": {
-                    "description": "Total count of articles.",
+                    "description": "Number of results on this page",
                     "type": "integer"
                 }
             }
         },
-        "models.ErrorResponse": {
+        "models.SearchResult": {
+            "type": "object",
+            "properties": {
+                "article": {
+                    "description": "The matching article",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Article"
+                        }
+                    ]
+                },
+                "relevance": {
+                    "description": "How well the article matches; higher is better",
+                    "type": "number"
+                },
+                "snippet": {
+                    "description": "HTML excerpt of the summary with matches wrapped in \u003cmark\u003e",
+                    "type": "string"
+                }
+            }
+        },
+        "models.Webhook": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Whether deliveries are enqueued and sent",
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "filter": {
+                    "description": "Filter expression articles must match; empty matches every article",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "secret": {
+                    "description": "Key signing the deliveries; only returned when the webhook is created",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Endpoint receiving the deliveries",
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookDeliveriesResponse": {
             "type": "object",
             "properties": {
                 "code": {
-                    "description": "HTTP status code.",
+                    "description": "HTTP status code",
                     "type": "integer"
                 },
-                "message": {
-                    "description": "Detailed error message.",
+                "deliveries": {
+                    "description": "Deliveries, newest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.WebhookDelivery"
+                    }
+                },
+                "status": {
+                    "description": "Response status message",
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookDelivery": {
+            "type": "object",
+            "properties": {
+                "article_id": {
+                    "type": "integer"
+                },
+                "attempts": {
+                    "description": "Attempts made so far",
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "delivered_at": {
+                    "description": "When the receiver acknowledged the delivery",
+                    "type": "string"
+                },
+                "event": {
+                    "description": "\"article.created\" or \"article.updated\"",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_error": {
+                    "description": "Why the latest attempt failed",
+                    "type": "string"
+                },
+                "next_attempt_at": {
+                    "description": "When a pending delivery is attempted next",
+                    "type": "string"
+                },
+                "payload": {
+                    "description": "Signed JSON body, a WebhookPayload",
+                    "type": "object"
+                },
+                "response_status": {
+                    "description": "HTTP status of the latest response",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "pending, delivered or failed",
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "webhook_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.WebhookDeliveryResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "HTTP status code",
+                    "type": "integer"
+                },
+                "delivery": {
+                    "description": "The delivery",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.WebhookDelivery"
+                        }
+                    ]
+                },
+                "status": {
+                    "description": "Response status message",
+                    "type": "string"
+                }
+            }
+        },
+        "models.WebhookRequest": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "description": "Whether the webhook receives deliveries; defaults to true on creation",
+                    "type": "boolean"
+                },
+                "filter": {
+                    "description": "Filter expression; empty matches every article",
+                    "type": "string",
+                    "example": "min_upvotes\u003e=200 AND NOT flagged"
+                },
+                "secret": {
+                    "description": "Signing key; generated on creation and kept on update if empty",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Endpoint receiving the deliveries",
+                    "type": "string",
+                    "example": "https://example.com/hooks/gophersignal"
+                }
+            }
+        },
+        "models.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "HTTP status code",
+                    "type": "integer"
+                },
+                "status": {
+                    "description": "Response status message",
                     "type": "string"
                 },
+                "webhook": {
+                    "description": "The webhook",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.Webhook"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.WebhooksResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "HTTP status code",
+                    "type": "integer"
+                },
                 "status": {
-                    "description": "Error status message.",
+                    "description": "Response status message",
                     "type": "string"
+                },
+                "webhooks": {
+                    "description": "Registered webhooks, oldest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Webhook"
+                    }
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "BearerAuth": {
+            "description": "\"Bearer \u003cADMIN_TOKEN\u003e\" for the admin API, \"Bearer \u003cINGEST_TOKEN\u003e\" for article ingest",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
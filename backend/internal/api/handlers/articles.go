@@ -1,20 +1,29 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
 	"strconv"
+	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/k-zehnder/gophersignal/backend/config"
 	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/pubsub"
 	"github.com/k-zehnder/gophersignal/backend/internal/store"
+	"github.com/k-zehnder/gophersignal/backend/internal/webhooks"
 )
 
 // ArticlesHandler manages article-related HTTP requests.
 type ArticlesHandler struct {
-	Store  store.Store       // Store provides access to the data layer.
-	Config *config.AppConfig // Config provides application configuration.
+	Store    store.Store          // Store provides access to the data layer.
+	Config   *config.AppConfig    // Config provides application configuration.
+	Hub      *pubsub.Hub          // Hub announces saved articles to streams; nil disables streaming.
+	Webhooks *webhooks.Dispatcher // Webhooks keeps and delivers registered webhooks; nil disables the webhook admin API.
 }
 
 // NewArticlesHandler creates a new ArticlesHandler with the provided store and configuration.
@@ -37,126 +46,264 @@ func (h *ArticlesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 // GetArticles handles the HTTP request to retrieve articles.
 //
 // @Summary Get filtered articles
-// @Description Retrieve paginated articles with optional filters and thresholds
+// @Description Retrieve paginated articles with optional filters, thresholds and sort order. Pages are best walked with cursor, passing the next_cursor of the previous page, which stays stable while new articles arrive; offset is still accepted. Use fields or exclude to return only some article fields, e.g. exclude=content.
 // @Tags Articles
 // @Accept  json
 // @Produce  json
-// @Param   flagged       query   boolean  false  "Filter by flagged status"
-// @Param   dead          query   boolean  false  "Filter by dead status"
-// @Param   dupe          query   boolean  false  "Filter by duplicate status"
-// @Param   limit         query   integer  false  "Results per page (max 100)"  default(30) minimum(1) maximum(100)
-// @Param   offset        query   integer  false  "Pagination offset"            default(0) minimum(0)
-// @Param   min_upvotes   query   integer  false  "Minimum upvotes threshold"    default(0) minimum(0) format(int64)
-// @Param   min_comments  query   integer  false  "Minimum comments threshold"   default(0) minimum(0) format(int64)
+// @Param   params  query  ArticleListParams  false  "Filters, thresholds and pagination"
+// @Param   If-None-Match      header  string  false  "ETag of a cached copy"
 // @Success 200 {object} models.ArticlesResponse
-// @Failure 400 {object} models.ErrorResponse
+// @Success 304 "Not Modified"
+// @Header  200 {string} Link "RFC 8288 links to the next and previous pages"
+// @Header  200 {string} ETag "Entity tag of the page's result set"
+// @Failure 400 {object} models.ProblemDetails
 // @Failure 500 {object} models.ErrorResponse
+// @Failure 504 {object} models.ErrorResponse
 // @Router /articles [get]
 func (h *ArticlesHandler) GetArticles(w http.ResponseWriter, r *http.Request) {
-	q := r.URL.Query()
-
-	// Parse optional boolean filters.
-	var flagged *bool
-	if flaggedStr := q.Get("flagged"); flaggedStr != "" {
-		f, err := strconv.ParseBool(flaggedStr)
-		if err != nil {
-			h.jsonErrorResponse(w, models.ErrorResponse{
-				Code:    http.StatusBadRequest,
-				Status:  "error",
-				Message: fmt.Sprintf("Invalid flagged parameter: %s", flaggedStr),
-			}, http.StatusBadRequest)
-			return
-		}
-		flagged = &f
+	var params ArticleListParams
+	if invalid := bindQuery(r, &params); len(invalid) > 0 {
+		h.invalidParamsProblem(w, r, invalid)
+		return
 	}
-	var dead *bool
-	if deadStr := q.Get("dead"); deadStr != "" {
-		d, err := strconv.ParseBool(deadStr)
-		if err != nil {
-			h.jsonErrorResponse(w, models.ErrorResponse{
-				Code:    http.StatusBadRequest,
-				Status:  "error",
-				Message: fmt.Sprintf("Invalid dead parameter: %s", deadStr),
-			}, http.StatusBadRequest)
-			return
-		}
-		dead = &d
+	query := params.paginate(params.articleQuery())
+	query.Sort = store.SortOrder(params.Sort)
+	query.Order = store.SortDirection(params.Order)
+	query.Cursor = params.Cursor
+	query.Fields = params.articleFields()
+
+	ctx, cancel := h.queryContext(r)
+	defer cancel()
+
+	page, err := h.Store.Query(ctx, query)
+	if err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
 	}
-	var dupe *bool
-	if dupeStr := q.Get("dupe"); dupeStr != "" {
-		d, err := strconv.ParseBool(dupeStr)
+	var totalCount *int
+	if params.Count {
+		total, err := h.Store.Count(ctx, query)
 		if err != nil {
-			h.jsonErrorResponse(w, models.ErrorResponse{
-				Code:    http.StatusBadRequest,
-				Status:  "error",
-				Message: fmt.Sprintf("Invalid dupe parameter: %s", dupeStr),
-			}, http.StatusBadRequest)
+			h.storeErrorResponse(ctx, w, r, err)
 			return
 		}
-		dupe = &d
+		totalCount = &total
 	}
 
-	// Extract pagination parameters.
-	limit := 30
-	offset := 0
-	if lStr := q.Get("limit"); lStr != "" {
-		if l, err := strconv.Atoi(lStr); err == nil {
-			limit = l
-		}
+	next, prev := pageLinks(r, page)
+	setLinkHeader(w, next, prev)
+	h.setCacheHeaders(w, h.Config.CacheMaxAge)
+	total := -1
+	if totalCount != nil {
+		total = *totalCount
 	}
-	if oStr := q.Get("offset"); oStr != "" {
-		if o, err := strconv.Atoi(oStr); err == nil {
-			offset = o
-		}
+	setValidators(w, articlesETag(page.Articles, total, page.HasMore), time.Time{})
+	h.jsonResponse(w, models.ArticlesResponse{
+		Code:       http.StatusOK,
+		Status:     "success",
+		TotalCount: totalCount,
+		Limit:      page.Limit,
+		Offset:     page.Offset,
+		Next:       next,
+		Prev:       prev,
+		Articles:   page.Articles,
+		HasMore:    page.HasMore,
+		NextCursor: page.NextCursor,
+		Fields:     query.Fields,
+	}, http.StatusOK)
+}
+
+// GetArticle handles the HTTP request to retrieve a single article by its ID.
+//
+// @Summary Get an article
+// @Description Retrieve one article by its GopherSignal ID. Responses carry an ETag, and requests with a matching If-None-Match header get 304 Not Modified.
+// @Tags Articles
+// @Produce  json
+// @Param   id             path    integer  true   "Article ID"  minimum(1)
+// @Param   If-None-Match  header  string   false  "ETag of a cached copy"
+// @Success 200 {object} models.ArticleResponse
+// @Success 304 "Not Modified"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 504 {object} models.ErrorResponse
+// @Header  200 {string} ETag "Entity tag of the article"
+// @Router /articles/{id} [get]
+func (h *ArticlesHandler) GetArticle(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r, "id", "article ID")
+	if !ok {
+		return
 	}
+	h.serveArticle(w, r, func(ctx context.Context) (*models.Article, error) {
+		return h.Store.Article(ctx, id)
+	})
+}
 
-	// Extract optional threshold parameters.
-	// A value of 0 means no threshold filtering.
-	minUpvotes := 0
-	minComments := 0
-	if upStr := q.Get("min_upvotes"); upStr != "" {
-		if up, err := strconv.Atoi(upStr); err == nil {
-			minUpvotes = up
-		}
+// GetArticleByHNID handles the HTTP request to retrieve a single article by its Hacker News ID.
+//
+// @Summary Get an article by Hacker News ID
+// @Description Retrieve one article by the ID of its Hacker News item. Responses carry an ETag, and requests with a matching If-None-Match header get 304 Not Modified.
+// @Tags Articles
+// @Produce  json
+// @Param   hn_id          path    integer  true   "Hacker News item ID"  minimum(1)
+// @Param   If-None-Match  header  string   false  "ETag of a cached copy"
+// @Success 200 {object} models.ArticleResponse
+// @Success 304 "Not Modified"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 504 {object} models.ErrorResponse
+// @Header  200 {string} ETag "Entity tag of the article"
+// @Router /articles/hn/{hn_id} [get]
+func (h *ArticlesHandler) GetArticleByHNID(w http.ResponseWriter, r *http.Request) {
+	hnID, ok := h.pathID(w, r, "hn_id", "Hacker News ID")
+	if !ok {
+		return
 	}
-	if commStr := q.Get("min_comments"); commStr != "" {
-		if comm, err := strconv.Atoi(commStr); err == nil {
-			minComments = comm
-		}
+	h.serveArticle(w, r, func(ctx context.Context) (*models.Article, error) {
+		return h.Store.ArticleByHNID(ctx, hnID)
+	})
+}
+
+// serveArticle writes the article returned by lookup, honoring If-None-Match and If-Modified-Since.
+func (h *ArticlesHandler) serveArticle(w http.ResponseWriter, r *http.Request, lookup func(context.Context) (*models.Article, error)) {
+	ctx, cancel := h.queryContext(r)
+	defer cancel()
+
+	article, err := lookup(ctx)
+	if err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
 	}
 
-	var (
-		articles []*models.Article
-		err      error
-	)
+	h.setCacheHeaders(w, h.Config.CacheMaxAge)
+	setValidators(w, "", article.UpdatedAt)
+	h.jsonResponseWithETag(w, r, models.ArticleResponse{
+		Code:    http.StatusOK,
+		Status:  "success",
+		Article: article,
+	})
+}
 
-	// Determine which store method to call based on provided filters.
-	if (minUpvotes > 0 || minComments > 0) && (flagged != nil || dead != nil || dupe != nil) {
-		articles, err = h.Store.GetArticlesWithThresholdsAndFilters(limit, offset, minUpvotes, minComments, flagged, dead, dupe)
-	} else if minUpvotes > 0 || minComments > 0 {
-		articles, err = h.Store.GetArticlesWithThresholds(limit, offset, minUpvotes, minComments)
-	} else if flagged != nil || dead != nil || dupe != nil {
-		articles, err = h.Store.GetFilteredArticles(flagged, dead, dupe, limit, offset)
-	} else {
-		articles, err = h.Store.GetArticles(limit, offset)
+// pathID parses the positive integer path variable name, writing a 400 response if it is invalid.
+func (h *ArticlesHandler) pathID(w http.ResponseWriter, r *http.Request, name, description string) (int, bool) {
+	value := mux.Vars(r)[name]
+	id, err := strconv.Atoi(value)
+	if err != nil || id <= 0 {
+		h.jsonErrorResponse(w, models.ErrorResponse{
+			Code:    http.StatusBadRequest,
+			Status:  "error",
+			Message: fmt.Sprintf("Invalid %s: %s", description, value),
+		}, http.StatusBadRequest)
+		return 0, false
 	}
+	return id, true
+}
 
+// GetArticleHistory handles the HTTP request to retrieve the rank and score history of an article.
+//
+// @Summary Get article history
+// @Description Retrieve the time series of front page rank, upvotes and comment counts recorded each time the article was scraped
+// @Tags Articles
+// @Produce  json
+// @Param   id  path  integer  true  "Article ID"  minimum(1)
+// @Param   If-None-Match      header  string  false  "ETag of a cached copy"
+// @Param   If-Modified-Since  header  string  false  "Last-Modified time of a cached copy"
+// @Success 200 {object} models.ArticleHistoryResponse
+// @Success 304 "Not Modified"
+// @Header  200 {string} ETag "Entity tag of the history"
+// @Header  200 {string} Last-Modified "Capture time of the latest snapshot"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 504 {object} models.ErrorResponse
+// @Router /articles/{id}/history [get]
+func (h *ArticlesHandler) GetArticleHistory(w http.ResponseWriter, r *http.Request) {
+	id, ok := h.pathID(w, r, "id", "article ID")
+	if !ok {
+		return
+	}
+
+	ctx, cancel := h.queryContext(r)
+	defer cancel()
+
+	snapshots, err := h.Store.History(ctx, id)
 	if err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
+	}
+
+	// Snapshots are only ever appended, so the latest one identifies the history.
+	var lastCaptured time.Time
+	if len(snapshots) > 0 {
+		lastCaptured = snapshots[len(snapshots)-1].CapturedAt
+	}
+	h.setCacheHeaders(w, h.Config.CacheMaxAge)
+	setValidators(w, strongETag(fmt.Appendf(nil, "%d:%d@%d", id, len(snapshots), lastCaptured.UnixNano())), lastCaptured)
+	h.jsonResponse(w, models.ArticleHistoryResponse{
+		Code:      http.StatusOK,
+		Status:    "success",
+		ArticleID: id,
+		Snapshots: snapshots,
+	}, http.StatusOK)
+}
+
+// queryContext derives the context for store calls made on behalf of r,
+// bounded by the configured query timeout.
+func (h *ArticlesHandler) queryContext(r *http.Request) (context.Context, context.CancelFunc) {
+	if h.Config.QueryTimeout > 0 {
+		return context.WithTimeout(r.Context(), h.Config.QueryTimeout)
+	}
+	return context.WithCancel(r.Context())
+}
+
+// storeErrorResponse maps a failed store call to an HTTP response. Unsearchable texts and invalid
+// cursors yield 400 problem responses naming the q or cursor parameter, missing articles, webhooks
+// and deliveries yield 404 Not Found, and queries that ran past their deadline yield 504 Gateway
+// Timeout. Queries cancelled because the client went away are logged and dropped, since nobody is
+// left to read the response.
+func (h *ArticlesHandler) storeErrorResponse(ctx context.Context, w http.ResponseWriter, r *http.Request, err error) {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		err = ctxErr
+	}
+	switch {
+	case errors.Is(err, store.ErrInvalidSearch):
+		h.invalidParamsProblem(w, r, []models.InvalidParam{{Name: "q", Reason: err.Error()}})
+	case errors.Is(err, store.ErrInvalidCursor):
+		h.invalidParamsProblem(w, r, []models.InvalidParam{{Name: "cursor", Reason: err.Error()}})
+	case errors.Is(err, store.ErrArticleNotFound):
+		h.jsonErrorResponse(w, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Status:  "error",
+			Message: "Article not found",
+		}, http.StatusNotFound)
+	case errors.Is(err, store.ErrWebhookNotFound):
+		h.jsonErrorResponse(w, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Status:  "error",
+			Message: "Webhook not found",
+		}, http.StatusNotFound)
+	case errors.Is(err, store.ErrDeliveryNotFound):
+		h.jsonErrorResponse(w, models.ErrorResponse{
+			Code:    http.StatusNotFound,
+			Status:  "error",
+			Message: "Delivery not found",
+		}, http.StatusNotFound)
+	case errors.Is(err, context.DeadlineExceeded):
+		h.jsonErrorResponse(w, models.ErrorResponse{
+			Code:    http.StatusGatewayTimeout,
+			Status:  "error",
+			Message: "Query timed out",
+		}, http.StatusGatewayTimeout)
+	case errors.Is(err, context.Canceled):
+		log.Printf("Request cancelled: %s %s: %v", r.Method, r.URL.RequestURI(), err)
+	default:
 		h.jsonErrorResponse(w, models.ErrorResponse{
 			Code:    http.StatusInternalServerError,
 			Status:  "error",
 			Message: err.Error(),
 		}, http.StatusInternalServerError)
-		return
 	}
-
-	h.setCacheHeaders(w, h.Config.CacheMaxAge)
-	h.jsonResponse(w, models.ArticlesResponse{
-		Code:       http.StatusOK,
-		Status:     "success",
-		TotalCount: len(articles),
-		Articles:   articles,
-	}, http.StatusOK)
 }
 
 func (h *ArticlesHandler) setCacheHeaders(w http.ResponseWriter, maxAgeSeconds int) {
@@ -170,6 +317,29 @@ func (h *ArticlesHandler) jsonResponse(w http.ResponseWriter, response interface
 	json.NewEncoder(w).Encode(response)
 }
 
+// jsonResponseWithETag writes response with a 200 status and an ETag derived from its encoding,
+// or an empty 304 Not Modified if the request's preconditions match that ETag or any
+// Last-Modified header already set.
+func (h *ArticlesHandler) jsonResponseWithETag(w http.ResponseWriter, r *http.Request, response interface{}) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		h.jsonErrorResponse(w, models.ErrorResponse{
+			Code:    http.StatusInternalServerError,
+			Status:  "error",
+			Message: err.Error(),
+		}, http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("ETag", strongETag(body))
+	if notModified(r, w.Header()) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	w.Write(append(body, '\n'))
+}
+
 func (h *ArticlesHandler) jsonErrorResponse(w http.ResponseWriter, response models.ErrorResponse, statusCode int) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
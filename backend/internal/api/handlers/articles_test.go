@@ -2,12 +2,16 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/gorilla/mux"
 	"github.com/k-zehnder/gophersignal/backend/config"
 	"github.com/k-zehnder/gophersignal/backend/internal/models"
 	"github.com/k-zehnder/gophersignal/backend/internal/store"
@@ -17,7 +21,7 @@ import (
 func TestGetArticles_Success(t *testing.T) {
 	// Set up a mock store with predefined data.
 	mockStore := store.NewMockStore([]*models.Article{
-		{ID: 1, Title: "Test Article 1"},
+		{ID: 1, Title: "Test Article 1", Summary: models.NewNullableString("Summary 1")},
 	}, nil, nil)
 
 	cfg := config.NewConfig()
@@ -70,10 +74,12 @@ func TestServeHTTP_MethodNotAllowed(t *testing.T) {
 func TestGetArticles_WithQueryParams(t *testing.T) {
 	// Prepare articles with varying boolean fields.
 	articles := []*models.Article{
-		{ID: 1, Title: "Article 1", Flagged: false, Dead: false, Dupe: false},
+		{ID: 1, Title: "Article 1", Summary: models.NewNullableString("Summary 1"), Flagged: false, Dead: false, Dupe: false},
 		{ID: 2, Title: "Article 2", Flagged: true, Dead: false, Dupe: false},
-		{ID: 3, Title: "Article 3", Flagged: true, Dead: true, Dupe: false},
+		{ID: 3, Title: "Article 3", Flagged: true, Dead: false, Dupe: false},
 		{ID: 4, Title: "Article 4", Flagged: false, Dead: false, Dupe: true},
+		{ID: 5, Title: "Article 5", Summary: models.NewNullableString("Summary 5"), Flagged: false, Dead: false, Dupe: false},
+		{ID: 6, Title: "Article 6", Flagged: true, Dead: true, Dupe: false},
 	}
 	mockStore := store.NewMockStore(articles, nil, nil)
 	cfg := config.NewConfig()
@@ -91,9 +97,9 @@ func TestGetArticles_WithQueryParams(t *testing.T) {
 	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
-	// Expecting Articles 2 and 3.
-	if resp.TotalCount != 2 {
-		t.Errorf("Expected 2 articles for flagged=true, got %d", resp.TotalCount)
+	// Expecting Articles 2 and 3; Article 6 is dead and dead articles stay hidden.
+	if len(resp.Articles) != 2 || resp.TotalCount == nil || *resp.TotalCount != 2 {
+		t.Errorf("Expected 2 articles for flagged=true, got %d", len(resp.Articles))
 	}
 
 	// Test pagination: limit=2, offset=0.
@@ -106,8 +112,8 @@ func TestGetArticles_WithQueryParams(t *testing.T) {
 	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
-	if resp.TotalCount != 2 {
-		t.Errorf("Expected 2 articles for limit=2, offset=0, got %d", resp.TotalCount)
+	if len(resp.Articles) != 2 {
+		t.Errorf("Expected 2 articles for limit=2, offset=0, got %d", len(resp.Articles))
 	}
 }
 
@@ -117,24 +123,28 @@ func TestGetArticles_WithThresholdFilters(t *testing.T) {
 	articles := []*models.Article{
 		{
 			ID: 1, Title: "Article 1",
+			Summary:      models.NewNullableString("Summary 1"),
 			Upvotes:      models.NewNullableInt(5),
 			CommentCount: models.NewNullableInt(2),
 			Flagged:      false, Dead: false, Dupe: false,
 		},
 		{
 			ID: 2, Title: "Article 2",
+			Summary:      models.NewNullableString("Summary 2"),
 			Upvotes:      models.NewNullableInt(10),
 			CommentCount: models.NewNullableInt(5),
 			Flagged:      false, Dead: false, Dupe: false,
 		},
 		{
 			ID: 3, Title: "Article 3",
+			Summary:      models.NewNullableString("Summary 3"),
 			Upvotes:      models.NewNullableInt(15),
 			CommentCount: models.NewNullableInt(8),
 			Flagged:      false, Dead: false, Dupe: false,
 		},
 		{
 			ID: 4, Title: "Article 4",
+			Summary:      models.NewNullableString("Summary 4"),
 			Upvotes:      models.NewNullableInt(3),
 			CommentCount: models.NewNullableInt(1),
 			Flagged:      false, Dead: false, Dupe: false,
@@ -156,8 +166,8 @@ func TestGetArticles_WithThresholdFilters(t *testing.T) {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
 	// Expecting Articles 2 and 3.
-	if resp.TotalCount != 2 {
-		t.Errorf("Expected 2 articles for min_upvotes=10 and min_comments=5, got %d", resp.TotalCount)
+	if len(resp.Articles) != 2 || resp.TotalCount == nil || *resp.TotalCount != 2 {
+		t.Errorf("Expected 2 articles for min_upvotes=10 and min_comments=5, got %d", len(resp.Articles))
 	}
 }
 
@@ -167,24 +177,28 @@ func TestGetArticles_WithCombinedFilters(t *testing.T) {
 	articles := []*models.Article{
 		{
 			ID: 1, Title: "Article 1",
+			Summary:      models.NewNullableString("Summary 1"),
 			Upvotes:      models.NewNullableInt(10),
 			CommentCount: models.NewNullableInt(5),
 			Flagged:      true, Dead: false, Dupe: false,
 		},
 		{
 			ID: 2, Title: "Article 2",
+			Summary:      models.NewNullableString("Summary 2"),
 			Upvotes:      models.NewNullableInt(20),
 			CommentCount: models.NewNullableInt(10),
 			Flagged:      true, Dead: false, Dupe: false,
 		},
 		{
 			ID: 3, Title: "Article 3",
+			Summary:      models.NewNullableString("Summary 3"),
 			Upvotes:      models.NewNullableInt(5),
 			CommentCount: models.NewNullableInt(2),
 			Flagged:      true, Dead: false, Dupe: false,
 		},
 		{
 			ID: 4, Title: "Article 4",
+			Summary:      models.NewNullableString("Summary 4"),
 			Upvotes:      models.NewNullableInt(25),
 			CommentCount: models.NewNullableInt(15),
 			Flagged:      false, Dead: false, Dupe: false,
@@ -206,7 +220,490 @@ func TestGetArticles_WithCombinedFilters(t *testing.T) {
 		t.Fatalf("Failed to decode response: %v", err)
 	}
 	// Expected: Only articles that are flagged true and meet thresholds: Articles 1 and 2.
-	if resp.TotalCount != 2 {
-		t.Errorf("Expected 2 articles for combined filters, got %d", resp.TotalCount)
+	if len(resp.Articles) != 2 || resp.TotalCount == nil || *resp.TotalCount != 2 {
+		t.Errorf("Expected 2 articles for combined filters, got %d", len(resp.Articles))
+	}
+}
+
+// TestGetArticles_Timeout tests that a store query running past its deadline maps to 504 Gateway Timeout.
+func TestGetArticles_Timeout(t *testing.T) {
+	mockStore := store.NewMockStore(nil, nil, fmt.Errorf("failed to execute query: %w", context.DeadlineExceeded))
+	cfg := config.NewConfig()
+	handler := NewArticlesHandler(mockStore, cfg)
+
+	req := httptest.NewRequest("GET", "/dummy-url", nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusGatewayTimeout {
+		t.Fatalf("Expected status 504, got %d", rr.Code)
+	}
+	var resp models.ErrorResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Code != http.StatusGatewayTimeout {
+		t.Errorf("Expected error code 504, got %d", resp.Code)
+	}
+}
+
+// TestGetArticles_ClientCancelled tests that no response is written once the client has gone away.
+func TestGetArticles_ClientCancelled(t *testing.T) {
+	mockStore := store.NewMockStore([]*models.Article{
+		{ID: 1, Title: "Test Article 1", Summary: models.NewNullableString("Summary 1")},
+	}, nil, nil)
+	cfg := config.NewConfig()
+	handler := NewArticlesHandler(mockStore, cfg)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	req := httptest.NewRequest("GET", "/dummy-url", nil).WithContext(ctx)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	if rr.Body.Len() != 0 {
+		t.Errorf("Expected no response body for a cancelled request, got %q", rr.Body.String())
+	}
+}
+
+// TestGetArticleHistory tests that an article's snapshots are returned in order.
+func TestGetArticleHistory(t *testing.T) {
+	mockStore := store.NewMockStore(nil, nil, nil)
+	for _, upvotes := range []int64{10, 40} {
+		article := &models.Article{HNID: 7, Title: "Climbing", Upvotes: models.NewNullableInt(upvotes)}
+		if _, err := mockStore.SaveArticles(context.Background(), []*models.Article{article}, store.SaveAtomic); err != nil {
+			t.Fatalf("SaveArticles failed: %v", err)
+		}
+	}
+	handler := NewArticlesHandler(mockStore, config.NewConfig())
+
+	req := mux.SetURLVars(httptest.NewRequest("GET", "/dummy-url", nil), map[string]string{"id": "1"})
+	rr := httptest.NewRecorder()
+	handler.GetArticleHistory(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rr.Code)
+	}
+	var resp models.ArticleHistoryResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.ArticleID != 1 || len(resp.Snapshots) != 2 {
+		t.Fatalf("Expected 2 snapshots of article 1, got %+v", resp)
+	}
+	if resp.Snapshots[0].Upvotes.Int64 != 10 || resp.Snapshots[1].Upvotes.Int64 != 40 {
+		t.Errorf("Expected upvotes [10 40], got [%d %d]", resp.Snapshots[0].Upvotes.Int64, resp.Snapshots[1].Upvotes.Int64)
+	}
+}
+
+// TestGetArticleHistory_Errors tests the responses for invalid and unknown article IDs.
+func TestGetArticleHistory_Errors(t *testing.T) {
+	handler := NewArticlesHandler(store.NewMockStore(nil, nil, nil), config.NewConfig())
+	for id, expected := range map[string]int{"abc": http.StatusBadRequest, "0": http.StatusBadRequest, "42": http.StatusNotFound} {
+		req := mux.SetURLVars(httptest.NewRequest("GET", "/dummy-url", nil), map[string]string{"id": id})
+		rr := httptest.NewRecorder()
+		handler.GetArticleHistory(rr, req)
+		if rr.Code != expected {
+			t.Errorf("ID %q: expected status %d, got %d", id, expected, rr.Code)
+		}
+	}
+}
+
+// TestGetArticle tests single-article lookups by ID and by Hacker News ID.
+func TestGetArticle(t *testing.T) {
+	mockStore := store.NewMockStore([]*models.Article{
+		{ID: 1, HNID: 100, Title: "First"},
+		{ID: 2, HNID: 200, Title: "Flagged", Flagged: true},
+	}, nil, nil)
+	handler := NewArticlesHandler(mockStore, config.NewConfig())
+
+	cases := []struct {
+		name  string
+		serve http.HandlerFunc
+		vars  map[string]string
+		title string
+		code  int
+	}{
+		{"ByID", handler.GetArticle, map[string]string{"id": "2"}, "Flagged", http.StatusOK},
+		{"ByHNID", handler.GetArticleByHNID, map[string]string{"hn_id": "100"}, "First", http.StatusOK},
+		{"MissingID", handler.GetArticle, map[string]string{"id": "3"}, "", http.StatusNotFound},
+		{"MissingHNID", handler.GetArticleByHNID, map[string]string{"hn_id": "300"}, "", http.StatusNotFound},
+		{"InvalidID", handler.GetArticle, map[string]string{"id": "x"}, "", http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := mux.SetURLVars(httptest.NewRequest("GET", "/dummy-url", nil), tc.vars)
+			rr := httptest.NewRecorder()
+			tc.serve(rr, req)
+
+			if rr.Code != tc.code {
+				t.Fatalf("Expected status %d, got %d", tc.code, rr.Code)
+			}
+			if tc.code != http.StatusOK {
+				var resp models.ErrorResponse
+				if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil || resp.Code != tc.code || resp.Status != "error" {
+					t.Errorf("Expected an ErrorResponse with code %d, got %+v (%v)", tc.code, resp, err)
+				}
+				return
+			}
+			var resp models.ArticleResponse
+			if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if resp.Article == nil || resp.Article.Title != tc.title {
+				t.Errorf("Expected article %q, got %+v", tc.title, resp.Article)
+			}
+			if rr.Header().Get("ETag") == "" {
+				t.Error("Expected an ETag header")
+			}
+		})
+	}
+}
+
+// TestGetArticle_NotModified tests that a matching If-None-Match header yields 304 Not Modified.
+func TestGetArticle_NotModified(t *testing.T) {
+	mockStore := store.NewMockStore([]*models.Article{{ID: 1, Title: "First"}}, nil, nil)
+	handler := NewArticlesHandler(mockStore, config.NewConfig())
+	vars := map[string]string{"id": "1"}
+
+	rr := httptest.NewRecorder()
+	handler.GetArticle(rr, mux.SetURLVars(httptest.NewRequest("GET", "/dummy-url", nil), vars))
+	etag := rr.Header().Get("ETag")
+
+	for header, expected := range map[string]int{
+		etag:               http.StatusNotModified,
+		"W/" + etag:        http.StatusNotModified,
+		`"other", ` + etag: http.StatusNotModified,
+		"*":                http.StatusNotModified,
+		`"other"`:          http.StatusOK,
+	} {
+		req := mux.SetURLVars(httptest.NewRequest("GET", "/dummy-url", nil), vars)
+		req.Header.Set("If-None-Match", header)
+		rr := httptest.NewRecorder()
+		handler.GetArticle(rr, req)
+		if rr.Code != expected {
+			t.Errorf("If-None-Match %s: expected status %d, got %d", header, expected, rr.Code)
+		}
+		if expected == http.StatusNotModified && rr.Body.Len() != 0 {
+			t.Errorf("If-None-Match %s: expected an empty body, got %q", header, rr.Body.String())
+		}
+	}
+
+	mockStore.Articles[0].Title = "Edited"
+	req := mux.SetURLVars(httptest.NewRequest("GET", "/dummy-url", nil), vars)
+	req.Header.Set("If-None-Match", etag)
+	rr = httptest.NewRecorder()
+	handler.GetArticle(rr, req)
+	if rr.Code != http.StatusOK || rr.Header().Get("ETag") == etag {
+		t.Errorf("Expected a new ETag after the article changed, got status %d", rr.Code)
+	}
+}
+
+// TestSearchArticles tests the search endpoint's results and parameter validation.
+func TestSearchArticles(t *testing.T) {
+	mockStore := store.NewMockStore([]*models.Article{
+		{ID: 1, Title: "Rust in production", Summary: models.NewNullableString("Shipping Rust services.")},
+		{ID: 2, Title: "Go generics", Summary: models.NewNullableString("Type parameters in Go.")},
+	}, nil, nil)
+	handler := NewArticlesHandler(mockStore, config.NewConfig())
+
+	rr := httptest.NewRecorder()
+	handler.SearchArticles(rr, httptest.NewRequest("GET", "/api/v1/search?q=rust", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rr.Code)
+	}
+	var resp models.SearchResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.Query != "rust" || resp.TotalCount != 1 || resp.Results[0].Article.ID != 1 {
+		t.Fatalf("Unexpected response: %+v", resp)
+	}
+	if resp.Results[0].Snippet != "Shipping <mark>Rust</mark> services." {
+		t.Errorf("Unexpected snippet: %q", resp.Results[0].Snippet)
+	}
+
+	for _, target := range []string{"/api/v1/search", "/api/v1/search?q=%22%22", "/api/v1/search?q=go&sort=sideways", "/api/v1/search?q=go&flagged=maybe"} {
+		rr := httptest.NewRecorder()
+		handler.SearchArticles(rr, httptest.NewRequest("GET", target, nil))
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", target, rr.Code)
+		}
+	}
+}
+
+// TestGetDomains tests the per-domain summary and the domain filters of the list.
+func TestGetDomains(t *testing.T) {
+	mockStore := store.NewMockStore([]*models.Article{
+		{ID: 1, Title: "Go", Link: "https://github.com/golang/go", Summary: models.NewNullableString("Summary"), Upvotes: models.NewNullableInt(10)},
+		{ID: 2, Title: "Gist", Link: "https://gist.github.com/x", Summary: models.NewNullableString("Summary"), Upvotes: models.NewNullableInt(30)},
+		{ID: 3, Title: "LWN", Link: "https://lwn.net/Articles/1/", Summary: models.NewNullableString("Summary"), Upvotes: models.NewNullableInt(5)},
+	}, nil, nil)
+	handler := NewArticlesHandler(mockStore, config.NewConfig())
+
+	rr := httptest.NewRecorder()
+	handler.GetDomains(rr, httptest.NewRequest("GET", "/api/v1/domains", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d", rr.Code)
+	}
+	var resp models.DomainsResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(resp.Domains) != 2 || *resp.Domains[0] != (models.DomainStats{Domain: "github.com", ArticleCount: 2, AverageUpvotes: 20}) {
+		t.Fatalf("Unexpected response: %+v", resp.Domains)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/articles?exclude_domain=github.com", nil))
+	var list models.ArticlesResponse
+	if err := json.NewDecoder(rr.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(list.Articles) != 1 || list.Articles[0].ID != 3 {
+		t.Errorf("Expected only the LWN article, got %+v", list.Articles)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.GetDomains(rr, httptest.NewRequest("GET", "/api/v1/domains?limit=0", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400, got %d", rr.Code)
+	}
+}
+
+// TestGetFacets tests the facet counts and the summarizer filters of the list.
+func TestGetFacets(t *testing.T) {
+	article := func(id int, model, commit string, flagged bool) *models.Article {
+		return &models.Article{ID: id, Title: fmt.Sprintf("Article %d", id), Summary: models.NewNullableString("Summary"),
+			Source: "Hacker News", ModelName: model, CommitHash: commit, Flagged: flagged}
+	}
+	handler := NewArticlesHandler(store.NewMockStore([]*models.Article{
+		article(1, "llama3:8b", "abc1234", false),
+		article(2, "llama3:8b", "def5678", false),
+		article(3, "mistral", "def5678", false),
+		article(4, "mistral", "def5678", true),
+	}, nil, nil), config.NewConfig())
+
+	rr := httptest.NewRecorder()
+	handler.GetFacets(rr, httptest.NewRequest("GET", "/api/v1/facets?model_name=mistral", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body)
+	}
+	var resp models.FacetsResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if resp.TotalCount != 1 {
+		t.Errorf("Expected 1 matching article, got %d", resp.TotalCount)
+	}
+	// The model facet ignores the model filter, and the flagged facet the default flagged filter.
+	if len(resp.Facets.ModelName) != 2 || *resp.Facets.ModelName[0] != (models.FacetCount{Value: "llama3:8b", Count: 2}) {
+		t.Errorf("Unexpected model facet: %+v", resp.Facets.ModelName)
+	}
+	if len(resp.Facets.CommitHash) != 1 || *resp.Facets.CommitHash[0] != (models.FacetCount{Value: "def5678", Count: 1}) {
+		t.Errorf("Unexpected commit facet: %+v", resp.Facets.CommitHash)
+	}
+	if resp.Facets.Flagged != (models.FlagCounts{True: 1, False: 1}) || resp.Facets.Dead != (models.FlagCounts{False: 1}) {
+		t.Errorf("Unexpected flag facets: %+v %+v", resp.Facets.Flagged, resp.Facets.Dead)
+	}
+
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/articles?commit_hash=def5678&model_name=llama3:8b,mistral", nil))
+	var list models.ArticlesResponse
+	if err := json.NewDecoder(rr.Body).Decode(&list); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	if len(list.Articles) != 2 || list.Articles[0].ID != 3 || list.Articles[1].ID != 2 {
+		t.Errorf("Expected articles 3 and 2, got %+v", list.Articles)
+	}
+}
+
+// TestGetArticles_Fields tests that sparse fieldsets trim the encoded articles.
+func TestGetArticles_Fields(t *testing.T) {
+	handler := NewArticlesHandler(store.NewMockStore([]*models.Article{
+		{ID: 1, Title: "Article 1", Content: "Long content", Summary: models.NewNullableString("Summary"), Link: "https://example.com/1"},
+	}, nil, nil), config.NewConfig())
+
+	for target, expected := range map[string]string{
+		"/api/v1/articles?fields=id,title,summary":                   `[{"id":1,"title":"Article 1","summary":"Summary"}]`,
+		"/api/v1/articles?fields=link,content,title&exclude=content": `[{"title":"Article 1","link":"https://example.com/1"}]`,
+	} {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", target, nil))
+		var resp struct {
+			Articles json.RawMessage `json:"articles"`
+		}
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if string(resp.Articles) != expected {
+			t.Errorf("%s: expected articles %s, got %s", target, expected, resp.Articles)
+		}
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/articles?exclude=content", nil))
+	if body := rr.Body.String(); strings.Contains(body, "Long content") || !strings.Contains(body, `"hn_id":0`) {
+		t.Errorf("Expected everything but the content, got %s", body)
+	}
+}
+
+// TestGetArticles_Cursor tests that next_cursor and has_more walk the list page by page.
+func TestGetArticles_Cursor(t *testing.T) {
+	articles := make([]*models.Article, 5)
+	for i := range articles {
+		articles[i] = &models.Article{ID: i + 1, Title: fmt.Sprintf("Article %d", i+1), Summary: models.NewNullableString("Summary")}
+	}
+	handler := NewArticlesHandler(store.NewMockStore(articles, nil, nil), config.NewConfig())
+
+	var ids []int
+	target := "/api/v1/articles?limit=2"
+	for pages := 0; target != ""; pages++ {
+		if pages > 3 {
+			t.Fatal("Expected pagination to end")
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", target, nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d", rr.Code)
+		}
+		var resp models.ArticlesResponse
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		for _, article := range resp.Articles {
+			ids = append(ids, article.ID)
+		}
+		if resp.HasMore != (resp.NextCursor != "") {
+			t.Errorf("Expected has_more to accompany next_cursor, got %+v", resp)
+		}
+		target = ""
+		if resp.HasMore {
+			target = "/api/v1/articles?limit=2&cursor=" + resp.NextCursor
+		}
+	}
+	if fmt.Sprint(ids) != "[5 4 3 2 1]" {
+		t.Errorf("Expected IDs [5 4 3 2 1], got %v", ids)
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/articles?cursor=garbage", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid cursor, got %d", rr.Code)
+	}
+}
+
+// TestGetArticles_TotalCountAndLinks tests the true total, page metadata and Link headers.
+func TestGetArticles_TotalCountAndLinks(t *testing.T) {
+	articles := make([]*models.Article, 7)
+	for i := range articles {
+		articles[i] = &models.Article{ID: i + 1, Title: fmt.Sprintf("Article %d", i+1), Summary: models.NewNullableString("Summary")}
+	}
+	articles[6].Flagged = true
+	handler := NewArticlesHandler(store.NewMockStore(articles, nil, nil), config.NewConfig())
+
+	get := func(target string) (models.ArticlesResponse, http.Header) {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", target, nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d", target, rr.Code)
+		}
+		var resp models.ArticlesResponse
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp, rr.Header()
+	}
+
+	resp, header := get("/api/v1/articles?limit=2&offset=2")
+	if resp.TotalCount == nil || *resp.TotalCount != 6 {
+		t.Fatalf("Expected a total of 6 visible articles, got %v", resp.TotalCount)
+	}
+	if resp.Limit != 2 || resp.Offset != 2 || len(resp.Articles) != 2 {
+		t.Errorf("Unexpected page metadata: %+v", resp)
+	}
+	if resp.Next != "/api/v1/articles?limit=2&offset=4" || resp.Prev != "/api/v1/articles?limit=2&offset=0" {
+		t.Errorf("Unexpected links: next %q, prev %q", resp.Next, resp.Prev)
+	}
+	expected := `</api/v1/articles?limit=2&offset=4>; rel="next", </api/v1/articles?limit=2&offset=0>; rel="prev"`
+	if got := header.Get("Link"); got != expected {
+		t.Errorf("Expected Link %q, got %q", expected, got)
+	}
+
+	resp, header = get("/api/v1/articles?limit=2&offset=4")
+	if resp.Next != "" || header.Get("Link") != `</api/v1/articles?limit=2&offset=2>; rel="prev"` {
+		t.Errorf("Expected only a prev link on the last page, got next %q, Link %q", resp.Next, header.Get("Link"))
+	}
+
+	resp, _ = get("/api/v1/articles?limit=2&cursor=" + resp.NextCursor + "&count=false")
+	if resp.TotalCount != nil {
+		t.Errorf("Expected no total with count=false, got %d", *resp.TotalCount)
+	}
+
+	first, _ := get("/api/v1/articles?limit=2")
+	if first.Next != "/api/v1/articles?cursor="+first.NextCursor+"&limit=2" || first.Prev != "" {
+		t.Errorf("Expected the first page to link to the next by cursor, got next %q, prev %q", first.Next, first.Prev)
+	}
+	second, _ := get("/api/v1/articles?limit=2&cursor=" + first.NextCursor)
+	if second.Next != "/api/v1/articles?cursor="+second.NextCursor+"&limit=2" || second.Prev != "" {
+		t.Errorf("Expected a cursor next link and no prev link, got next %q, prev %q", second.Next, second.Prev)
+	}
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/articles?count=maybe", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an invalid count, got %d", rr.Code)
+	}
+}
+
+// TestGetArticles_Sort tests sorting the list, alone and together with filters and cursors.
+func TestGetArticles_Sort(t *testing.T) {
+	articles := make([]*models.Article, 5)
+	for i := range articles {
+		articles[i] = &models.Article{ID: i + 1, Title: fmt.Sprintf("Article %d", i+1), Summary: models.NewNullableString("Summary"),
+			Upvotes: models.NewNullableInt(int64(10 * ((i + 3) % 5)))}
+	}
+	handler := NewArticlesHandler(store.NewMockStore(articles, nil, nil), config.NewConfig())
+
+	get := func(target string) models.ArticlesResponse {
+		t.Helper()
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", target, nil))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected status 200, got %d: %s", target, rr.Code, rr.Body)
+		}
+		var resp models.ArticlesResponse
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		return resp
+	}
+	ids := func(resp models.ArticlesResponse) []int {
+		var ids []int
+		for _, article := range resp.Articles {
+			ids = append(ids, article.ID)
+		}
+		return ids
+	}
+
+	// Upvotes by ID: 1→30, 2→40, 3→0, 4→10, 5→20.
+	if got := ids(get("/api/v1/articles?sort=upvotes")); fmt.Sprint(got) != "[2 1 5 4 3]" {
+		t.Errorf("Expected most upvoted first, got %v", got)
+	}
+	if got := ids(get("/api/v1/articles?sort=upvotes&order=asc&min_upvotes=10")); fmt.Sprint(got) != "[4 5 1 2]" {
+		t.Errorf("Expected least upvoted first above the threshold, got %v", got)
+	}
+	first := get("/api/v1/articles?sort=upvotes&limit=2")
+	if got := ids(get("/api/v1/articles?sort=upvotes&limit=2&cursor=" + first.NextCursor)); fmt.Sprint(got) != "[5 4]" {
+		t.Errorf("Expected the cursor to continue the upvotes order, got %v", got)
+	}
+
+	for _, target := range []string{"/api/v1/articles?sort=best", "/api/v1/articles?sort=upvotes&order=up", "/api/v1/articles?sort=oldest&cursor=" + first.NextCursor} {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", target, nil))
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", target, rr.Code)
+		}
 	}
 }
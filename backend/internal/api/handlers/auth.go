@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// RequireToken returns middleware admitting only requests that carry token as a bearer token in
+// their Authorization header. Others get 401 Unauthorized. With an empty token every request is
+// refused with 403 Forbidden, so that routes behind it stay closed until a token is configured.
+func RequireToken(token string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if token == "" {
+				writeAuthError(w, http.StatusForbidden, "API disabled: no access token is configured")
+				return
+			}
+			scheme, credentials, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+			if !strings.EqualFold(scheme, "Bearer") || subtle.ConstantTimeCompare([]byte(strings.TrimSpace(credentials)), []byte(token)) != 1 {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="gophersignal"`)
+				writeAuthError(w, http.StatusUnauthorized, "Missing or invalid bearer token")
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// writeAuthError writes a JSON error response refusing a request.
+func writeAuthError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(models.ErrorResponse{
+		Code:    statusCode,
+		Status:  "error",
+		Message: message,
+	})
+}
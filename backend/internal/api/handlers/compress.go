@@ -0,0 +1,247 @@
+package handlers
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// encoder is a compressor that can be reused for another response once closed.
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(io.Writer)
+}
+
+// encoderPools hold idle encoders per content coding, in order of preference.
+var encoderPools = []struct {
+	coding string
+	pool   *sync.Pool
+}{
+	{"br", &sync.Pool{New: func() interface{} { return brotli.NewWriterLevel(nil, 4) }}},
+	{"zstd", &sync.Pool{New: func() interface{} {
+		w, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1))
+		return w
+	}}},
+	{"gzip", &sync.Pool{New: func() interface{} { return gzip.NewWriter(nil) }}},
+}
+
+// Compress returns middleware compressing responses of at least minSize bytes with the content
+// coding the client prefers among br, zstd and gzip. Only textual media types are compressed,
+// and every such response varies by Accept-Encoding. Responses a handler or proxy closer to the
+// application already encoded pass through untouched, so nothing is compressed twice; a proxy in
+// front, such as nginx, likewise leaves encoded responses alone. Event streams and protocol
+// upgrades are never buffered. A minSize of 0 disables compression.
+func Compress(minSize int) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if minSize <= 0 {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+			coding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+			cw := &compressWriter{ResponseWriter: w, coding: coding, minSize: minSize}
+			defer cw.Close()
+			next.ServeHTTP(cw, r)
+		})
+	}
+}
+
+// negotiateEncoding returns the supported content coding the Accept-Encoding header prefers,
+// breaking ties by encoderPools order, or "" if identity should be used.
+func negotiateEncoding(header string) string {
+	qualities := make(map[string]float64)
+	for _, part := range strings.Split(header, ",") {
+		coding, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if coding = strings.ToLower(strings.TrimSpace(coding)); coding != "" {
+			qualities[coding] = q
+		}
+	}
+
+	best, bestQ := "", 0.0
+	for _, pool := range encoderPools {
+		q, ok := qualities[pool.coding]
+		if !ok {
+			q, ok = qualities["*"]
+		}
+		if ok && q > bestQ {
+			best, bestQ = pool.coding, q
+		}
+	}
+	return best
+}
+
+// compressibleType reports whether responses of the media type benefit from compression.
+func compressibleType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mediaType == "text/event-stream" {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "json") ||
+		strings.HasSuffix(mediaType, "xml") ||
+		mediaType == "application/javascript"
+}
+
+// compressWriter buffers a response until it is known to reach the minimum size, then
+// compresses it with coding, or writes it as is if it ends up smaller.
+type compressWriter struct {
+	http.ResponseWriter
+	coding  string
+	minSize int
+
+	status      int
+	wroteHeader bool    // WriteHeader was called by the handler.
+	decided     bool    // The status and headers were written to the ResponseWriter.
+	buf         []byte  // Body written before deciding.
+	encoder     encoder // Compressor of the body, if compressing.
+}
+
+// WriteHeader records status and decides at once for responses that are not to be compressed.
+func (w *compressWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	w.status = status
+
+	header := w.Header()
+	compressible := compressibleType(header.Get("Content-Type"))
+	if compressible {
+		header.Add("Vary", "Accept-Encoding")
+	}
+	hasBody := status >= http.StatusOK && status != http.StatusNoContent && status != http.StatusNotModified
+	if !compressible || !hasBody || header.Get("Content-Encoding") != "" || w.coding == "" {
+		w.decide(false)
+		return
+	}
+	if length, err := strconv.Atoi(header.Get("Content-Length")); err == nil {
+		w.decide(length >= w.minSize)
+	}
+}
+
+// Write buffers b until the response reaches the minimum size, then writes it through the encoder.
+func (w *compressWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		if w.Header().Get("Content-Type") == "" {
+			w.Header().Set("Content-Type", http.DetectContentType(b))
+		}
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.decided {
+		w.buf = append(w.buf, b...)
+		if len(w.buf) >= w.minSize {
+			w.decide(true)
+			if err := w.flushBuffer(); err != nil {
+				return 0, err
+			}
+		}
+		return len(b), nil
+	}
+	if w.encoder != nil {
+		return w.encoder.Write(b)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// decide writes the status and headers, starting the encoder if compress is set.
+func (w *compressWriter) decide(compress bool) {
+	w.decided = true
+	if compress {
+		header := w.Header()
+		header.Set("Content-Encoding", w.coding)
+		header.Del("Content-Length")
+		// The compressed bytes differ from those a strong ETag names; weaken it as nginx does,
+		// so that If-None-Match, which compares weakly, still matches.
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			header.Set("ETag", "W/"+etag)
+		}
+		w.encoder = w.pool().Get().(encoder)
+		w.encoder.Reset(w.ResponseWriter)
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+}
+
+// flushBuffer writes the buffered body through the decided path.
+func (w *compressWriter) flushBuffer() error {
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	if w.encoder != nil {
+		_, err = w.encoder.Write(buf)
+	} else {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// Flush sends everything written so far, compressing it if the response has reached the minimum size.
+func (w *compressWriter) Flush() {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if !w.decided {
+		w.decide(len(w.buf) >= w.minSize)
+		w.flushBuffer()
+	}
+	if w.encoder != nil {
+		w.encoder.Flush()
+	}
+	http.NewResponseController(w.ResponseWriter).Flush()
+}
+
+// Close finishes the response, writing small bodies as is and returning the encoder to its pool.
+func (w *compressWriter) Close() error {
+	if !w.wroteHeader {
+		return nil
+	}
+	if !w.decided {
+		w.decide(false)
+	}
+	err := w.flushBuffer()
+	if w.encoder != nil {
+		if closeErr := w.encoder.Close(); err == nil {
+			err = closeErr
+		}
+		w.encoder.Reset(nil)
+		w.pool().Put(w.encoder)
+		w.encoder = nil
+	}
+	return err
+}
+
+// pool returns the encoder pool of the negotiated coding.
+func (w *compressWriter) pool() *sync.Pool {
+	for _, pool := range encoderPools {
+		if pool.coding == w.coding {
+			return pool.pool
+		}
+	}
+	panic("handlers: no encoder for " + w.coding)
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
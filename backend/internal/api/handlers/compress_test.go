@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// TestNegotiateEncoding verifies that the preferred supported coding is chosen from Accept-Encoding.
+func TestNegotiateEncoding(t *testing.T) {
+	for header, expected := range map[string]string{
+		"":                           "",
+		"identity":                   "",
+		"gzip":                       "gzip",
+		"gzip, deflate, br, zstd":    "br",
+		"gzip;q=1.0, br;q=0.5":       "gzip",
+		"br;q=0, zstd":               "zstd",
+		"*":                          "br",
+		"*;q=0.5, gzip":              "gzip",
+		"*, br;q=0":                  "zstd",
+		"GZIP;q=0.8":                 "gzip",
+		"gzip;q=0":                   "",
+		"gzip;q=high, zstd;q=0.1":    "zstd",
+		" deflate , compress;q=0.9 ": "",
+	} {
+		if got := negotiateEncoding(header); got != expected {
+			t.Errorf("%q: expected %q, got %q", header, expected, got)
+		}
+	}
+}
+
+// decode returns the body of rr decoded according to its Content-Encoding.
+func decode(t *testing.T, rr *httptest.ResponseRecorder) string {
+	t.Helper()
+	var r io.Reader = rr.Body
+	switch rr.Header().Get("Content-Encoding") {
+	case "gzip":
+		gr, err := gzip.NewReader(rr.Body)
+		if err != nil {
+			t.Fatalf("Failed to read gzip body: %v", err)
+		}
+		r = gr
+	case "br":
+		r = brotli.NewReader(rr.Body)
+	case "zstd":
+		zr, err := zstd.NewReader(rr.Body)
+		if err != nil {
+			t.Fatalf("Failed to read zstd body: %v", err)
+		}
+		defer zr.Close()
+		r = zr
+	}
+	body, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatalf("Failed to decode body: %v", err)
+	}
+	return string(body)
+}
+
+// TestCompress verifies that responses are compressed only when negotiated, large enough and
+// not already encoded, and that compressible responses vary by Accept-Encoding.
+func TestCompress(t *testing.T) {
+	large := `{"content":"` + strings.Repeat("gopher ", 200) + `"}`
+	small := `{"ok":true}`
+	handler := Compress(256)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body := large
+		if r.URL.Query().Has("small") {
+			body = small
+		}
+		switch r.URL.Path {
+		case "/encoded":
+			w.Header().Set("Content-Encoding", "gzip")
+		case "/image":
+			w.Header().Set("Content-Type", "image/png")
+		case "/stream":
+			w.Header().Set("Content-Type", "text/event-stream")
+		default:
+			w.Header().Set("Content-Type", "application/json")
+		}
+		w.Header().Set("ETag", `"v1"`)
+		// Write in pieces so the threshold is crossed mid-response.
+		for i := 0; i < len(body); i += 100 {
+			w.Write([]byte(body[i:min(i+100, len(body))]))
+		}
+	}))
+
+	for _, tc := range []struct {
+		path, acceptEncoding string
+		encoding, etag, vary string
+		expected             string
+	}{
+		{"/", "gzip", "gzip", `W/"v1"`, "Accept-Encoding", large},
+		{"/", "br", "br", `W/"v1"`, "Accept-Encoding", large},
+		{"/", "zstd", "zstd", `W/"v1"`, "Accept-Encoding", large},
+		{"/", "", "", `"v1"`, "Accept-Encoding", large},
+		{"/?small", "gzip", "", `"v1"`, "Accept-Encoding", small},
+		{"/encoded", "gzip", "gzip", `"v1"`, "Accept-Encoding", large},
+		{"/image", "gzip", "", `"v1"`, "", large},
+		{"/stream", "gzip", "", `"v1"`, "", large},
+	} {
+		req := httptest.NewRequest("GET", tc.path, nil)
+		req.Header.Set("Accept-Encoding", tc.acceptEncoding)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		name := tc.path + " " + tc.acceptEncoding
+		if got := rr.Header().Get("Content-Encoding"); got != tc.encoding {
+			t.Errorf("%s: expected Content-Encoding %q, got %q", name, tc.encoding, got)
+		}
+		if got := rr.Header().Get("ETag"); got != tc.etag {
+			t.Errorf("%s: expected ETag %q, got %q", name, tc.etag, got)
+		}
+		if got := rr.Header().Get("Vary"); got != tc.vary {
+			t.Errorf("%s: expected Vary %q, got %q", name, tc.vary, got)
+		}
+		if tc.path == "/encoded" {
+			// Already encoded responses pass through byte for byte.
+			if rr.Body.String() != tc.expected {
+				t.Errorf("%s: expected the body to pass through unchanged", name)
+			}
+			continue
+		}
+		if got := decode(t, rr); got != tc.expected {
+			t.Errorf("%s: expected the decoded body to round-trip, got %d bytes", name, len(got))
+		}
+	}
+}
+
+// TestCompress_Disabled verifies that a minimum size of 0 leaves responses untouched.
+func TestCompress_Disabled(t *testing.T) {
+	body := bytes.Repeat([]byte("a"), 4096)
+	handler := Compress(0)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.Write(body)
+	}))
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Header().Get("Content-Encoding") != "" || rr.Header().Get("Vary") != "" || !bytes.Equal(rr.Body.Bytes(), body) {
+		t.Errorf("Expected an untouched response, got headers %v", rr.Header())
+	}
+}
+
+// TestCompress_NotModified verifies that 304 responses from ConditionalGET stay empty and uncompressed.
+func TestCompress_NotModified(t *testing.T) {
+	handler := Compress(1)(ConditionalGET(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		setValidators(w, `"v1"`, time.Time{})
+		w.Write([]byte(`{"ok":true}`))
+	})))
+	req := httptest.NewRequest("GET", "/", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("If-None-Match", `W/"v1"`)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotModified || rr.Body.Len() != 0 || rr.Header().Get("Content-Encoding") != "" {
+		t.Errorf("Expected an empty uncompressed 304, got %d with %d bytes", rr.Code, rr.Body.Len())
+	}
+}
@@ -0,0 +1,130 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// ConditionalGET answers GET and HEAD requests whose preconditions show the client's copy is
+// current with 304 Not Modified. Handlers opt in by setting ETag or Last-Modified before writing
+// a 200 response, typically with setValidators; the middleware compares them with If-None-Match,
+// or with If-Modified-Since when no If-None-Match is given, and drops the body if they match.
+func ConditionalGET(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Protocol upgrades have no representation to validate and need the connection itself.
+		if (r.Method != http.MethodGet && r.Method != http.MethodHead) || r.Header.Get("Upgrade") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		next.ServeHTTP(&conditionalWriter{ResponseWriter: w, r: r}, r)
+	})
+}
+
+// conditionalWriter replaces a 200 response with 304 Not Modified when the request's
+// preconditions match the validators set by the handler.
+type conditionalWriter struct {
+	http.ResponseWriter
+	r           *http.Request
+	wroteHeader bool
+	discard     bool
+}
+
+// WriteHeader evaluates the preconditions once the handler has set its validators.
+func (w *conditionalWriter) WriteHeader(status int) {
+	if w.wroteHeader {
+		return
+	}
+	w.wroteHeader = true
+	if status == http.StatusOK && notModified(w.r, w.Header()) {
+		w.Header().Del("Content-Type")
+		w.Header().Del("Content-Length")
+		w.discard = true
+		status = http.StatusNotModified
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+// Write writes b unless the response was replaced by 304 Not Modified.
+func (w *conditionalWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	if w.discard {
+		return len(b), nil
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap returns the underlying ResponseWriter for http.ResponseController.
+func (w *conditionalWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// notModified reports whether the preconditions of r show that the client holds the
+// representation described by the ETag and Last-Modified validators in header. If-None-Match
+// takes precedence over If-Modified-Since, as RFC 9110 requires.
+func notModified(r *http.Request, header http.Header) bool {
+	if ifNoneMatch := r.Header.Get("If-None-Match"); ifNoneMatch != "" {
+		etag := header.Get("ETag")
+		if etag == "" {
+			return strings.TrimSpace(ifNoneMatch) == "*"
+		}
+		return etagMatches(ifNoneMatch, etag)
+	}
+	ifModifiedSince, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	lastModified, err := http.ParseTime(header.Get("Last-Modified"))
+	return err == nil && !lastModified.After(ifModifiedSince)
+}
+
+// etagMatches reports whether an If-None-Match header value matches etag,
+// using the weak comparison RFC 9110 prescribes for If-None-Match.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// setValidators sets the ETag and Last-Modified headers of a response; an empty etag or a
+// zero lastModified leaves the header unset.
+func setValidators(w http.ResponseWriter, etag string, lastModified time.Time) {
+	if etag != "" {
+		w.Header().Set("ETag", etag)
+	}
+	if !lastModified.IsZero() {
+		w.Header().Set("Last-Modified", lastModified.UTC().Format(http.TimeFormat))
+	}
+}
+
+// strongETag returns a strong entity tag for a representation identified by data.
+func strongETag(data []byte) string {
+	sum := sha256.Sum256(data)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// articlesETag derives the entity tag of a response listing articles from its result set: it
+// covers the ID and update time of every article in order, plus any other values shaping the
+// response, such as its total count. Lists carry no Last-Modified, since the latest update of
+// the listed articles goes back in time when an article leaves the list.
+func articlesETag(articles []*models.Article, extra ...interface{}) string {
+	var b strings.Builder
+	for _, article := range articles {
+		fmt.Fprintf(&b, "%d@%d;", article.ID, article.UpdatedAt.UnixNano())
+	}
+	for _, value := range extra {
+		fmt.Fprintf(&b, "|%v", value)
+	}
+	return strongETag([]byte(b.String()))
+}
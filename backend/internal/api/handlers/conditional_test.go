@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/config"
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// TestConditionalGET verifies that the middleware answers matching preconditions with an empty 304.
+func TestConditionalGET(t *testing.T) {
+	modified := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	handler := ConditionalGET(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/validated" {
+			setValidators(w, `"v1"`, modified)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ok":true}`))
+	}))
+
+	for _, tc := range []struct {
+		method, path string
+		header       map[string]string
+		expected     int
+	}{
+		{"GET", "/validated", nil, http.StatusOK},
+		{"GET", "/validated", map[string]string{"If-None-Match": `"v1"`}, http.StatusNotModified},
+		{"HEAD", "/validated", map[string]string{"If-None-Match": `W/"v1"`}, http.StatusNotModified},
+		{"GET", "/validated", map[string]string{"If-None-Match": `"v0"`}, http.StatusOK},
+		{"GET", "/validated", map[string]string{"If-Modified-Since": modified.Format(http.TimeFormat)}, http.StatusNotModified},
+		{"GET", "/validated", map[string]string{"If-Modified-Since": modified.Add(-time.Second).Format(http.TimeFormat)}, http.StatusOK},
+		// If-None-Match takes precedence over If-Modified-Since.
+		{"GET", "/validated", map[string]string{"If-None-Match": `"v0"`, "If-Modified-Since": modified.Format(http.TimeFormat)}, http.StatusOK},
+		{"POST", "/validated", map[string]string{"If-None-Match": `"v1"`}, http.StatusOK},
+		{"GET", "/unvalidated", map[string]string{"If-None-Match": `"v1"`, "If-Modified-Since": modified.Format(http.TimeFormat)}, http.StatusOK},
+		{"GET", "/unvalidated", map[string]string{"If-None-Match": "*"}, http.StatusNotModified},
+	} {
+		req := httptest.NewRequest(tc.method, tc.path, nil)
+		for name, value := range tc.header {
+			req.Header.Set(name, value)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		if rr.Code != tc.expected {
+			t.Errorf("%s %s %v: expected status %d, got %d", tc.method, tc.path, tc.header, tc.expected, rr.Code)
+		}
+		if rr.Code == http.StatusNotModified {
+			if rr.Body.Len() != 0 || rr.Header().Get("Content-Type") != "" {
+				t.Errorf("%s %s %v: expected no body or content type, got %q", tc.method, tc.path, tc.header, rr.Body)
+			}
+			if tc.path == "/validated" && rr.Header().Get("ETag") != `"v1"` {
+				t.Errorf("%s %s %v: expected the ETag to be kept", tc.method, tc.path, tc.header)
+			}
+		}
+	}
+}
+
+// TestGetArticles_Validators verifies that list validators follow the result set.
+func TestGetArticles_Validators(t *testing.T) {
+	updated := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	mockStore := store.NewMockStore([]*models.Article{
+		{ID: 1, Title: "First", Summary: models.NewNullableString("Summary"), UpdatedAt: updated.Add(-time.Hour)},
+		{ID: 2, Title: "Second", Summary: models.NewNullableString("Summary"), UpdatedAt: updated},
+	}, nil, nil)
+	handler := ConditionalGET(NewArticlesHandler(mockStore, config.NewConfig()))
+
+	get := func(target, header, value string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", target, nil)
+		if value != "" {
+			req.Header.Set(header, value)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := get("/api/v1/articles", "", "")
+	etag := rr.Header().Get("ETag")
+	if etag == "" || rr.Header().Get("Last-Modified") != "" {
+		t.Fatalf("Expected an ETag and no Last-Modified, got ETag %q and Last-Modified %q", etag, rr.Header().Get("Last-Modified"))
+	}
+	if rr := get("/api/v1/articles", "If-None-Match", etag); rr.Code != http.StatusNotModified {
+		t.Errorf("Expected 304 for an unchanged list, got %d", rr.Code)
+	}
+	if rr := get("/api/v1/articles?count=false", "", ""); rr.Header().Get("ETag") == etag {
+		t.Error("Expected the ETag to cover the total count")
+	}
+
+	mockStore.Articles[0].UpdatedAt = updated.Add(time.Hour)
+	if rr := get("/api/v1/articles", "If-None-Match", etag); rr.Code != http.StatusOK || rr.Header().Get("ETag") == etag {
+		t.Errorf("Expected a new ETag after an update, got status %d", rr.Code)
+	}
+	etag = get("/api/v1/articles", "", "").Header().Get("ETag")
+
+	// The newest article leaving the list turns back the latest update of the rest,
+	// which must not make the list look unmodified.
+	mockStore.Articles = mockStore.Articles[1:]
+	if rr := get("/api/v1/articles", "If-None-Match", etag); rr.Code != http.StatusOK {
+		t.Errorf("Expected a new ETag after an article left the list, got status %d", rr.Code)
+	}
+	if rr := get("/api/v1/articles", "If-Modified-Since", updated.Add(time.Hour).Format(http.TimeFormat)); rr.Code != http.StatusOK {
+		t.Errorf("Expected 200 for If-Modified-Since after an article left the list, got %d", rr.Code)
+	}
+}
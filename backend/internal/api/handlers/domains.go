@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// GetDomains handles the HTTP request to summarize articles per linked site.
+//
+// @Summary List domains
+// @Description Count the articles linking to each registrable domain, such as github.com for gist.github.com, with their average upvotes. Domains with the most matching articles come first.
+// @Tags Articles
+// @Produce  json
+// @Param   params  query  DomainListParams  false  "Filters, thresholds and pagination of the counted articles"
+// @Success 200 {object} models.DomainsResponse
+// @Failure 400 {object} models.ProblemDetails
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 504 {object} models.ErrorResponse
+// @Router /domains [get]
+func (h *ArticlesHandler) GetDomains(w http.ResponseWriter, r *http.Request) {
+	var params DomainListParams
+	if invalid := bindQuery(r, &params); len(invalid) > 0 {
+		h.invalidParamsProblem(w, r, invalid)
+		return
+	}
+
+	ctx, cancel := h.queryContext(r)
+	defer cancel()
+
+	domains, err := h.Store.Domains(ctx, params.paginate(params.articleQuery()))
+	if err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
+	}
+
+	h.setCacheHeaders(w, h.Config.CacheMaxAge)
+	h.jsonResponse(w, models.DomainsResponse{
+		Code:    http.StatusOK,
+		Status:  "success",
+		Domains: domains,
+	}, http.StatusOK)
+}
@@ -0,0 +1,50 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// GetFacets handles the HTTP request to break the articles matching a filter set down by facet.
+//
+// @Summary Get facet counts
+// @Description Count the articles matching the filters per summarizing model, commit hash, source and flagged, dead and duplicate state. Each facet is counted without its own filter, so filtering by model_name still lists the counts of the other models.
+// @Tags Articles
+// @Produce  json
+// @Param   params  query  FacetParams  false  "Filters and thresholds of the counted articles"
+// @Success 200 {object} models.FacetsResponse
+// @Failure 400 {object} models.ProblemDetails
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 504 {object} models.ErrorResponse
+// @Router /facets [get]
+func (h *ArticlesHandler) GetFacets(w http.ResponseWriter, r *http.Request) {
+	var params FacetParams
+	if invalid := bindQuery(r, &params); len(invalid) > 0 {
+		h.invalidParamsProblem(w, r, invalid)
+		return
+	}
+	query := params.articleQuery()
+
+	ctx, cancel := h.queryContext(r)
+	defer cancel()
+
+	total, err := h.Store.Count(ctx, query)
+	if err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
+	}
+	facets, err := h.Store.Facets(ctx, query)
+	if err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
+	}
+
+	h.setCacheHeaders(w, h.Config.CacheMaxAge)
+	h.jsonResponse(w, models.FacetsResponse{
+		Code:       http.StatusOK,
+		Status:     "success",
+		TotalCount: total,
+		Facets:     facets,
+	}, http.StatusOK)
+}
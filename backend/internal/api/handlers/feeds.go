@@ -0,0 +1,338 @@
+package handlers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"html"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+const (
+	feedSiteURL     = "https://gophersignal.com"
+	feedDescription = "Latest articles from Gopher Signal"
+)
+
+// FeedParams declares the query parameters of the article feeds.
+type FeedParams struct {
+	ArticleFilterParams
+	Limit  int    `form:"limit" default:"30" minimum:"1" maximum:"100"` // Articles in the feed
+	Period string `form:"period" enums:"daily,weekly"`                  // Only articles of the last day or week; since takes precedence
+}
+
+// articleQuery converts the filters to a store query for the newest articles of the feed,
+// leaving out their content, which feeds never show.
+func (p FeedParams) articleQuery(now time.Time) store.ArticleQuery {
+	q := p.ArticleFilterParams.articleQuery()
+	if q.Since.IsZero() {
+		switch p.Period {
+		case "daily":
+			q.Since = now.Add(-24 * time.Hour)
+		case "weekly":
+			q.Since = now.Add(-7 * 24 * time.Hour)
+		}
+	}
+	q.Limit = p.Limit
+	q.Sort = store.SortNewest
+	q.Fields = slices.DeleteFunc(models.ArticleFields(), func(field string) bool { return field == "content" })
+	return q
+}
+
+// title names the feed after its period and whether it is otherwise filtered.
+func (p FeedParams) title() string {
+	var parts []string
+	switch p.Period {
+	case "daily":
+		parts = append(parts, "Daily")
+	case "weekly":
+		parts = append(parts, "Weekly")
+	}
+	for _, flag := range []struct {
+		label string
+		value *bool
+	}{{"Flagged", p.Flagged}, {"Dead", p.Dead}, {"Dupe", p.Dupe}} {
+		if flag.value != nil && *flag.value {
+			parts = append(parts, flag.label)
+		}
+	}
+	if p.MinUpvotes > 0 || p.MinComments > 0 ||
+		!p.Since.IsZero() || !p.Until.IsZero() || !p.UpdatedSince.IsZero() ||
+		len(p.Domain) > 0 || len(p.ExcludeDomain) > 0 || len(p.ModelName) > 0 || len(p.CommitHash) > 0 {
+		parts = append(parts, "Filtered")
+	}
+	if len(parts) == 0 {
+		return "Gopher Signal"
+	}
+	return "Gopher Signal - " + strings.Join(parts, ", ")
+}
+
+// feed holds the articles of a feed request and what describes the feed as a whole.
+type feed struct {
+	title    string
+	selfURL  string
+	updated  time.Time // Latest update of the articles, or the time of the request if there are none.
+	articles []*models.Article
+}
+
+// GetRSSFeed handles the HTTP request for the RSS feed of articles.
+//
+// @Summary RSS feed
+// @Description Serve the newest articles matching the filters as an RSS 2.0 feed. Items describe the article with its summary and link the Hacker News discussion, and their GUIDs stay stable across updates.
+// @Tags Feeds
+// @Produce  xml
+// @Param   params  query  FeedParams  false  "Filters and size of the feed"
+// @Param   If-None-Match      header  string  false  "ETag of a cached copy"
+// @Success 200 {object} models.RSSFeed
+// @Success 304 "Not Modified"
+// @Header  200 {string} ETag "Entity tag of the feed's result set"
+// @Failure 400 {object} models.ProblemDetails
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 504 {object} models.ErrorResponse
+// @Router /feeds/rss.xml [get]
+func (h *ArticlesHandler) GetRSSFeed(w http.ResponseWriter, r *http.Request) {
+	f, ok := h.loadFeed(w, r)
+	if !ok {
+		return
+	}
+	items := make([]models.RSSItem, len(f.articles))
+	for i, article := range f.articles {
+		guid, isPermaLink := articleGUID(article)
+		items[i] = models.RSSItem{
+			Title:       article.Title,
+			Link:        article.Link,
+			Description: feedDescriptionHTML(article),
+			Comments:    commentsURL(article),
+			GUID:        models.RSSGUID{IsPermaLink: isPermaLink, Value: guid},
+			PubDate:     article.CreatedAt.UTC().Format(time.RFC1123Z),
+		}
+	}
+	h.xmlResponse(w, "application/rss+xml; charset=utf-8", models.RSSFeed{
+		Version:   "2.0",
+		AtomXMLNS: "http://www.w3.org/2005/Atom",
+		Channel: models.RSSChannel{
+			Title:         f.title,
+			Link:          feedSiteURL,
+			Description:   feedDescription,
+			SelfLink:      models.AtomLink{Href: f.selfURL, Rel: "self", Type: "application/rss+xml"},
+			LastBuildDate: f.updated.UTC().Format(time.RFC1123Z),
+			Items:         items,
+		},
+	})
+}
+
+// GetAtomFeed handles the HTTP request for the Atom feed of articles.
+//
+// @Summary Atom feed
+// @Description Serve the newest articles matching the filters as an Atom 1.0 feed. Entries link the article and its Hacker News discussion, and their IDs stay stable across updates.
+// @Tags Feeds
+// @Produce  xml
+// @Param   params  query  FeedParams  false  "Filters and size of the feed"
+// @Param   If-None-Match      header  string  false  "ETag of a cached copy"
+// @Success 200 {object} models.AtomFeed
+// @Success 304 "Not Modified"
+// @Header  200 {string} ETag "Entity tag of the feed's result set"
+// @Failure 400 {object} models.ProblemDetails
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 504 {object} models.ErrorResponse
+// @Router /feeds/atom.xml [get]
+func (h *ArticlesHandler) GetAtomFeed(w http.ResponseWriter, r *http.Request) {
+	f, ok := h.loadFeed(w, r)
+	if !ok {
+		return
+	}
+	entries := make([]models.AtomEntry, len(f.articles))
+	for i, article := range f.articles {
+		guid, _ := articleGUID(article)
+		links := []models.AtomLink{{Href: article.Link, Rel: "alternate", Type: "text/html"}}
+		if comments := commentsURL(article); comments != "" {
+			links = append(links, models.AtomLink{Href: comments, Rel: "related", Type: "text/html"})
+		}
+		entries[i] = models.AtomEntry{
+			Title:     article.Title,
+			ID:        guid,
+			Updated:   article.UpdatedAt.UTC().Format(time.RFC3339),
+			Published: article.CreatedAt.UTC().Format(time.RFC3339),
+			Links:     links,
+			Summary:   models.AtomText{Type: "html", Value: feedDescriptionHTML(article)},
+		}
+	}
+	h.xmlResponse(w, "application/atom+xml; charset=utf-8", models.AtomFeed{
+		Title:   f.title,
+		ID:      f.selfURL,
+		Updated: f.updated.UTC().Format(time.RFC3339),
+		Links: []models.AtomLink{
+			{Href: f.selfURL, Rel: "self", Type: "application/atom+xml"},
+			{Href: feedSiteURL, Rel: "alternate", Type: "text/html"},
+		},
+		Author:  models.AtomPerson{Name: "Gopher Signal"},
+		Entries: entries,
+	})
+}
+
+// GetJSONFeed handles the HTTP request for the JSON Feed of articles.
+//
+// @Summary JSON Feed
+// @Description Serve the newest articles matching the filters as a JSON Feed 1.1. Items point at the Hacker News discussion with the article as their external URL, and their IDs stay stable across updates.
+// @Tags Feeds
+// @Produce  json
+// @Param   params  query  FeedParams  false  "Filters and size of the feed"
+// @Param   If-None-Match      header  string  false  "ETag of a cached copy"
+// @Success 200 {object} models.JSONFeed
+// @Success 304 "Not Modified"
+// @Header  200 {string} ETag "Entity tag of the feed's result set"
+// @Failure 400 {object} models.ProblemDetails
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 504 {object} models.ErrorResponse
+// @Router /feeds/feed.json [get]
+func (h *ArticlesHandler) GetJSONFeed(w http.ResponseWriter, r *http.Request) {
+	f, ok := h.loadFeed(w, r)
+	if !ok {
+		return
+	}
+	items := make([]*models.JSONFeedItem, len(f.articles))
+	for i, article := range f.articles {
+		guid, _ := articleGUID(article)
+		item := &models.JSONFeedItem{
+			ID:            guid,
+			URL:           commentsURL(article),
+			ExternalURL:   article.Link,
+			Title:         article.Title,
+			ContentHTML:   feedDescriptionHTML(article),
+			Summary:       article.Summary.String,
+			DatePublished: article.CreatedAt.UTC().Format(time.RFC3339),
+			DateModified:  article.UpdatedAt.UTC().Format(time.RFC3339),
+		}
+		if article.Domain != "" {
+			item.Tags = []string{article.Domain}
+		}
+		items[i] = item
+	}
+	w.Header().Set("Content-Type", "application/feed+json; charset=utf-8")
+	json.NewEncoder(w).Encode(models.JSONFeed{
+		Version:     "https://jsonfeed.org/version/1.1",
+		Title:       f.title,
+		HomePageURL: feedSiteURL,
+		FeedURL:     f.selfURL,
+		Description: feedDescription,
+		Items:       items,
+	})
+}
+
+// loadFeed binds the feed parameters of r and queries the articles of the feed, setting the
+// caching headers and validators of the response. It writes the error response and returns false
+// if that fails.
+func (h *ArticlesHandler) loadFeed(w http.ResponseWriter, r *http.Request) (*feed, bool) {
+	var params FeedParams
+	if invalid := bindQuery(r, &params); len(invalid) > 0 {
+		h.invalidParamsProblem(w, r, invalid)
+		return nil, false
+	}
+
+	ctx, cancel := h.queryContext(r)
+	defer cancel()
+
+	now := time.Now()
+	page, err := h.Store.Query(ctx, params.articleQuery(now))
+	if err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return nil, false
+	}
+
+	f := &feed{
+		title:    params.title(),
+		selfURL:  absoluteURL(r),
+		articles: page.Articles,
+	}
+	h.setCacheHeaders(w, h.Config.CacheMaxAge)
+	setValidators(w, articlesETag(page.Articles, f.title), time.Time{})
+	for _, article := range page.Articles {
+		if article.UpdatedAt.After(f.updated) {
+			f.updated = article.UpdatedAt
+		}
+	}
+	if f.updated.IsZero() {
+		f.updated = now
+	}
+	return f, true
+}
+
+// xmlResponse writes feed as an XML document of the given content type.
+func (h *ArticlesHandler) xmlResponse(w http.ResponseWriter, contentType string, feed interface{}) {
+	w.Header().Set("Content-Type", contentType)
+	w.Write([]byte(xml.Header))
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	enc.Encode(feed)
+}
+
+// absoluteURL returns the URL of r, taking the scheme from X-Forwarded-Proto behind a proxy.
+func absoluteURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := r.Header.Get("X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	return scheme + "://" + r.Host + r.URL.RequestURI()
+}
+
+// hnItemURL returns the URL of the Hacker News discussion of the item with the given ID.
+func hnItemURL(hnID int) string {
+	return fmt.Sprintf("https://news.ycombinator.com/item?id=%d", hnID)
+}
+
+// articleGUID returns the stable identifier of an article in feeds: its Hacker News item URL,
+// which is a permalink, or failing that its link, which is not.
+func articleGUID(article *models.Article) (guid string, isPermaLink bool) {
+	if article.HNID > 0 {
+		return hnItemURL(article.HNID), true
+	}
+	return article.Link, false
+}
+
+// commentsURL returns the URL of the discussion of an article, or "" if it has none.
+func commentsURL(article *models.Article) string {
+	if article.CommentLink.Valid && article.CommentLink.String != "" {
+		return article.CommentLink.String
+	}
+	if article.HNID > 0 {
+		return hnItemURL(article.HNID)
+	}
+	return ""
+}
+
+// feedDescriptionHTML returns the escaped summary of an article followed by a footer with its
+// upvotes, comments, summarizer and site.
+func feedDescriptionHTML(article *models.Article) string {
+	summary := "No summary"
+	if article.Summary.Valid {
+		summary = article.Summary.String
+	}
+
+	footer := []string{fmt.Sprintf("▲ %d", article.Upvotes.Int64)}
+	comments := fmt.Sprintf("💬 %d", article.CommentCount.Int64)
+	if url := commentsURL(article); url != "" && article.CommentCount.Int64 > 0 {
+		comments = fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), comments)
+	}
+	footer = append(footer, comments)
+	if article.ModelName != "" {
+		footer = append(footer, "🤖 "+html.EscapeString(article.ModelName))
+	}
+	if article.CommitHash != "" {
+		footer = append(footer, "🔨 "+html.EscapeString(article.CommitHash))
+	}
+	domain := article.Domain
+	if domain == "" {
+		domain = "source"
+	}
+	footer = append(footer, fmt.Sprintf(`<a href="%s">🌐 %s</a>`, html.EscapeString(article.Link), html.EscapeString(domain)))
+
+	return html.EscapeString(summary) + "<br><br><small>" + strings.Join(footer, " · ") + "</small>"
+}
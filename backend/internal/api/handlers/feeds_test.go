@@ -0,0 +1,171 @@
+package handlers
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/config"
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// newFeedHandler returns a handler over two summarized articles, one of them posted to Hacker News.
+func newFeedHandler() *ArticlesHandler {
+	created := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	return NewArticlesHandler(store.NewMockStore([]*models.Article{
+		{
+			ID: 1, Title: "Old <Article>", Link: "https://example.com/old", Content: "Full text",
+			Summary: models.NewNullableString("Old & summarized"), CreatedAt: created, UpdatedAt: created,
+		},
+		{
+			ID: 2, HNID: 200, Title: "New Article", Link: "https://www.github.com/new", Domain: "github.com", Content: "Full text",
+			Summary: models.NewNullableString("New summary"), ModelName: "llama3:8b",
+			CreatedAt: created.Add(time.Hour), UpdatedAt: created.Add(2 * time.Hour),
+			Upvotes: models.NewNullableInt(42), CommentCount: models.NewNullableInt(7),
+		},
+	}, nil, nil), config.NewConfig())
+}
+
+// TestGetRSSFeed verifies the RSS channel and its items, newest first.
+func TestGetRSSFeed(t *testing.T) {
+	rr := httptest.NewRecorder()
+	newFeedHandler().GetRSSFeed(rr, httptest.NewRequest("GET", "/api/v1/feeds/rss.xml?period=weekly&since=2026-01-01", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body)
+	}
+	if got := rr.Header().Get("Content-Type"); got != "application/rss+xml; charset=utf-8" {
+		t.Errorf("Unexpected Content-Type %q", got)
+	}
+	if rr.Header().Get("ETag") == "" || rr.Header().Get("Last-Modified") != "" {
+		t.Errorf("Expected an ETag and no Last-Modified, got %v", rr.Header())
+	}
+
+	var feed models.RSSFeed
+	if err := xml.Unmarshal(rr.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("Failed to parse feed: %v", err)
+	}
+	channel := feed.Channel
+	if channel.Title != "Gopher Signal - Weekly, Filtered" {
+		t.Errorf("Unexpected title %q", channel.Title)
+	}
+	// encoding/xml writes the prefixed atom:link element but does not read it back.
+	if selfLink := `<atom:link href="http://example.com/api/v1/feeds/rss.xml?period=weekly&amp;since=2026-01-01" rel="self"`; !strings.Contains(rr.Body.String(), selfLink) {
+		t.Errorf("Expected the self link %s in %s", selfLink, rr.Body)
+	}
+	if len(channel.Items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(channel.Items))
+	}
+	item := channel.Items[0]
+	if item.GUID != (models.RSSGUID{IsPermaLink: true, Value: "https://news.ycombinator.com/item?id=200"}) {
+		t.Errorf("Unexpected GUID %+v", item.GUID)
+	}
+	if item.Comments != "https://news.ycombinator.com/item?id=200" || item.Link != "https://www.github.com/new" {
+		t.Errorf("Unexpected links %q and %q", item.Link, item.Comments)
+	}
+	if item.PubDate != "Thu, 01 Oct 2026 13:00:00 +0000" {
+		t.Errorf("Unexpected pubDate %q", item.PubDate)
+	}
+	for _, part := range []string{"New summary", "▲ 42", `<a href="https://news.ycombinator.com/item?id=200">💬 7</a>`, "🤖 llama3:8b", "🌐 github.com"} {
+		if !strings.Contains(item.Description, part) {
+			t.Errorf("Expected the description to contain %q, got %q", part, item.Description)
+		}
+	}
+	if old := channel.Items[1]; old.GUID != (models.RSSGUID{Value: "https://example.com/old"}) || old.Comments != "" ||
+		!strings.HasPrefix(old.Description, "Old &amp; summarized") {
+		t.Errorf("Unexpected item without a Hacker News ID: %+v", old)
+	}
+}
+
+// TestGetAtomFeed verifies the Atom feed and its entries.
+func TestGetAtomFeed(t *testing.T) {
+	rr := httptest.NewRecorder()
+	newFeedHandler().GetAtomFeed(rr, httptest.NewRequest("GET", "/api/v1/feeds/atom.xml", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body)
+	}
+
+	var feed models.AtomFeed
+	if err := xml.Unmarshal(rr.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("Failed to parse feed: %v", err)
+	}
+	if feed.Title != "Gopher Signal" || feed.Updated != "2026-10-01T14:00:00Z" || len(feed.Entries) != 2 {
+		t.Fatalf("Unexpected feed %q updated %q with %d entries", feed.Title, feed.Updated, len(feed.Entries))
+	}
+	entry := feed.Entries[0]
+	if entry.ID != "https://news.ycombinator.com/item?id=200" || entry.Published != "2026-10-01T13:00:00Z" || entry.Updated != "2026-10-01T14:00:00Z" {
+		t.Errorf("Unexpected entry %+v", entry)
+	}
+	if len(entry.Links) != 2 || entry.Links[1].Rel != "related" || entry.Summary.Type != "html" {
+		t.Errorf("Unexpected links %+v or summary type %q", entry.Links, entry.Summary.Type)
+	}
+}
+
+// TestGetJSONFeed verifies the JSON Feed and its items.
+func TestGetJSONFeed(t *testing.T) {
+	rr := httptest.NewRecorder()
+	newFeedHandler().GetJSONFeed(rr, httptest.NewRequest("GET", "/api/v1/feeds/feed.json?limit=1&flagged=false", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body)
+	}
+	if got := rr.Header().Get("Content-Type"); got != "application/feed+json; charset=utf-8" {
+		t.Errorf("Unexpected Content-Type %q", got)
+	}
+
+	var feed models.JSONFeed
+	if err := json.Unmarshal(rr.Body.Bytes(), &feed); err != nil {
+		t.Fatalf("Failed to parse feed: %v", err)
+	}
+	if feed.Version != "https://jsonfeed.org/version/1.1" || feed.Title != "Gopher Signal" || len(feed.Items) != 1 {
+		t.Fatalf("Unexpected feed %+v", feed)
+	}
+	item := feed.Items[0]
+	if item.ID != "https://news.ycombinator.com/item?id=200" || item.ExternalURL != "https://www.github.com/new" ||
+		item.Summary != "New summary" || len(item.Tags) != 1 || item.Tags[0] != "github.com" {
+		t.Errorf("Unexpected item %+v", item)
+	}
+}
+
+// TestGetFeeds_Errors verifies that feeds reject invalid parameters with a problem response.
+func TestGetFeeds_Errors(t *testing.T) {
+	handler := newFeedHandler()
+	for _, serve := range []http.HandlerFunc{handler.GetRSSFeed, handler.GetAtomFeed, handler.GetJSONFeed} {
+		rr := httptest.NewRecorder()
+		serve(rr, httptest.NewRequest("GET", "/feed?period=hourly&offset=5", nil))
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("Expected status 400, got %d", rr.Code)
+		}
+		var problem models.ProblemDetails
+		json.Unmarshal(rr.Body.Bytes(), &problem)
+		if len(problem.InvalidParams) != 2 || problem.InvalidParams[0].Name != "period" || problem.InvalidParams[1].Name != "offset" {
+			t.Errorf("Unexpected invalid params %+v", problem.InvalidParams)
+		}
+	}
+}
+
+// TestFeedParams_Period verifies that the period bounds the feed unless since is given.
+func TestFeedParams_Period(t *testing.T) {
+	now := time.Date(2026, 10, 8, 0, 0, 0, 0, time.UTC)
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, tc := range []struct {
+		params   FeedParams
+		expected time.Time
+	}{
+		{FeedParams{}, time.Time{}},
+		{FeedParams{Period: "daily"}, now.Add(-24 * time.Hour)},
+		{FeedParams{Period: "weekly"}, now.Add(-7 * 24 * time.Hour)},
+		{FeedParams{ArticleFilterParams: ArticleFilterParams{Since: since}, Period: "weekly"}, since},
+	} {
+		q := tc.params.articleQuery(now)
+		if !q.Since.Equal(tc.expected) {
+			t.Errorf("%+v: expected since %s, got %s", tc.params, tc.expected, q.Since)
+		}
+		if q.Sort != store.SortNewest || len(q.Fields) == 0 || strings.Contains(strings.Join(q.Fields, ","), "content") {
+			t.Errorf("%+v: expected the newest articles without content, got sort %q and fields %v", tc.params, q.Sort, q.Fields)
+		}
+	}
+}
@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// Limits of ingest requests. A front page of articles with their content fits well within them.
+const (
+	maxIngestBody     = 16 << 20
+	maxIngestArticles = 500
+)
+
+// decodeIngestRequest reads the JSON array of articles in the body of an ingest request.
+func decodeIngestRequest(w http.ResponseWriter, r *http.Request) ([]*models.Article, []models.InvalidParam) {
+	var articles []*models.Article
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxIngestBody))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&articles); err != nil {
+		return nil, []models.InvalidParam{{Name: "body", Reason: "must be a JSON array of articles: " + err.Error()}}
+	}
+	switch {
+	case len(articles) == 0:
+		return nil, []models.InvalidParam{{Name: "body", Reason: "must contain at least one article"}}
+	case len(articles) > maxIngestArticles:
+		return nil, []models.InvalidParam{{Name: "body", Reason: fmt.Sprintf("must not contain more than %d articles", maxIngestArticles)}}
+	}
+	return articles, nil
+}
+
+// validateIngestedArticle rejects articles the scraper could not have produced. Limits that depend
+// on the schema, such as the length of titles, are left to the store.
+func validateIngestedArticle(article *models.Article) error {
+	switch {
+	case article == nil:
+		return errors.New("article is null")
+	case strings.TrimSpace(article.Title) == "":
+		return errors.New("title is required")
+	case article.HNID < 0:
+		return errors.New("hn_id must not be negative")
+	case article.ArticleRank < 0:
+		return errors.New("article_rank must not be negative")
+	case article.Upvotes.Valid && article.Upvotes.Int64 < 0:
+		return errors.New("upvotes must not be negative")
+	case article.CommentCount.Valid && article.CommentCount.Int64 < 0:
+		return errors.New("comment_count must not be negative")
+	case article.Link != "" && !isWebURL(article.Link):
+		return errors.New("link must be an absolute http or https URL")
+	case article.CommentLink.Valid && article.CommentLink.String != "" && !isWebURL(article.CommentLink.String):
+		return errors.New("comment_link must be an absolute http or https URL")
+	}
+	return nil
+}
+
+// isWebURL reports whether s is an absolute http or https URL.
+func isWebURL(s string) bool {
+	u, err := url.Parse(s)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+// IngestArticles handles the HTTP request to save a batch of scraped articles.
+//
+// @Summary Ingest articles
+// @Description Save a batch of scraped articles, upserting them by Hacker News ID or link as the scraper would. Every article is validated on its own: valid ones are saved even if others are rejected, and the response reports the outcome of each in request order. IDs sent by the client are ignored, and missing created_at and updated_at times default to now. Saved articles are announced to streams and webhooks.
+// @Tags Articles
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param   articles  body  []models.Article  true  "Articles to save"
+// @Success 200 {object} models.IngestResponse "Every article was saved"
+// @Success 207 {object} models.IngestResponse "Some articles were rejected"
+// @Failure 400 {object} models.ProblemDetails
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 422 {object} models.IngestResponse "Every article was rejected"
+// @Failure 500 {object} models.ErrorResponse
+// @Router /articles [post]
+func (h *ArticlesHandler) IngestArticles(w http.ResponseWriter, r *http.Request) {
+	articles, invalid := decodeIngestRequest(w, r)
+	if len(invalid) > 0 {
+		h.invalidBodyProblem(w, r, invalid)
+		return
+	}
+
+	// Validate every article, keeping the position of the valid ones in the request.
+	results := make([]*models.IngestResult, len(articles))
+	var valid []*models.Article
+	var positions []int
+	seen := make(map[int]int)
+	now := time.Now().UTC()
+	for i, article := range articles {
+		result := &models.IngestResult{Index: i, Status: models.IngestSaved}
+		results[i] = result
+		err := validateIngestedArticle(article)
+		if article != nil {
+			result.HNID = article.HNID
+			if first, ok := seen[article.HNID]; ok && err == nil && article.HNID > 0 {
+				err = fmt.Errorf("hn_id duplicates article %d of the batch", first)
+			}
+		}
+		if err != nil {
+			result.Status, result.Error = models.IngestRejected, err.Error()
+			continue
+		}
+		seen[article.HNID] = i
+		article.ID = 0
+		if article.CreatedAt.IsZero() {
+			article.CreatedAt = now
+		}
+		if article.UpdatedAt.IsZero() {
+			article.UpdatedAt = now
+		}
+		valid = append(valid, article)
+		positions = append(positions, i)
+	}
+
+	if len(valid) > 0 {
+		ctx, cancel := h.queryContext(r)
+		defer cancel()
+		_, err := h.Store.SaveArticles(ctx, valid, store.SavePartial)
+		var batchErr *store.BatchSaveError
+		if err != nil && !errors.As(err, &batchErr) {
+			h.storeErrorResponse(ctx, w, r, err)
+			return
+		}
+		if batchErr != nil {
+			for _, failure := range batchErr.Failures {
+				result := results[positions[failure.Index]]
+				result.Status = models.IngestRejected
+				if errors.Is(failure.Err, store.ErrInvalidArticle) {
+					result.Error = failure.Err.Error()
+				} else {
+					log.Printf("Failed to ingest article with HN ID %d: %v", failure.HNID, failure.Err)
+					result.Error = "article could not be stored"
+				}
+			}
+		}
+	}
+
+	response := models.IngestResponse{Results: results}
+	for _, result := range results {
+		if result.Status == models.IngestSaved {
+			response.Saved++
+		} else {
+			response.Rejected++
+		}
+	}
+	switch {
+	case response.Rejected == 0:
+		response.Code, response.Status = http.StatusOK, "success"
+	case response.Saved > 0:
+		response.Code, response.Status = http.StatusMultiStatus, "partial"
+	default:
+		response.Code, response.Status = http.StatusUnprocessableEntity, "error"
+	}
+	w.Header().Set("Cache-Control", "no-store")
+	h.jsonResponse(w, response, response.Code)
+}
@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/k-zehnder/gophersignal/backend/config"
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// ingest posts body to the ingest handler backed by mockStore and decodes the response.
+func ingest(t *testing.T, mockStore *store.MockStore, body string) (*httptest.ResponseRecorder, models.IngestResponse) {
+	t.Helper()
+	handler := NewArticlesHandler(mockStore, config.NewConfig())
+	rr := httptest.NewRecorder()
+	handler.IngestArticles(rr, httptest.NewRequest("POST", "/api/v1/articles", strings.NewReader(body)))
+	var response models.IngestResponse
+	if rr.Header().Get("Content-Type") == "application/json" {
+		if err := json.Unmarshal(rr.Body.Bytes(), &response); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+	}
+	return rr, response
+}
+
+// TestIngestArticles_Saved tests that a valid batch is saved in full.
+func TestIngestArticles_Saved(t *testing.T) {
+	mockStore := store.NewMockStore(nil, nil, nil)
+	rr, response := ingest(t, mockStore, `[
+		{"id": 99, "hn_id": 1, "title": "First", "link": "https://go.dev/blog", "upvotes": 120},
+		{"hn_id": 2, "title": "Second", "link": "https://example.com/post", "comment_link": "https://news.ycombinator.com/item?id=2"}
+	]`)
+
+	if rr.Code != http.StatusOK || response.Status != "success" || response.Saved != 2 || response.Rejected != 0 {
+		t.Fatalf("Expected both articles saved with status 200, got %d: %s", rr.Code, rr.Body)
+	}
+	if len(mockStore.Articles) != 2 {
+		t.Fatalf("Expected 2 stored articles, got %d", len(mockStore.Articles))
+	}
+	first := mockStore.Articles[0]
+	if first.ID != 1 || first.Domain != "go.dev" || first.CreatedAt.IsZero() || first.UpdatedAt.IsZero() {
+		t.Errorf("Expected a new article with server-assigned ID, domain and times, got %+v", first)
+	}
+	if rr.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("Expected Cache-Control no-store, got %q", rr.Header().Get("Cache-Control"))
+	}
+}
+
+// TestIngestArticles_FillsSummary tests that ingesting a known story again with a summary stores it.
+func TestIngestArticles_FillsSummary(t *testing.T) {
+	s, err := store.NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	handler := NewArticlesHandler(s, config.NewConfig())
+	for _, body := range []string{
+		`[{"hn_id": 1, "title": "Story", "link": "https://go.dev/blog"}]`,
+		`[{"hn_id": 1, "title": "Story", "link": "https://go.dev/blog", "summary": "A summary", "model_name": "llama3:8b"}]`,
+	} {
+		rr := httptest.NewRecorder()
+		handler.IngestArticles(rr, httptest.NewRequest("POST", "/api/v1/articles", strings.NewReader(body)))
+		if rr.Code != http.StatusOK {
+			t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body)
+		}
+	}
+
+	page, err := s.Query(context.Background(), store.ArticleQuery{})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(page.Articles) != 1 || page.Articles[0].Summary.String != "A summary" || page.Articles[0].ModelName != "llama3:8b" {
+		t.Errorf("Expected the story with the summary of the second ingest, got %+v", page.Articles)
+	}
+}
+
+// TestIngestArticles_PerItemResults tests that rejected articles are reported without failing the batch.
+func TestIngestArticles_PerItemResults(t *testing.T) {
+	mockStore := store.NewMockStore(nil, nil, nil)
+	mockStore.ArticleErrors = map[int]error{5: errors.New("deadlock found")}
+	rr, response := ingest(t, mockStore, `[
+		{"hn_id": 1, "title": "Valid", "link": "https://go.dev"},
+		{"hn_id": 2, "title": "  "},
+		null,
+		{"hn_id": 3, "title": "Bad link", "link": "javascript:alert(1)"},
+		{"hn_id": 1, "title": "Valid again"},
+		{"hn_id": 4, "title": "`+strings.Repeat("x", 256)+`"},
+		{"hn_id": 5, "title": "Database failure"},
+		{"hn_id": 6, "title": "Negative", "upvotes": -3}
+	]`)
+
+	if rr.Code != http.StatusMultiStatus || response.Status != "partial" || response.Saved != 1 || response.Rejected != 7 {
+		t.Fatalf("Expected 1 saved and 7 rejected with status 207, got %d: %s", rr.Code, rr.Body)
+	}
+	expected := []struct {
+		hnID   int
+		status string
+		error  string
+	}{
+		{1, models.IngestSaved, ""},
+		{2, models.IngestRejected, "title is required"},
+		{0, models.IngestRejected, "article is null"},
+		{3, models.IngestRejected, "link must be an absolute http or https URL"},
+		{1, models.IngestRejected, "hn_id duplicates article 0 of the batch"},
+		{4, models.IngestRejected, "invalid article: title exceeds 255 characters"},
+		{5, models.IngestRejected, "article could not be stored"},
+		{6, models.IngestRejected, "upvotes must not be negative"},
+	}
+	for i, want := range expected {
+		got := response.Results[i]
+		if got.Index != i || got.HNID != want.hnID || got.Status != want.status || got.Error != want.error {
+			t.Errorf("Result %d: expected %+v, got %+v", i, want, *got)
+		}
+	}
+	if len(mockStore.Articles) != 1 || mockStore.Articles[0].Title != "Valid" {
+		t.Errorf("Expected only the valid article to be stored, got %d articles", len(mockStore.Articles))
+	}
+}
+
+// TestIngestArticles_AllRejected tests that a batch without a savable article is unprocessable.
+func TestIngestArticles_AllRejected(t *testing.T) {
+	rr, response := ingest(t, store.NewMockStore(nil, nil, nil), `[{"hn_id": -1, "title": "Negative ID"}]`)
+	if rr.Code != http.StatusUnprocessableEntity || response.Status != "error" || response.Rejected != 1 {
+		t.Errorf("Expected status 422 with 1 rejected article, got %d: %s", rr.Code, rr.Body)
+	}
+}
+
+// TestIngestArticles_InvalidBody tests that malformed batches are rejected with problem details.
+func TestIngestArticles_InvalidBody(t *testing.T) {
+	for _, body := range []string{
+		`{"hn_id": 1, "title": "Not an array"}`,
+		`[]`,
+		`[{"title": "Unknown field", "score": 5}]`,
+		`[` + strings.TrimSuffix(strings.Repeat(`{"title": "x"},`, maxIngestArticles+1), ",") + `]`,
+	} {
+		rr, _ := ingest(t, store.NewMockStore(nil, nil, nil), body)
+		if rr.Code != http.StatusBadRequest || rr.Header().Get("Content-Type") != problemContentType {
+			t.Errorf("%.40s: expected a 400 problem, got %d %q", body, rr.Code, rr.Header().Get("Content-Type"))
+		}
+	}
+}
+
+// TestIngestArticles_StoreError tests that a failing store answers 500.
+func TestIngestArticles_StoreError(t *testing.T) {
+	rr, _ := ingest(t, store.NewMockStore(nil, errors.New("connection refused"), nil), `[{"hn_id": 1, "title": "First"}]`)
+	if rr.Code != http.StatusInternalServerError {
+		t.Errorf("Expected status 500, got %d", rr.Code)
+	}
+}
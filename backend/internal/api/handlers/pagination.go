@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// pageLinks returns the URLs of the pages after and before page, relative to the host of r.
+// Requests paging by offset get offset links in both directions. All other requests, including
+// the first page requested without either, get a cursor link to the next page only, since cursors
+// cannot be walked backwards and offsets would skip or repeat articles saved in between.
+func pageLinks(r *http.Request, page *store.ArticlePage) (next, prev string) {
+	q := r.URL.Query()
+	byOffset := q.Get("cursor") == "" && q.Get("offset") != ""
+	if page.HasMore {
+		if byOffset {
+			next = pageURL(r, map[string]string{"offset": strconv.Itoa(page.Offset + page.Limit)})
+		} else {
+			next = pageURL(r, map[string]string{"cursor": page.NextCursor, "offset": ""})
+		}
+	}
+	if byOffset && page.Offset > 0 {
+		prev = pageURL(r, map[string]string{"offset": strconv.Itoa(max(page.Offset-page.Limit, 0))})
+	}
+	return next, prev
+}
+
+// pageURL returns the path and query of r with the given parameters replaced;
+// empty values remove the parameter.
+func pageURL(r *http.Request, params map[string]string) string {
+	q := r.URL.Query()
+	for name, value := range params {
+		if value == "" {
+			q.Del(name)
+		} else {
+			q.Set(name, value)
+		}
+	}
+	u := url.URL{Path: r.URL.Path, RawQuery: q.Encode()}
+	return u.String()
+}
+
+// setLinkHeader advertises the next and previous pages in an RFC 8288 Link header.
+func setLinkHeader(w http.ResponseWriter, next, prev string) {
+	var links []string
+	if next != "" {
+		links = append(links, "<"+next+`>; rel="next"`)
+	}
+	if prev != "" {
+		links = append(links, "<"+prev+`>; rel="prev"`)
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+}
@@ -0,0 +1,352 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// Query parameter declarations are structs whose fields carry the parameter name in a form tag and
+// their constraints in the default, minimum, maximum, enums and binding:"required" tags. bindQuery
+// enforces the tags, and swag reads the same tags and field comments when a handler documents its
+// parameters with "@Param params query <struct> false", so the validation and the documented
+// bounds cannot drift apart. Supported field types are string, int, bool, *bool, time.Time and
+// []string, which takes comma-separated or repeated values and applies enums to each of them;
+// see parseTimeParam for the accepted times. Constraints spanning several parameters are checked
+// by declarations implementing paramValidator.
+
+// ArticleFilterParams declares the filter, threshold and time range parameters shared by the
+// article endpoints.
+type ArticleFilterParams struct {
+	Flagged       *bool     `form:"flagged"`                                                    // Filter by flagged status
+	Dead          *bool     `form:"dead"`                                                       // Filter by dead status
+	Dupe          *bool     `form:"dupe"`                                                       // Filter by duplicate status
+	MinUpvotes    int       `form:"min_upvotes" default:"0" minimum:"0"`                        // Minimum upvotes threshold
+	MinComments   int       `form:"min_comments" default:"0" minimum:"0"`                       // Minimum comments threshold
+	Since         time.Time `form:"since" swaggertype:"string" example:"24h"`                   // Only articles created at or after this RFC 3339 time, date (2026-10-01) or duration ago (24h, 7d, 2w)
+	Until         time.Time `form:"until" swaggertype:"string" example:"2026-10-02"`            // Only articles created before this RFC 3339 time, date or duration ago
+	UpdatedSince  time.Time `form:"updated_since" swaggertype:"string" example:"1h"`            // Only articles updated at or after this RFC 3339 time, date or duration ago
+	Domain        []string  `form:"domain" collectionFormat:"csv" example:"github.com"`         // Only articles linking to these sites
+	ExcludeDomain []string  `form:"exclude_domain" collectionFormat:"csv" example:"medium.com"` // Exclude articles linking to these sites
+	ModelName     []string  `form:"model_name" collectionFormat:"csv" example:"llama3:8b"`      // Only articles summarized by these models
+	CommitHash    []string  `form:"commit_hash" collectionFormat:"csv" example:"abc1234"`       // Only articles summarized by these builds
+}
+
+// PageParams declares the offset pagination parameters of the article endpoints returning lists.
+type PageParams struct {
+	Limit  int `form:"limit" default:"30" minimum:"1" maximum:"100"` // Results per page
+	Offset int `form:"offset" default:"0" minimum:"0"`               // Pagination offset
+}
+
+// ArticleListParams declares the query parameters of GET /articles.
+type ArticleListParams struct {
+	ArticleFilterParams
+	PageParams
+	Sort    string   `form:"sort" default:"newest" enums:"newest,oldest,upvotes,comments,rank,hot,updated"`                                                                                                                           // Result order: by ID, upvotes, comment count, front page rank, upvotes decayed by age, or last update
+	Order   string   `form:"order" enums:"asc,desc"`                                                                                                                                                                                  // Direction of sort; defaults to asc for oldest and rank and desc otherwise
+	Cursor  string   `form:"cursor"`                                                                                                                                                                                                  // Resume after the page that returned this next_cursor; sort and order must not change
+	Count   bool     `form:"count" default:"true"`                                                                                                                                                                                    // Count all matching articles for total_count; false skips the count
+	Fields  []string `form:"fields" collectionFormat:"csv" enums:"id,hn_id,title,link,domain,article_rank,content,summary,source,commit_hash,model_name,created_at,updated_at,upvotes,comment_count,comment_link,flagged,dead,dupe"`  // Only return these article fields
+	Exclude []string `form:"exclude" collectionFormat:"csv" enums:"id,hn_id,title,link,domain,article_rank,content,summary,source,commit_hash,model_name,created_at,updated_at,upvotes,comment_count,comment_link,flagged,dead,dupe"` // Leave these article fields out, e.g. content
+}
+
+// DomainListParams declares the query parameters of GET /domains.
+type DomainListParams struct {
+	ArticleFilterParams
+	PageParams
+}
+
+// FacetParams declares the query parameters of GET /facets.
+type FacetParams struct {
+	ArticleFilterParams
+}
+
+// SearchParams declares the query parameters of GET /search.
+type SearchParams struct {
+	ArticleFilterParams
+	PageParams
+	Q    string `form:"q" binding:"required"`                                     // Search text, e.g. rust "memory safety" compil*
+	Sort string `form:"sort" default:"relevance" enums:"relevance,newest,oldest"` // Result order
+}
+
+// articleQuery converts the filters to a store query.
+func (p ArticleFilterParams) articleQuery() store.ArticleQuery {
+	return store.ArticleQuery{
+		Flagged:        p.Flagged,
+		Dead:           p.Dead,
+		Dupe:           p.Dupe,
+		MinUpvotes:     p.MinUpvotes,
+		MinComments:    p.MinComments,
+		Since:          p.Since,
+		Until:          p.Until,
+		UpdatedSince:   p.UpdatedSince,
+		Domains:        p.Domain,
+		ExcludeDomains: p.ExcludeDomain,
+		ModelNames:     p.ModelName,
+		CommitHashes:   p.CommitHash,
+	}
+}
+
+// paginate returns q limited to the requested page.
+func (p PageParams) paginate(q store.ArticleQuery) store.ArticleQuery {
+	q.Limit, q.Offset = p.Limit, p.Offset
+	return q
+}
+
+// validateParams requires the time range to be non-empty.
+func (p ArticleFilterParams) validateParams() []models.InvalidParam {
+	if !p.Since.IsZero() && !p.Until.IsZero() && !p.Until.After(p.Since) {
+		return []models.InvalidParam{{Name: "until", Reason: "must be later than since"}}
+	}
+	return nil
+}
+
+// validateParams requires the article fields to leave at least one field.
+func (p ArticleListParams) validateParams() []models.InvalidParam {
+	invalid := p.ArticleFilterParams.validateParams()
+	if fields := p.articleFields(); fields != nil && len(fields) == 0 {
+		invalid = append(invalid, models.InvalidParam{Name: "exclude", Reason: "must leave at least one field"})
+	}
+	return invalid
+}
+
+// articleFields returns the article fields to return, or nil to return them whole.
+func (p ArticleListParams) articleFields() []string {
+	if p.Fields == nil && p.Exclude == nil {
+		return nil
+	}
+	fields := p.Fields
+	if fields == nil {
+		fields = models.ArticleFields()
+	}
+	return slices.DeleteFunc(slices.Clone(fields), func(field string) bool { return slices.Contains(p.Exclude, field) })
+}
+
+// paramValidator is implemented by parameter declarations with constraints spanning several parameters.
+type paramValidator interface {
+	// validateParams returns the parameters violating the constraints of the bound declaration.
+	validateParams() []models.InvalidParam
+}
+
+// bindQuery fills the parameter declaration dst, a pointer to a struct, from the query of r.
+// Absent parameters take their default. It returns every unknown, repeated, malformed,
+// missing or out-of-range parameter; dst is only fully populated when none are returned.
+func bindQuery(r *http.Request, dst interface{}) []models.InvalidParam {
+	return bindValues(r.URL.Query(), dst)
+}
+
+// bindValues is bindQuery for parameters that do not come from a URL query.
+func bindValues(values url.Values, dst interface{}) []models.InvalidParam {
+	known := make(map[string]bool)
+	var invalid []models.InvalidParam
+	for _, field := range paramFields(reflect.ValueOf(dst).Elem()) {
+		name := field.tag.Get("form")
+		known[name] = true
+		if reason := bindParam(field, values[name]); reason != "" {
+			invalid = append(invalid, models.InvalidParam{Name: name, Reason: reason})
+		}
+	}
+
+	if validator, ok := dst.(paramValidator); ok {
+		invalid = append(invalid, validator.validateParams()...)
+	}
+
+	var unknown []string
+	for name := range values {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		invalid = append(invalid, models.InvalidParam{Name: name, Reason: "is not a recognized parameter"})
+	}
+	return invalid
+}
+
+// paramField is a settable struct field declaring a query parameter.
+type paramField struct {
+	value reflect.Value
+	tag   reflect.StructTag
+}
+
+// paramFields returns the parameter fields of v in declaration order, including those of embedded structs.
+func paramFields(v reflect.Value) []paramField {
+	var fields []paramField
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		switch {
+		case field.Anonymous && field.Type.Kind() == reflect.Struct:
+			fields = append(fields, paramFields(v.Field(i))...)
+		case field.Tag.Get("form") != "":
+			fields = append(fields, paramField{value: v.Field(i), tag: field.Tag})
+		}
+	}
+	return fields
+}
+
+// bindParam sets field from the values given for its parameter and returns why they are invalid,
+// or an empty string if they are not.
+func bindParam(field paramField, values []string) string {
+	if ptr, ok := field.value.Addr().Interface().(*[]string); ok {
+		*ptr = nil
+		enums := field.tag.Get("enums")
+		for _, value := range values {
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item == "" {
+					continue
+				}
+				if enums != "" && !slices.Contains(strings.Split(enums, ","), item) {
+					return "must only contain " + strings.ReplaceAll(enums, ",", ", ")
+				}
+				*ptr = append(*ptr, item)
+			}
+		}
+		return ""
+	}
+	if len(values) > 1 {
+		return "must not be repeated"
+	}
+	raw := ""
+	if len(values) == 1 {
+		raw = strings.TrimSpace(values[0])
+	}
+	if raw == "" {
+		if field.tag.Get("binding") == "required" {
+			return "is required"
+		}
+		raw = field.tag.Get("default")
+		if raw == "" {
+			return ""
+		}
+	}
+
+	switch ptr := field.value.Addr().Interface().(type) {
+	case *string:
+		if enums := field.tag.Get("enums"); enums != "" && !slices.Contains(strings.Split(enums, ","), raw) {
+			return "must be one of " + strings.ReplaceAll(enums, ",", ", ")
+		}
+		*ptr = raw
+	case *bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "must be a boolean"
+		}
+		*ptr = b
+	case **bool:
+		b, err := strconv.ParseBool(raw)
+		if err != nil {
+			return "must be a boolean"
+		}
+		*ptr = &b
+	case *int:
+		n, err := strconv.Atoi(raw)
+		if err != nil {
+			return "must be an integer"
+		}
+		if min, ok := intTag(field.tag, "minimum"); ok && n < min {
+			return fmt.Sprintf("must be at least %d", min)
+		}
+		if max, ok := intTag(field.tag, "maximum"); ok && n > max {
+			return fmt.Sprintf("must be at most %d", max)
+		}
+		*ptr = n
+	case *time.Time:
+		t, err := parseTimeParam(raw, time.Now())
+		if err != nil {
+			return "must be an RFC 3339 time, a date such as 2026-10-01 or a duration such as 24h or 7d"
+		}
+		*ptr = t
+	default:
+		panic(fmt.Sprintf("handlers: unsupported query parameter type %s", field.value.Type()))
+	}
+	return ""
+}
+
+// parseTimeParam parses an RFC 3339 time, a date in UTC, or a positive duration before now.
+// Durations are those of time.ParseDuration plus whole days ("7d") and weeks ("2w").
+func parseTimeParam(raw string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, raw); err == nil {
+		return t, nil
+	}
+	if t, err := time.Parse(time.DateOnly, raw); err == nil {
+		return t, nil
+	}
+
+	var d time.Duration
+	var err error
+	switch unit := raw[len(raw)-1]; unit {
+	case 'd', 'w':
+		var n int
+		n, err = strconv.Atoi(raw[:len(raw)-1])
+		per := 24 * time.Hour
+		if unit == 'w' {
+			per *= 7
+		}
+		if int64(n) > math.MaxInt64/int64(per) {
+			return time.Time{}, fmt.Errorf("invalid time %q", raw)
+		}
+		d = time.Duration(n) * per
+	default:
+		d, err = time.ParseDuration(raw)
+	}
+	if err != nil || d <= 0 {
+		return time.Time{}, fmt.Errorf("invalid time %q", raw)
+	}
+	return now.Add(-d), nil
+}
+
+// intTag returns the integer value of the named struct tag, if set.
+func intTag(tag reflect.StructTag, name string) (int, bool) {
+	value, ok := tag.Lookup(name)
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		panic(fmt.Sprintf("handlers: invalid %s tag %q", name, value))
+	}
+	return n, true
+}
+
+// problemContentType is the media type of RFC 7807 problem details.
+const problemContentType = "application/problem+json"
+
+// invalidParamsProblem writes an RFC 7807 problem response listing every invalid query parameter.
+func (h *ArticlesHandler) invalidParamsProblem(w http.ResponseWriter, r *http.Request, invalid []models.InvalidParam) {
+	h.badRequestProblem(w, r, "Invalid query parameters", invalid)
+}
+
+// invalidBodyProblem writes an RFC 7807 problem response listing every invalid field of a request body.
+func (h *ArticlesHandler) invalidBodyProblem(w http.ResponseWriter, r *http.Request, invalid []models.InvalidParam) {
+	h.badRequestProblem(w, r, "Invalid request body", invalid)
+}
+
+// badRequestProblem writes a 400 problem response titled title, naming every invalid parameter.
+func (h *ArticlesHandler) badRequestProblem(w http.ResponseWriter, r *http.Request, title string, invalid []models.InvalidParam) {
+	names := make([]string, len(invalid))
+	for i, param := range invalid {
+		names[i] = param.Name
+	}
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(http.StatusBadRequest)
+	json.NewEncoder(w).Encode(models.ProblemDetails{
+		Type:          "about:blank",
+		Title:         title,
+		Status:        http.StatusBadRequest,
+		Detail:        title + ": " + strings.Join(names, ", "),
+		Instance:      r.URL.RequestURI(),
+		InvalidParams: invalid,
+	})
+}
@@ -0,0 +1,246 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/config"
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// TestBindQuery_Defaults verifies that absent parameters take the defaults of their declarations.
+func TestBindQuery_Defaults(t *testing.T) {
+	var params ArticleListParams
+	if invalid := bindQuery(httptest.NewRequest("GET", "/articles", nil), &params); len(invalid) > 0 {
+		t.Fatalf("Unexpected invalid parameters: %+v", invalid)
+	}
+	if params.Limit != 30 || params.Offset != 0 || !params.Count || params.Flagged != nil || params.Cursor != "" {
+		t.Errorf("Unexpected defaults: %+v", params)
+	}
+}
+
+// TestBindQuery_Values verifies that every supported field type is parsed.
+func TestBindQuery_Values(t *testing.T) {
+	var params SearchParams
+	r := httptest.NewRequest("GET", "/search?q=rust&sort=oldest&flagged=true&limit=100&offset=5&min_upvotes=10&min_comments=0", nil)
+	if invalid := bindQuery(r, &params); len(invalid) > 0 {
+		t.Fatalf("Unexpected invalid parameters: %+v", invalid)
+	}
+	if params.Q != "rust" || params.Sort != "oldest" || params.Flagged == nil || !*params.Flagged ||
+		params.Limit != 100 || params.Offset != 5 || params.MinUpvotes != 10 {
+		t.Errorf("Unexpected parameters: %+v", params)
+	}
+}
+
+// TestBindQuery_Lists verifies that list parameters take comma-separated and repeated values.
+func TestBindQuery_Lists(t *testing.T) {
+	var params ArticleListParams
+	r := httptest.NewRequest("GET", "/articles?domain=github.com,+lwn.net&domain=bbc.co.uk&exclude_domain=", nil)
+	if invalid := bindQuery(r, &params); len(invalid) > 0 {
+		t.Fatalf("Unexpected invalid parameters: %+v", invalid)
+	}
+	if !reflect.DeepEqual(params.Domain, []string{"github.com", "lwn.net", "bbc.co.uk"}) || params.ExcludeDomain != nil {
+		t.Errorf("Unexpected domains: %q, excluded %q", params.Domain, params.ExcludeDomain)
+	}
+}
+
+// TestBindQuery_FacetParams verifies that the facet counts accept filters but not pagination.
+func TestBindQuery_FacetParams(t *testing.T) {
+	var params FacetParams
+	r := httptest.NewRequest("GET", "/facets?model_name=llama3:8b,mistral&commit_hash=abc1234&limit=5", nil)
+	expected := []models.InvalidParam{{Name: "limit", Reason: "is not a recognized parameter"}}
+	if invalid := bindQuery(r, &params); !reflect.DeepEqual(invalid, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, invalid)
+	}
+	query := params.articleQuery()
+	if !reflect.DeepEqual(query.ModelNames, []string{"llama3:8b", "mistral"}) || !reflect.DeepEqual(query.CommitHashes, []string{"abc1234"}) {
+		t.Errorf("Unexpected query: %+v", query)
+	}
+}
+
+// TestArticleListParams_FieldsMatchArticle verifies that the selectable fields are exactly those of an article.
+func TestArticleListParams_FieldsMatchArticle(t *testing.T) {
+	expected := strings.Join(models.ArticleFields(), ",")
+	for _, name := range []string{"Fields", "Exclude"} {
+		field, _ := reflect.TypeOf(ArticleListParams{}).FieldByName(name)
+		if field.Tag.Get("enums") != expected {
+			t.Errorf("Expected %s enums %q, got %q", name, expected, field.Tag.Get("enums"))
+		}
+	}
+}
+
+// TestBindQuery_Fields verifies the sparse fieldset parameters.
+func TestBindQuery_Fields(t *testing.T) {
+	for _, tc := range []struct {
+		query    string
+		expected []string
+		invalid  []models.InvalidParam
+	}{
+		{"", nil, nil},
+		{"fields=title,id,summary", []string{"title", "id", "summary"}, nil},
+		{"fields=title,content&exclude=content", []string{"title"}, nil},
+		{"exclude=content", slices.DeleteFunc(models.ArticleFields(), func(f string) bool { return f == "content" }), nil},
+		{"fields=title,body", nil, []models.InvalidParam{{Name: "fields", Reason: "must only contain " + strings.Join(models.ArticleFields(), ", ")}}},
+		{"fields=content&exclude=content", nil, []models.InvalidParam{{Name: "exclude", Reason: "must leave at least one field"}}},
+	} {
+		var params ArticleListParams
+		invalid := bindQuery(httptest.NewRequest("GET", "/articles?"+tc.query, nil), &params)
+		if !reflect.DeepEqual(invalid, tc.invalid) {
+			t.Errorf("%s: expected invalid %+v, got %+v", tc.query, tc.invalid, invalid)
+		} else if invalid == nil && !reflect.DeepEqual(params.articleFields(), tc.expected) {
+			t.Errorf("%s: expected fields %q, got %q", tc.query, tc.expected, params.articleFields())
+		}
+	}
+}
+
+// TestBindQuery_Invalid verifies that every offending parameter is reported, in declaration order
+// followed by unknown parameters in name order.
+func TestBindQuery_Invalid(t *testing.T) {
+	var params SearchParams
+	r := httptest.NewRequest("GET", "/search?dead=maybe&limit=100000&offset=-1&min_upvotes=lots&sort=sideways&sort=newest&page=2&color=red", nil)
+	expected := []models.InvalidParam{
+		{Name: "dead", Reason: "must be a boolean"},
+		{Name: "min_upvotes", Reason: "must be an integer"},
+		{Name: "limit", Reason: "must be at most 100"},
+		{Name: "offset", Reason: "must be at least 0"},
+		{Name: "q", Reason: "is required"},
+		{Name: "sort", Reason: "must not be repeated"},
+		{Name: "color", Reason: "is not a recognized parameter"},
+		{Name: "page", Reason: "is not a recognized parameter"},
+	}
+	if invalid := bindQuery(r, &params); !reflect.DeepEqual(invalid, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, invalid)
+	}
+
+	r = httptest.NewRequest("GET", "/search?q=go&sort=sideways", nil)
+	expected = []models.InvalidParam{{Name: "sort", Reason: "must be one of relevance, newest, oldest"}}
+	if invalid := bindQuery(r, &params); !reflect.DeepEqual(invalid, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, invalid)
+	}
+}
+
+// TestPageParams_LimitMatchesStore verifies that the declared limit bounds match what the store allows.
+func TestPageParams_LimitMatchesStore(t *testing.T) {
+	field, _ := reflect.TypeOf(PageParams{}).FieldByName("Limit")
+	if field.Tag.Get("default") != strconv.Itoa(store.DefaultLimit) || field.Tag.Get("maximum") != strconv.Itoa(store.MaxLimit) {
+		t.Errorf("Expected limit default %d and maximum %d, got tag %q", store.DefaultLimit, store.MaxLimit, field.Tag)
+	}
+}
+
+// TestGetArticles_InvalidParams verifies the RFC 7807 problem response for invalid query parameters.
+func TestGetArticles_InvalidParams(t *testing.T) {
+	handler := NewArticlesHandler(store.NewMockStore(nil, nil, nil), config.NewConfig())
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/articles?limit=100000&min_comments=-3&q=go", nil))
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("Expected status 400, got %d", rr.Code)
+	}
+	if contentType := rr.Header().Get("Content-Type"); contentType != "application/problem+json" {
+		t.Errorf("Expected problem content type, got %q", contentType)
+	}
+	var problem models.ProblemDetails
+	if err := json.NewDecoder(rr.Body).Decode(&problem); err != nil {
+		t.Fatalf("Failed to decode problem: %v", err)
+	}
+	if problem.Status != http.StatusBadRequest || problem.Instance != "/api/v1/articles?limit=100000&min_comments=-3&q=go" {
+		t.Errorf("Unexpected problem: %+v", problem)
+	}
+	var names []string
+	for _, param := range problem.InvalidParams {
+		names = append(names, param.Name)
+	}
+	if !reflect.DeepEqual(names, []string{"min_comments", "limit", "q"}) {
+		t.Errorf("Expected min_comments, limit and q to be reported, got %+v", problem.InvalidParams)
+	}
+}
+
+// TestGetArticles_InvalidCursorProblem verifies that cursors rejected by the store are reported as a problem with the cursor parameter.
+func TestGetArticles_InvalidCursorProblem(t *testing.T) {
+	handler := NewArticlesHandler(store.NewMockStore(nil, nil, nil), config.NewConfig())
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/articles?cursor=bogus", nil))
+
+	var problem models.ProblemDetails
+	if err := json.NewDecoder(rr.Body).Decode(&problem); err != nil {
+		t.Fatalf("Failed to decode problem: %v", err)
+	}
+	if rr.Code != http.StatusBadRequest || len(problem.InvalidParams) != 1 || problem.InvalidParams[0].Name != "cursor" {
+		t.Errorf("Expected a cursor problem, got %d %+v", rr.Code, problem)
+	}
+}
+
+// TestParseTimeParam verifies absolute times, dates and relative durations.
+func TestParseTimeParam(t *testing.T) {
+	now := time.Date(2026, 10, 17, 12, 0, 0, 0, time.UTC)
+	for raw, expected := range map[string]time.Time{
+		"2026-10-01T08:30:00Z":      time.Date(2026, 10, 1, 8, 30, 0, 0, time.UTC),
+		"2026-10-01T08:30:00-07:00": time.Date(2026, 10, 1, 15, 30, 0, 0, time.UTC),
+		"2026-10-01":                time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		"24h":                       now.Add(-24 * time.Hour),
+		"90m":                       now.Add(-90 * time.Minute),
+		"7d":                        now.Add(-7 * 24 * time.Hour),
+		"2w":                        now.Add(-14 * 24 * time.Hour),
+	} {
+		got, err := parseTimeParam(raw, now)
+		if err != nil || !got.Equal(expected) {
+			t.Errorf("%s: expected %v, got %v (%v)", raw, expected, got, err)
+		}
+	}
+	// Durations beyond time.Duration, about 292 years, are rejected rather than wrapped around.
+	for _, raw := range []string{"yesterday", "-24h", "0d", "d", "2026-13-01", "1.5d", "106752d", "15251w", "9223372036854775807w"} {
+		if _, err := parseTimeParam(raw, now); err == nil {
+			t.Errorf("%s: expected an error", raw)
+		}
+	}
+}
+
+// TestBindQuery_TimeRange verifies time parameters and the check that until follows since.
+func TestBindQuery_TimeRange(t *testing.T) {
+	var params ArticleListParams
+	r := httptest.NewRequest("GET", "/articles?since=2026-10-01&until=2026-10-02&updated_since=1h", nil)
+	if invalid := bindQuery(r, &params); len(invalid) > 0 {
+		t.Fatalf("Unexpected invalid parameters: %+v", invalid)
+	}
+	if !params.Since.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) || time.Since(params.UpdatedSince) < time.Hour {
+		t.Errorf("Unexpected times: %+v", params)
+	}
+
+	r = httptest.NewRequest("GET", "/articles?since=2026-10-02&until=2026-10-01&updated_since=soon", nil)
+	expected := []models.InvalidParam{
+		{Name: "updated_since", Reason: "must be an RFC 3339 time, a date such as 2026-10-01 or a duration such as 24h or 7d"},
+		{Name: "until", Reason: "must be later than since"},
+	}
+	if invalid := bindQuery(r, &ArticleListParams{}); !reflect.DeepEqual(invalid, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, invalid)
+	}
+}
+
+// TestGetArticles_Since verifies that relative time bounds reach the store.
+func TestGetArticles_Since(t *testing.T) {
+	now := time.Now()
+	handler := NewArticlesHandler(store.NewMockStore([]*models.Article{
+		{ID: 1, Title: "Last week", Summary: models.NewNullableString("Summary"), CreatedAt: now.Add(-6 * 24 * time.Hour)},
+		{ID: 2, Title: "Today", Summary: models.NewNullableString("Summary"), CreatedAt: now.Add(-time.Hour)},
+	}, nil, nil), config.NewConfig())
+
+	for target, expected := range map[string]int{"/api/v1/articles?since=24h": 1, "/api/v1/articles?since=1w": 2, "/api/v1/articles?until=1d": 1} {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, httptest.NewRequest("GET", target, nil))
+		var resp models.ArticlesResponse
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+		if len(resp.Articles) != expected {
+			t.Errorf("%s: expected %d articles, got %d", target, expected, len(resp.Articles))
+		}
+	}
+}
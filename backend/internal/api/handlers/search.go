@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// SearchArticles handles the HTTP request to search articles by text.
+//
+// @Summary Search articles
+// @Description Full-text search over article titles, content and summaries. Every term must match; append * to a word to match it as a prefix, and quote words to match them as a phrase. Results are ranked by relevance and carry an HTML snippet of the summary with matches wrapped in <mark>.
+// @Tags Articles
+// @Produce  json
+// @Param   params  query  SearchParams  false  "Search text, filters, thresholds and pagination"
+// @Param   If-None-Match      header  string  false  "ETag of a cached copy"
+// @Success 200 {object} models.SearchResponse
+// @Success 304 "Not Modified"
+// @Header  200 {string} ETag "Entity tag of the result set"
+// @Failure 400 {object} models.ProblemDetails
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 504 {object} models.ErrorResponse
+// @Router /search [get]
+func (h *ArticlesHandler) SearchArticles(w http.ResponseWriter, r *http.Request) {
+	var params SearchParams
+	if invalid := bindQuery(r, &params); len(invalid) > 0 {
+		h.invalidParamsProblem(w, r, invalid)
+		return
+	}
+	query := params.paginate(params.articleQuery())
+	query.Sort = store.SortOrder(params.Sort)
+
+	ctx, cancel := h.queryContext(r)
+	defer cancel()
+
+	results, err := h.Store.Search(ctx, store.SearchQuery{Text: params.Q, ArticleQuery: query})
+	if err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
+	}
+
+	articles := make([]*models.Article, len(results))
+	for i, result := range results {
+		articles[i] = result.Article
+	}
+	h.setCacheHeaders(w, h.Config.CacheMaxAge)
+	setValidators(w, articlesETag(articles), time.Time{})
+	h.jsonResponse(w, models.SearchResponse{
+		Code:       http.StatusOK,
+		Status:     "success",
+		Query:      params.Q,
+		TotalCount: len(results),
+		Results:    results,
+	}, http.StatusOK)
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// streamBuffer bounds the saved articles a stream may fall behind by before it is ended,
+// leaving the client to reconnect and catch up with Last-Event-ID.
+const streamBuffer = 256
+
+// streamRetry is the reconnection delay, in milliseconds, suggested to clients of a stream.
+const streamRetry = 5000
+
+// streamPosition is how far a client has read a stream: the highest ID and the latest update time
+// of the articles sent. Every event is identified by the position after it, so that a client
+// reconnecting with Last-Event-ID is replayed both the articles stored and those updated since.
+type streamPosition struct {
+	lastID    int
+	updatedAt int64 // Unix seconds of the latest update sent; 0 if unknown.
+}
+
+// parseStreamPosition parses a position formatted by String. A bare article ID, as sent by earlier
+// versions, resumes after that article without replaying updates.
+func parseStreamPosition(s string) (streamPosition, bool) {
+	id, updated, hasUpdated := strings.Cut(strings.TrimSpace(s), "-")
+	var pos streamPosition
+	var err error
+	if pos.lastID, err = strconv.Atoi(id); err != nil || pos.lastID < 0 {
+		return pos, false
+	}
+	if hasUpdated {
+		if pos.updatedAt, err = strconv.ParseInt(updated, 10, 64); err != nil || pos.updatedAt < 0 {
+			return pos, false
+		}
+	}
+	return pos, true
+}
+
+// advance moves the position past article.
+func (p *streamPosition) advance(article *models.Article) {
+	p.lastID = max(p.lastID, article.ID)
+	p.updatedAt = max(p.updatedAt, article.UpdatedAt.Unix())
+}
+
+// String formats the position as "<article ID>-<Unix seconds>".
+func (p streamPosition) String() string {
+	return fmt.Sprintf("%d-%d", p.lastID, p.updatedAt)
+}
+
+// StreamParams declares the query parameters of GET /articles/stream.
+type StreamParams struct {
+	ArticleFilterParams
+}
+
+// StreamArticles handles the HTTP request to stream saved articles as Server-Sent Events.
+//
+// @Summary Stream saved articles
+// @Description Push an "article" event whenever a new or updated article matching the filters is saved. The event ID is the stream position after the event, "<highest article ID>-<latest update as Unix seconds>". Reconnecting with Last-Event-ID first replays the matching articles stored after that article ID, oldest first, then those updated since that second, least recently updated first; articles updated within that second may be sent again. Idle streams carry comment heartbeats. Clients that fall too far behind are disconnected and should reconnect with Last-Event-ID.
+// @Tags Articles
+// @Produce  text/event-stream
+// @Param   params  query  StreamParams  false  "Filters of the streamed articles"
+// @Param   Last-Event-ID  header  string  false  "ID of the last event received, to resume after"
+// @Success 200 {object} models.Article "Stream of article events"
+// @Failure 400 {object} models.ProblemDetails
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Router /articles/stream [get]
+func (h *ArticlesHandler) StreamArticles(w http.ResponseWriter, r *http.Request) {
+	var params StreamParams
+	invalid := bindQuery(r, &params)
+	var pos streamPosition
+	if header := r.Header.Get("Last-Event-ID"); header != "" {
+		var ok bool
+		if pos, ok = parseStreamPosition(header); !ok {
+			invalid = append(invalid, models.InvalidParam{Name: "Last-Event-ID", Reason: "must be the ID of an event of this stream"})
+		}
+	}
+	if len(invalid) > 0 {
+		h.invalidParamsProblem(w, r, invalid)
+		return
+	}
+	if h.Hub == nil {
+		h.jsonErrorResponse(w, models.ErrorResponse{
+			Code:    http.StatusServiceUnavailable,
+			Status:  "error",
+			Message: "Streaming is not available",
+		}, http.StatusServiceUnavailable)
+		return
+	}
+	query := params.articleQuery()
+
+	// Subscribe before replaying so that articles saved meanwhile are not missed.
+	sub := h.Hub.Subscribe(streamBuffer)
+	defer sub.Close()
+
+	rc := http.NewResponseController(w)
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("X-Accel-Buffering", "no") // Keep nginx from buffering events.
+	w.WriteHeader(http.StatusOK)
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetry)
+	if err := rc.Flush(); err != nil {
+		log.Printf("Streaming unsupported: %s %s: %v", r.Method, r.URL.RequestURI(), err)
+		return
+	}
+
+	replayed, err := h.replayArticles(w, r, query, &pos)
+	if err != nil {
+		log.Printf("Failed to replay articles: %s %s: %v", r.Method, r.URL.RequestURI(), err)
+		return
+	}
+	rc.Flush()
+
+	heartbeat := time.NewTicker(h.Config.StreamHeartbeat)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			io.WriteString(w, ": heartbeat\n\n")
+		case event, ok := <-sub.Events():
+			if !ok {
+				if sub.Lagged() {
+					log.Printf("Stream fell behind: %s %s", r.Method, r.URL.RequestURI())
+				}
+				return
+			}
+			article := event.Article
+			if updatedAt, ok := replayed[article.ID]; (ok && updatedAt.Equal(article.UpdatedAt)) || !query.Matches(article) {
+				continue
+			}
+			pos.advance(article)
+			if err := writeArticleEvent(w, article, pos); err != nil {
+				return
+			}
+		}
+		if err := rc.Flush(); err != nil {
+			return
+		}
+	}
+}
+
+// replayArticles writes the articles matching query stored after pos, oldest first, then those
+// updated since pos, least recently updated first, advancing pos past them. It returns the update
+// time of each by ID so that copies published while replaying are not sent twice.
+func (h *ArticlesHandler) replayArticles(w io.Writer, r *http.Request, query store.ArticleQuery, pos *streamPosition) (map[int]time.Time, error) {
+	replayed := make(map[int]time.Time)
+	if pos.lastID <= 0 && pos.updatedAt <= 0 {
+		return replayed, nil
+	}
+	query.Limit = store.MaxLimit
+	stored, updated := query, query
+	stored.Sort, stored.Cursor = store.SortOldest, store.AfterIDCursor(pos.lastID)
+	updated.Sort, updated.Order = store.SortUpdated, store.Ascending
+	updated.UpdatedSince = time.Unix(pos.updatedAt, 0)
+	passes := []store.ArticleQuery{stored}
+	if pos.updatedAt > 0 {
+		passes = append(passes, updated)
+	}
+
+	for _, query := range passes {
+		for {
+			ctx, cancel := h.queryContext(r)
+			page, err := h.Store.Query(ctx, query)
+			cancel()
+			if err != nil {
+				return nil, err
+			}
+			for _, article := range page.Articles {
+				if updatedAt, ok := replayed[article.ID]; ok && updatedAt.Equal(article.UpdatedAt) {
+					continue
+				}
+				pos.advance(article)
+				if err := writeArticleEvent(w, article, *pos); err != nil {
+					return nil, err
+				}
+				replayed[article.ID] = article.UpdatedAt
+			}
+			if !page.HasMore {
+				break
+			}
+			query.Cursor = page.NextCursor
+		}
+	}
+	return replayed, nil
+}
+
+// writeArticleEvent writes article as an "article" event identified by the stream position pos.
+func writeArticleEvent(w io.Writer, article *models.Article, pos streamPosition) error {
+	data, err := json.Marshal(article)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: article\ndata: %s\n\n", pos, data)
+	return err
+}
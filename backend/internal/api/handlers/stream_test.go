@@ -0,0 +1,219 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/config"
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/pubsub"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// sseEvent is an event or comment read from a stream.
+type sseEvent struct {
+	id, event, data, retry, comment string
+}
+
+// readEvent reads the next event or comment of a stream, failing the test after a second.
+func readEvent(t *testing.T, events <-chan sseEvent) sseEvent {
+	t.Helper()
+	select {
+	case e, ok := <-events:
+		if !ok {
+			t.Fatal("Stream ended")
+		}
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for an event")
+	}
+	return sseEvent{}
+}
+
+// readArticleEvent reads the next event of a stream, skipping heartbeats.
+func readArticleEvent(t *testing.T, events <-chan sseEvent) sseEvent {
+	t.Helper()
+	for {
+		if e := readEvent(t, events); e.comment != "heartbeat" {
+			return e
+		}
+	}
+}
+
+// openStream connects to a stream served by handler and returns its events after checking that
+// the first is the retry hint. The stream is closed at the end of the test.
+func openStream(t *testing.T, handler http.Handler, target string, header map[string]string) <-chan sseEvent {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	req, _ := http.NewRequestWithContext(ctx, "GET", srv.URL+target, nil)
+	for name, value := range header {
+		req.Header.Set(name, value)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK || resp.Header.Get("Content-Type") != "text/event-stream" {
+		t.Fatalf("Expected an event stream, got %d %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+
+	events := make(chan sseEvent, 64)
+	go func() {
+		defer close(events)
+		defer resp.Body.Close()
+		scanner := bufio.NewScanner(resp.Body)
+		var e sseEvent
+		for scanner.Scan() {
+			line := scanner.Text()
+			if line == "" {
+				if e != (sseEvent{}) {
+					events <- e
+				}
+				e = sseEvent{}
+				continue
+			}
+			field, value, _ := strings.Cut(line, ":")
+			value = strings.TrimPrefix(value, " ")
+			switch field {
+			case "":
+				e.comment = value
+			case "id":
+				e.id = value
+			case "event":
+				e.event = value
+			case "data":
+				e.data = value
+			case "retry":
+				e.retry = value
+			}
+		}
+	}()
+	if e := readEvent(t, events); e.retry != "5000" {
+		t.Fatalf("Expected the retry hint first, got %+v", e)
+	}
+	return events
+}
+
+// newStreamHandler returns a handler streaming what is saved to its store, with a short heartbeat.
+func newStreamHandler(articles []*models.Article) *ArticlesHandler {
+	cfg := config.NewConfig()
+	cfg.StreamHeartbeat = 50 * time.Millisecond
+	hub := pubsub.NewHub()
+	h := NewArticlesHandler(pubsub.NewPublishingStore(store.NewMockStore(articles, nil, nil), hub), cfg)
+	h.Hub = hub
+	return h
+}
+
+// TestStreamArticles verifies that saved articles matching the filters are pushed as events.
+func TestStreamArticles(t *testing.T) {
+	h := newStreamHandler(nil)
+	events := openStream(t, http.HandlerFunc(h.StreamArticles), "/api/v1/articles/stream?min_upvotes=10", nil)
+
+	_, err := h.Store.SaveArticles(context.Background(), []*models.Article{
+		{HNID: 1, Title: "Popular", Summary: models.NewNullableString("Summary"), Upvotes: models.NewNullableInt(50)},
+		{HNID: 2, Title: "Unpopular", Summary: models.NewNullableString("Summary"), Upvotes: models.NewNullableInt(1)},
+	}, store.SaveAtomic)
+	if err != nil {
+		t.Fatalf("SaveArticles failed: %v", err)
+	}
+
+	e := readArticleEvent(t, events)
+	var article models.Article
+	if err := json.Unmarshal([]byte(e.data), &article); err != nil {
+		t.Fatalf("Failed to parse event data %q: %v", e.data, err)
+	}
+	if e.event != "article" || e.id != "1-0" || article.ID != 1 || article.Title != "Popular" {
+		t.Errorf("Unexpected event %+v", e)
+	}
+	if e := readEvent(t, events); e.comment != "heartbeat" {
+		t.Errorf("Expected only a heartbeat after the matching article, got %+v", e)
+	}
+}
+
+// TestStreamArticles_Resume verifies that an article ID as Last-Event-ID replays the matching
+// articles stored after it.
+func TestStreamArticles_Resume(t *testing.T) {
+	h := newStreamHandler([]*models.Article{
+		{ID: 1, Title: "Seen", Summary: models.NewNullableString("Summary")},
+		{ID: 2, Title: "Missed", Summary: models.NewNullableString("Summary")},
+		{ID: 3, Title: "Flagged", Summary: models.NewNullableString("Summary"), Flagged: true},
+		{ID: 4, Title: "Also missed", Summary: models.NewNullableString("Summary")},
+	})
+	events := openStream(t, http.HandlerFunc(h.StreamArticles), "/api/v1/articles/stream", map[string]string{"Last-Event-ID": "1"})
+	for _, expected := range []string{"2-0", "4-0"} {
+		if e := readArticleEvent(t, events); e.id != expected {
+			t.Errorf("Expected replayed article %s, got %+v", expected, e)
+		}
+	}
+}
+
+// TestStreamArticles_ResumeUpdates verifies that a stream position as Last-Event-ID also replays
+// the articles updated since, including articles stored before it.
+func TestStreamArticles_ResumeUpdates(t *testing.T) {
+	seen := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	h := newStreamHandler([]*models.Article{
+		{ID: 1, Title: "Updated", Summary: models.NewNullableString("Summary"), UpdatedAt: seen.Add(2 * time.Minute)},
+		{ID: 2, Title: "Unchanged", Summary: models.NewNullableString("Summary"), UpdatedAt: seen.Add(-time.Minute)},
+		{ID: 3, Title: "Stored", Summary: models.NewNullableString("Summary"), UpdatedAt: seen.Add(time.Minute)},
+	})
+	lastEventID := fmt.Sprintf("2-%d", seen.Unix()+1)
+	events := openStream(t, http.HandlerFunc(h.StreamArticles), "/api/v1/articles/stream", map[string]string{"Last-Event-ID": lastEventID})
+	for _, expected := range []string{"Stored", "Updated"} {
+		e := readArticleEvent(t, events)
+		var article models.Article
+		if err := json.Unmarshal([]byte(e.data), &article); err != nil || article.Title != expected {
+			t.Errorf("Expected replayed article %q, got %+v", expected, e)
+		}
+	}
+	if e := readEvent(t, events); e.comment != "heartbeat" {
+		t.Errorf("Expected only a heartbeat after the replay, got %+v", e)
+	}
+}
+
+// TestStreamArticles_Errors verifies the rejection of invalid parameters and unavailable streaming.
+func TestStreamArticles_Errors(t *testing.T) {
+	h := newStreamHandler(nil)
+	req := httptest.NewRequest("GET", "/api/v1/articles/stream?limit=5", nil)
+	req.Header.Set("Last-Event-ID", "latest")
+	rr := httptest.NewRecorder()
+	h.StreamArticles(rr, req)
+	var problem models.ProblemDetails
+	json.Unmarshal(rr.Body.Bytes(), &problem)
+	if rr.Code != http.StatusBadRequest || len(problem.InvalidParams) != 2 || problem.InvalidParams[1].Name != "Last-Event-ID" {
+		t.Errorf("Expected limit and Last-Event-ID to be rejected, got %d %+v", rr.Code, problem.InvalidParams)
+	}
+
+	h.Hub = nil
+	rr = httptest.NewRecorder()
+	h.StreamArticles(rr, httptest.NewRequest("GET", "/api/v1/articles/stream", nil))
+	if rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503 without a hub, got %d", rr.Code)
+	}
+}
+
+// TestStreamArticles_HubClosed verifies that closing the hub ends open streams.
+func TestStreamArticles_HubClosed(t *testing.T) {
+	h := newStreamHandler(nil)
+	events := openStream(t, http.HandlerFunc(h.StreamArticles), "/api/v1/articles/stream", nil)
+	h.Hub.Close()
+	for {
+		select {
+		case _, ok := <-events:
+			if !ok {
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Expected the stream to end")
+		}
+	}
+}
@@ -0,0 +1,434 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+	"github.com/k-zehnder/gophersignal/backend/internal/webhooks"
+)
+
+// Limits of webhook requests, matching the columns of the webhooks table.
+const (
+	maxWebhookBody   = 64 << 10
+	maxWebhookURL    = 2048
+	maxWebhookFilter = 1024
+	maxWebhookSecret = 255
+)
+
+// DeliveryListParams declares the query parameters of GET /admin/webhooks/{id}/deliveries.
+type DeliveryListParams struct {
+	Status string `form:"status" enums:"pending,delivered,failed"`      // Only deliveries with this status
+	Limit  int    `form:"limit" default:"30" minimum:"1" maximum:"100"` // Deliveries to return, newest first
+}
+
+// webhookStore returns the store of the webhook dispatcher, writing a 503 response if webhooks are disabled.
+func (h *ArticlesHandler) webhookStore(w http.ResponseWriter) (store.WebhookStore, bool) {
+	if h.Webhooks == nil {
+		h.jsonErrorResponse(w, models.ErrorResponse{
+			Code:    http.StatusServiceUnavailable,
+			Status:  "error",
+			Message: "Webhooks are not available",
+		}, http.StatusServiceUnavailable)
+		return nil, false
+	}
+	return h.Webhooks.Store, true
+}
+
+// adminResponse writes a JSON response that must not be cached, as admin responses carry secrets
+// and queue state.
+func (h *ArticlesHandler) adminResponse(w http.ResponseWriter, response interface{}, statusCode int) {
+	w.Header().Set("Cache-Control", "no-store")
+	h.jsonResponse(w, response, statusCode)
+}
+
+// withoutSecret returns a copy of webhook for responses other than its creation.
+func withoutSecret(webhook *models.Webhook) *models.Webhook {
+	redacted := *webhook
+	redacted.Secret = ""
+	return &redacted
+}
+
+// decodeWebhookRequest reads and validates the body of a request creating or updating a webhook.
+func decodeWebhookRequest(w http.ResponseWriter, r *http.Request) (models.WebhookRequest, []models.InvalidParam) {
+	var req models.WebhookRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		return req, []models.InvalidParam{{Name: "body", Reason: "must be a webhook JSON object: " + err.Error()}}
+	}
+
+	var invalid []models.InvalidParam
+	if u, err := url.Parse(req.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		invalid = append(invalid, models.InvalidParam{Name: "url", Reason: "must be an absolute http or https URL"})
+	} else if len(req.URL) > maxWebhookURL {
+		invalid = append(invalid, models.InvalidParam{Name: "url", Reason: fmt.Sprintf("must not exceed %d characters", maxWebhookURL)})
+	}
+	if len(req.Filter) > maxWebhookFilter {
+		invalid = append(invalid, models.InvalidParam{Name: "filter", Reason: fmt.Sprintf("must not exceed %d characters", maxWebhookFilter)})
+	} else if _, err := webhooks.ParseFilter(req.Filter); err != nil {
+		invalid = append(invalid, models.InvalidParam{Name: "filter", Reason: err.Error()})
+	}
+	if len(req.Secret) > maxWebhookSecret {
+		invalid = append(invalid, models.InvalidParam{Name: "secret", Reason: fmt.Sprintf("must not exceed %d characters", maxWebhookSecret)})
+	}
+	return req, invalid
+}
+
+// ListWebhooks handles the HTTP request to list the registered webhooks.
+//
+// @Summary List webhooks
+// @Description List every registered webhook, oldest first. Secrets are not returned.
+// @Tags Webhooks
+// @Produce  json
+// @Security BearerAuth
+// @Success 200 {object} models.WebhooksResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Router /admin/webhooks [get]
+func (h *ArticlesHandler) ListWebhooks(w http.ResponseWriter, r *http.Request) {
+	webhookStore, ok := h.webhookStore(w)
+	if !ok {
+		return
+	}
+	ctx, cancel := h.queryContext(r)
+	defer cancel()
+
+	list, err := webhookStore.Webhooks(ctx)
+	if err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
+	}
+	for i, webhook := range list {
+		list[i] = withoutSecret(webhook)
+	}
+	h.adminResponse(w, models.WebhooksResponse{
+		Code:     http.StatusOK,
+		Status:   "success",
+		Webhooks: list,
+	}, http.StatusOK)
+}
+
+// CreateWebhook handles the HTTP request to register a webhook.
+//
+// @Summary Register a webhook
+// @Description Register a URL receiving a signed POST for every saved article matching the filter, such as min_upvotes>=200 AND NOT flagged. Filters combine conditions on upvotes, comments, rank, domain, source, model, title, link, flagged, dead, dupe and summarized with AND, OR, NOT and parentheses. Each article is delivered at most once per webhook. Requests carry X-GopherSignal-Timestamp and X-GopherSignal-Signature, "sha256=" followed by the hex HMAC-SHA256 of the timestamp, a dot and the body keyed with the secret. The secret is generated unless given, and only returned here.
+// @Tags Webhooks
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param   webhook  body  models.WebhookRequest  true  "Webhook to register"
+// @Success 201 {object} models.WebhookResponse
+// @Header  201 {string} Location "URL of the new webhook"
+// @Failure 400 {object} models.ProblemDetails
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Router /admin/webhooks [post]
+func (h *ArticlesHandler) CreateWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookStore, ok := h.webhookStore(w)
+	if !ok {
+		return
+	}
+	req, invalid := decodeWebhookRequest(w, r)
+	if len(invalid) > 0 {
+		h.invalidBodyProblem(w, r, invalid)
+		return
+	}
+
+	webhook := &models.Webhook{URL: req.URL, Filter: req.Filter, Secret: req.Secret, Active: true}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+	if webhook.Secret == "" {
+		secret, err := webhooks.NewSecret()
+		if err != nil {
+			h.storeErrorResponse(r.Context(), w, r, err)
+			return
+		}
+		webhook.Secret = secret
+	}
+
+	ctx, cancel := h.queryContext(r)
+	defer cancel()
+	if err := webhookStore.CreateWebhook(ctx, webhook); err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
+	}
+	w.Header().Set("Location", fmt.Sprintf("/api/v1/admin/webhooks/%d", webhook.ID))
+	h.adminResponse(w, models.WebhookResponse{
+		Code:    http.StatusCreated,
+		Status:  "success",
+		Webhook: webhook,
+	}, http.StatusCreated)
+}
+
+// GetWebhook handles the HTTP request to retrieve a webhook.
+//
+// @Summary Get a webhook
+// @Description Retrieve one webhook by ID. Its secret is not returned.
+// @Tags Webhooks
+// @Produce  json
+// @Security BearerAuth
+// @Param   id  path  integer  true  "Webhook ID"  minimum(1)
+// @Success 200 {object} models.WebhookResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Router /admin/webhooks/{id} [get]
+func (h *ArticlesHandler) GetWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookStore, ok := h.webhookStore(w)
+	if !ok {
+		return
+	}
+	id, ok := h.pathID(w, r, "id", "webhook ID")
+	if !ok {
+		return
+	}
+	ctx, cancel := h.queryContext(r)
+	defer cancel()
+
+	webhook, err := webhookStore.Webhook(ctx, id)
+	if err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
+	}
+	h.adminResponse(w, models.WebhookResponse{
+		Code:    http.StatusOK,
+		Status:  "success",
+		Webhook: withoutSecret(webhook),
+	}, http.StatusOK)
+}
+
+// UpdateWebhook handles the HTTP request to change a webhook.
+//
+// @Summary Update a webhook
+// @Description Replace the URL and filter of a webhook. The secret is rotated if one is given and kept otherwise, and the active state is kept unless given. Deactivated webhooks receive no new deliveries, and their queued ones wait until reactivation.
+// @Tags Webhooks
+// @Accept  json
+// @Produce  json
+// @Security BearerAuth
+// @Param   id       path  integer                true  "Webhook ID"  minimum(1)
+// @Param   webhook  body  models.WebhookRequest  true  "New settings"
+// @Success 200 {object} models.WebhookResponse
+// @Failure 400 {object} models.ProblemDetails
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Router /admin/webhooks/{id} [put]
+func (h *ArticlesHandler) UpdateWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookStore, ok := h.webhookStore(w)
+	if !ok {
+		return
+	}
+	id, ok := h.pathID(w, r, "id", "webhook ID")
+	if !ok {
+		return
+	}
+	req, invalid := decodeWebhookRequest(w, r)
+	if len(invalid) > 0 {
+		h.invalidBodyProblem(w, r, invalid)
+		return
+	}
+	ctx, cancel := h.queryContext(r)
+	defer cancel()
+
+	webhook, err := webhookStore.Webhook(ctx, id)
+	if err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
+	}
+	webhook.URL, webhook.Filter = req.URL, req.Filter
+	if req.Secret != "" {
+		webhook.Secret = req.Secret
+	}
+	if req.Active != nil {
+		webhook.Active = *req.Active
+	}
+	if err := webhookStore.UpdateWebhook(ctx, webhook); err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
+	}
+	if webhook.Active {
+		h.Webhooks.Wake()
+	}
+	h.adminResponse(w, models.WebhookResponse{
+		Code:    http.StatusOK,
+		Status:  "success",
+		Webhook: withoutSecret(webhook),
+	}, http.StatusOK)
+}
+
+// DeleteWebhook handles the HTTP request to remove a webhook.
+//
+// @Summary Delete a webhook
+// @Description Remove a webhook together with its queued deliveries and delivery log.
+// @Tags Webhooks
+// @Security BearerAuth
+// @Param   id  path  integer  true  "Webhook ID"  minimum(1)
+// @Success 204 "No Content"
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Router /admin/webhooks/{id} [delete]
+func (h *ArticlesHandler) DeleteWebhook(w http.ResponseWriter, r *http.Request) {
+	webhookStore, ok := h.webhookStore(w)
+	if !ok {
+		return
+	}
+	id, ok := h.pathID(w, r, "id", "webhook ID")
+	if !ok {
+		return
+	}
+	ctx, cancel := h.queryContext(r)
+	defer cancel()
+
+	if err := webhookStore.DeleteWebhook(ctx, id); err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// ListWebhookDeliveries handles the HTTP request to read the delivery log of a webhook.
+//
+// @Summary List webhook deliveries
+// @Description Read the delivery log of a webhook, newest first: every article queued for it with its payload, status, attempts, next attempt and the outcome of the latest attempt.
+// @Tags Webhooks
+// @Produce  json
+// @Security BearerAuth
+// @Param   id      path   integer             true   "Webhook ID"  minimum(1)
+// @Param   params  query  DeliveryListParams  false  "Status filter and limit"
+// @Success 200 {object} models.WebhookDeliveriesResponse
+// @Failure 400 {object} models.ProblemDetails
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Router /admin/webhooks/{id}/deliveries [get]
+func (h *ArticlesHandler) ListWebhookDeliveries(w http.ResponseWriter, r *http.Request) {
+	webhookStore, ok := h.webhookStore(w)
+	if !ok {
+		return
+	}
+	id, ok := h.pathID(w, r, "id", "webhook ID")
+	if !ok {
+		return
+	}
+	var params DeliveryListParams
+	if invalid := bindQuery(r, &params); len(invalid) > 0 {
+		h.invalidParamsProblem(w, r, invalid)
+		return
+	}
+	ctx, cancel := h.queryContext(r)
+	defer cancel()
+
+	if _, err := webhookStore.Webhook(ctx, id); err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
+	}
+	deliveries, err := webhookStore.Deliveries(ctx, store.DeliveryQuery{WebhookID: id, Status: params.Status, Limit: params.Limit})
+	if err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
+	}
+	h.adminResponse(w, models.WebhookDeliveriesResponse{
+		Code:       http.StatusOK,
+		Status:     "success",
+		Deliveries: deliveries,
+	}, http.StatusOK)
+}
+
+// GetWebhookDelivery handles the HTTP request to retrieve a webhook delivery.
+//
+// @Summary Get a webhook delivery
+// @Description Retrieve one entry of the delivery log by ID.
+// @Tags Webhooks
+// @Produce  json
+// @Security BearerAuth
+// @Param   id  path  integer  true  "Delivery ID"  minimum(1)
+// @Success 200 {object} models.WebhookDeliveryResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Router /admin/deliveries/{id} [get]
+func (h *ArticlesHandler) GetWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	webhookStore, ok := h.webhookStore(w)
+	if !ok {
+		return
+	}
+	id, ok := h.pathID(w, r, "id", "delivery ID")
+	if !ok {
+		return
+	}
+	ctx, cancel := h.queryContext(r)
+	defer cancel()
+
+	delivery, err := webhookStore.Delivery(ctx, id)
+	if err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
+	}
+	h.adminResponse(w, models.WebhookDeliveryResponse{
+		Code:     http.StatusOK,
+		Status:   "success",
+		Delivery: delivery,
+	}, http.StatusOK)
+}
+
+// ReplayWebhookDelivery handles the HTTP request to send a webhook delivery again.
+//
+// @Summary Replay a webhook delivery
+// @Description Queue a delivery to be sent again now with its original payload, whether it was delivered, failed or is still pending. Its attempt count starts over.
+// @Tags Webhooks
+// @Produce  json
+// @Security BearerAuth
+// @Param   id  path  integer  true  "Delivery ID"  minimum(1)
+// @Success 202 {object} models.WebhookDeliveryResponse
+// @Failure 400 {object} models.ErrorResponse
+// @Failure 401 {object} models.ErrorResponse
+// @Failure 403 {object} models.ErrorResponse
+// @Failure 404 {object} models.ErrorResponse
+// @Failure 500 {object} models.ErrorResponse
+// @Failure 503 {object} models.ErrorResponse
+// @Router /admin/deliveries/{id}/replay [post]
+func (h *ArticlesHandler) ReplayWebhookDelivery(w http.ResponseWriter, r *http.Request) {
+	if _, ok := h.webhookStore(w); !ok {
+		return
+	}
+	id, ok := h.pathID(w, r, "id", "delivery ID")
+	if !ok {
+		return
+	}
+	ctx, cancel := h.queryContext(r)
+	defer cancel()
+
+	delivery, err := h.Webhooks.Replay(ctx, id)
+	if err != nil {
+		h.storeErrorResponse(ctx, w, r, err)
+		return
+	}
+	h.adminResponse(w, models.WebhookDeliveryResponse{
+		Code:     http.StatusAccepted,
+		Status:   "success",
+		Delivery: delivery,
+	}, http.StatusAccepted)
+}
@@ -0,0 +1,202 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/k-zehnder/gophersignal/backend/config"
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+	"github.com/k-zehnder/gophersignal/backend/internal/webhooks"
+)
+
+// newWebhooksHandler returns a handler whose webhooks are kept in an empty SQLite store.
+func newWebhooksHandler(t *testing.T) *ArticlesHandler {
+	t.Helper()
+	s, err := store.NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	cfg := config.NewConfig()
+	handler := NewArticlesHandler(s, cfg)
+	handler.Webhooks = webhooks.NewDispatcher(s, cfg)
+	return handler
+}
+
+// serveWebhookRequest calls fn with a request for method and body carrying the path variable id, if set.
+func serveWebhookRequest(fn http.HandlerFunc, method, id, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/dummy-url", strings.NewReader(body))
+	if id != "" {
+		req = mux.SetURLVars(req, map[string]string{"id": id})
+	}
+	rr := httptest.NewRecorder()
+	fn(rr, req)
+	return rr
+}
+
+// TestWebhooks_CRUD tests creating, reading, updating and deleting webhooks.
+func TestWebhooks_CRUD(t *testing.T) {
+	handler := newWebhooksHandler(t)
+
+	rr := serveWebhookRequest(handler.CreateWebhook, "POST", "", `{"url":"https://example.com/hook","filter":"min_upvotes>=200 AND NOT flagged"}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("Expected status 201, got %d: %s", rr.Code, rr.Body)
+	}
+	var created models.WebhookResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &created); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+	webhook := created.Webhook
+	if webhook.ID == 0 || !webhook.Active || !strings.HasPrefix(webhook.Secret, "whsec_") {
+		t.Errorf("Expected an active webhook with a generated secret, got %+v", webhook)
+	}
+	if rr.Header().Get("Location") != "/api/v1/admin/webhooks/1" || rr.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("Unexpected headers: %v", rr.Header())
+	}
+
+	rr = serveWebhookRequest(handler.UpdateWebhook, "PUT", "1", `{"url":"https://example.com/other","filter":"upvotes > 500","active":false}`)
+	var updated models.WebhookResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &updated); err != nil || rr.Code != http.StatusOK {
+		t.Fatalf("Expected status 200, got %d: %s", rr.Code, rr.Body)
+	}
+	if updated.Webhook.URL != "https://example.com/other" || updated.Webhook.Active || updated.Webhook.Secret != "" {
+		t.Errorf("Expected the updated webhook without its secret, got %+v", updated.Webhook)
+	}
+	stored, err := handler.Webhooks.Store.Webhook(context.Background(), 1)
+	if err != nil || stored.Secret != webhook.Secret {
+		t.Errorf("Expected the secret to be kept, got %+v (%v)", stored, err)
+	}
+
+	rr = serveWebhookRequest(handler.ListWebhooks, "GET", "", "")
+	var list models.WebhooksResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &list); err != nil || len(list.Webhooks) != 1 || list.Webhooks[0].Secret != "" {
+		t.Errorf("Expected one webhook without its secret, got %s", rr.Body)
+	}
+
+	if rr = serveWebhookRequest(handler.DeleteWebhook, "DELETE", "1", ""); rr.Code != http.StatusNoContent {
+		t.Errorf("Expected status 204, got %d", rr.Code)
+	}
+	for _, fn := range []http.HandlerFunc{handler.GetWebhook, handler.DeleteWebhook, handler.ListWebhookDeliveries} {
+		if rr = serveWebhookRequest(fn, "GET", "1", ""); rr.Code != http.StatusNotFound {
+			t.Errorf("Expected status 404 for a deleted webhook, got %d", rr.Code)
+		}
+	}
+}
+
+// TestWebhooks_InvalidRequests tests that invalid webhook bodies are rejected with problem details.
+func TestWebhooks_InvalidRequests(t *testing.T) {
+	handler := newWebhooksHandler(t)
+	tests := []struct {
+		body    string
+		invalid []string
+	}{
+		{`not json`, []string{"body"}},
+		{`{"url":"https://example.com","unknown":1}`, []string{"body"}},
+		{`{"url":"ftp://example.com","filter":"upvotes >"}`, []string{"url", "filter"}},
+		{`{"url":"/relative","filter":"score > 5"}`, []string{"url", "filter"}},
+	}
+	for _, tc := range tests {
+		rr := serveWebhookRequest(handler.CreateWebhook, "POST", "", tc.body)
+		if rr.Code != http.StatusBadRequest || rr.Header().Get("Content-Type") != problemContentType {
+			t.Errorf("%s: expected a 400 problem, got %d %q", tc.body, rr.Code, rr.Header().Get("Content-Type"))
+			continue
+		}
+		var problem models.ProblemDetails
+		json.Unmarshal(rr.Body.Bytes(), &problem)
+		var names []string
+		for _, param := range problem.InvalidParams {
+			names = append(names, param.Name)
+		}
+		if strings.Join(names, ",") != strings.Join(tc.invalid, ",") {
+			t.Errorf("%s: expected invalid %v, got %v", tc.body, tc.invalid, names)
+		}
+	}
+}
+
+// TestWebhooks_DeliveriesAndReplay tests reading the delivery log and replaying a delivery.
+func TestWebhooks_DeliveriesAndReplay(t *testing.T) {
+	handler := newWebhooksHandler(t)
+	ctx := context.Background()
+	serveWebhookRequest(handler.CreateWebhook, "POST", "", `{"url":"https://example.com/hook","filter":"upvotes >= 200"}`)
+	article := &models.Article{HNID: 1, Title: "High Signal", Source: "Hacker News", Upvotes: models.NewNullableInt(300)}
+	if _, err := handler.Store.SaveArticles(ctx, []*models.Article{article}, store.SaveAtomic); err != nil {
+		t.Fatalf("SaveArticles failed: %v", err)
+	}
+	article, _ = handler.Store.ArticleByHNID(ctx, 1)
+
+	rr := serveWebhookRequest(handler.ListWebhookDeliveries, "GET", "1", "")
+	var log models.WebhookDeliveriesResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &log); err != nil || len(log.Deliveries) != 1 {
+		t.Fatalf("Expected one delivery, got %s", rr.Body)
+	}
+	delivery := log.Deliveries[0]
+	if delivery.Status != models.DeliveryPending || delivery.ArticleID != article.ID || !strings.Contains(string(delivery.Payload), `"event":"article.created"`) {
+		t.Errorf("Unexpected delivery: %+v", delivery)
+	}
+
+	req := mux.SetURLVars(httptest.NewRequest("GET", "/dummy-url?status=delivered", nil), map[string]string{"id": "1"})
+	rr = httptest.NewRecorder()
+	handler.ListWebhookDeliveries(rr, req)
+	if err := json.Unmarshal(rr.Body.Bytes(), &log); err != nil || len(log.Deliveries) != 0 {
+		t.Errorf("Expected no delivered deliveries, got %s", rr.Body)
+	}
+	req = mux.SetURLVars(httptest.NewRequest("GET", "/dummy-url?status=lost", nil), map[string]string{"id": "1"})
+	rr = httptest.NewRecorder()
+	handler.ListWebhookDeliveries(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("Expected status 400 for an unknown status, got %d", rr.Code)
+	}
+
+	rr = serveWebhookRequest(handler.ReplayWebhookDelivery, "POST", "1", "")
+	var replayed models.WebhookDeliveryResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &replayed); err != nil || rr.Code != http.StatusAccepted || replayed.Delivery.Status != models.DeliveryPending {
+		t.Errorf("Expected an accepted pending delivery, got %d: %s", rr.Code, rr.Body)
+	}
+	if rr = serveWebhookRequest(handler.GetWebhookDelivery, "GET", "2", ""); rr.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 for an unknown delivery, got %d", rr.Code)
+	}
+}
+
+// TestWebhooks_Disabled tests that the webhook endpoints answer 503 without a dispatcher.
+func TestWebhooks_Disabled(t *testing.T) {
+	handler := NewArticlesHandler(store.NewMockStore(nil, nil, nil), config.NewConfig())
+	if rr := serveWebhookRequest(handler.ListWebhooks, "GET", "", ""); rr.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", rr.Code)
+	}
+}
+
+// TestRequireToken tests that only requests with the configured bearer token are admitted.
+func TestRequireToken(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	tests := []struct {
+		token, header string
+		expected      int
+	}{
+		{"s3cret", "Bearer s3cret", http.StatusOK},
+		{"s3cret", "bearer s3cret", http.StatusOK},
+		{"s3cret", "Bearer wrong", http.StatusUnauthorized},
+		{"s3cret", "Basic s3cret", http.StatusUnauthorized},
+		{"s3cret", "", http.StatusUnauthorized},
+		{"", "Bearer ", http.StatusForbidden},
+	}
+	for _, tc := range tests {
+		req := httptest.NewRequest("GET", "/", nil)
+		if tc.header != "" {
+			req.Header.Set("Authorization", tc.header)
+		}
+		rr := httptest.NewRecorder()
+		RequireToken(tc.token)(next).ServeHTTP(rr, req)
+		if rr.Code != tc.expected {
+			t.Errorf("token %q, header %q: expected status %d, got %d", tc.token, tc.header, tc.expected, rr.Code)
+		}
+		if tc.expected == http.StatusUnauthorized && rr.Header().Get("WWW-Authenticate") == "" {
+			t.Errorf("header %q: expected a WWW-Authenticate challenge", tc.header)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/pubsub"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// WebSocket connection limits.
+const (
+	wsWriteWait      = 10 * time.Second // Time allowed to write a message to a client.
+	wsMaxMessageSize = 8192             // Largest message accepted from a client.
+	wsReplyBuffer    = 16               // Replies a client may leave unread before it is disconnected.
+)
+
+// wsUpgrader upgrades requests to WebSocket connections. Its default origin check refuses
+// browsers on other sites.
+var wsUpgrader = websocket.Upgrader{ReadBufferSize: 1024, WriteBufferSize: 4096}
+
+// SnapshotParams declares the parameters of WebSocket snapshot requests.
+type SnapshotParams struct {
+	Limit int `form:"limit" default:"30" minimum:"1" maximum:"100"` // Articles in the snapshot
+}
+
+// ServeWebSocket handles the HTTP request to open a WebSocket subscription to saved articles.
+//
+// @Summary Subscribe over WebSocket
+// @Description Upgrade to a WebSocket speaking JSON messages with a "type" and an optional "id" echoed in replies. {"type":"subscribe","filters":{...}} starts or replaces the subscription, with filters named and valued as the query parameters of GET /articles, e.g. {"min_upvotes":50,"domain":["github.com"]}, and is answered with "subscribed" or an "error" listing the invalid filters. {"type":"unsubscribe"} stops events and is answered with "unsubscribed". {"type":"ping"} is answered with "pong". {"type":"snapshot","limit":30} is answered with a "snapshot" of the newest articles matching the subscription. While subscribed, every saved article matching the filters is pushed as {"type":"article","event":"created"|"updated","article":{...}}. Clients that fall behind are disconnected with close code 1008 and should resubscribe and request a snapshot.
+// @Tags Articles
+// @Success 101 "Switching Protocols"
+// @Failure 400 {string} string "Not a WebSocket handshake"
+// @Failure 503 {object} models.ErrorResponse
+// @Router /ws [get]
+func (h *ArticlesHandler) ServeWebSocket(w http.ResponseWriter, r *http.Request) {
+	if h.Hub == nil {
+		h.jsonErrorResponse(w, models.ErrorResponse{
+			Code:    http.StatusServiceUnavailable,
+			Status:  "error",
+			Message: "Streaming is not available",
+		}, http.StatusServiceUnavailable)
+		return
+	}
+	conn, err := wsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// The upgrader has already replied with the error.
+		return
+	}
+
+	c := &wsConn{
+		h:       h,
+		r:       r,
+		conn:    conn,
+		sub:     h.Hub.Subscribe(streamBuffer),
+		replies: make(chan interface{}, wsReplyBuffer),
+		done:    make(chan struct{}),
+	}
+	go c.writeLoop()
+	c.readLoop()
+	close(c.done)
+	c.sub.Close()
+}
+
+// wsConn is one WebSocket connection. Its read loop runs on the handler's goroutine and its write
+// loop on its own; only the write loop writes messages, so replies are queued for it.
+type wsConn struct {
+	h       *ArticlesHandler
+	r       *http.Request
+	conn    *websocket.Conn
+	sub     *pubsub.Subscription
+	replies chan interface{}
+	done    chan struct{} // Closed when the read loop ends.
+
+	mu         sync.Mutex
+	query      store.ArticleQuery // Filters of the subscription.
+	subscribed bool
+}
+
+// readLoop handles client messages until the connection fails or closes.
+func (c *wsConn) readLoop() {
+	pongWait := 2 * c.h.Config.StreamHeartbeat
+	c.conn.SetReadLimit(wsMaxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, data, err := c.conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("WebSocket read failed: %v", err)
+			}
+			return
+		}
+		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+
+		var req models.WSRequest
+		if err := json.Unmarshal(data, &req); err != nil {
+			if !c.reply(models.WSError{Type: "error", Message: "malformed message: " + err.Error()}) {
+				return
+			}
+			continue
+		}
+		if !c.reply(c.handle(req)) {
+			return
+		}
+	}
+}
+
+// handle carries out req and returns the reply.
+func (c *wsConn) handle(req models.WSRequest) interface{} {
+	switch req.Type {
+	case "subscribe":
+		values, invalid := filterValues(req.Filters)
+		var params ArticleFilterParams
+		invalid = append(invalid, bindValues(values, &params)...)
+		if len(invalid) > 0 {
+			return models.WSError{Type: "error", ID: req.ID, Message: "invalid filters", InvalidParams: invalid}
+		}
+		c.mu.Lock()
+		c.query, c.subscribed = params.articleQuery(), true
+		c.mu.Unlock()
+		return models.WSReply{Type: "subscribed", ID: req.ID}
+	case "unsubscribe":
+		c.mu.Lock()
+		c.subscribed = false
+		c.mu.Unlock()
+		return models.WSReply{Type: "unsubscribed", ID: req.ID}
+	case "ping":
+		return models.WSReply{Type: "pong", ID: req.ID}
+	case "snapshot":
+		return c.snapshot(req)
+	}
+	return models.WSError{Type: "error", ID: req.ID, Message: fmt.Sprintf("unknown message type %q", req.Type)}
+}
+
+// snapshot returns the newest articles matching the subscription's filters.
+func (c *wsConn) snapshot(req models.WSRequest) interface{} {
+	values := url.Values{}
+	if req.Limit != 0 {
+		values.Set("limit", strconv.Itoa(req.Limit))
+	}
+	var params SnapshotParams
+	if invalid := bindValues(values, &params); len(invalid) > 0 {
+		return models.WSError{Type: "error", ID: req.ID, Message: "invalid snapshot", InvalidParams: invalid}
+	}
+	c.mu.Lock()
+	query := c.query
+	c.mu.Unlock()
+	query.Limit = params.Limit
+
+	ctx, cancel := c.h.queryContext(c.r)
+	defer cancel()
+	page, err := c.h.Store.Query(ctx, query)
+	if err != nil {
+		return models.WSError{Type: "error", ID: req.ID, Message: "snapshot failed: " + err.Error()}
+	}
+	articles := page.Articles
+	if articles == nil {
+		articles = []*models.Article{}
+	}
+	return models.WSSnapshot{Type: "snapshot", ID: req.ID, Articles: articles}
+}
+
+// reply queues msg for the write loop. A client that leaves too many replies unread is
+// disconnected as a slow consumer, and false is returned.
+func (c *wsConn) reply(msg interface{}) bool {
+	select {
+	case c.replies <- msg:
+		return true
+	default:
+		c.close(websocket.ClosePolicyViolation, "slow consumer")
+		return false
+	}
+}
+
+// matches reports whether the subscription wants article.
+func (c *wsConn) matches(article *models.Article) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.subscribed && c.query.Matches(article)
+}
+
+// writeLoop writes replies, matching article events and keep-alive pings until the read loop
+// ends, a write fails, or the subscription ends, then closes the connection.
+func (c *wsConn) writeLoop() {
+	ping := time.NewTicker(c.h.Config.StreamHeartbeat)
+	defer func() {
+		ping.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case <-c.done:
+			return
+		case msg := <-c.replies:
+			if err := c.write(msg); err != nil {
+				return
+			}
+		case event, ok := <-c.sub.Events():
+			if !ok {
+				if c.sub.Lagged() {
+					c.close(websocket.ClosePolicyViolation, "slow consumer")
+				} else {
+					c.close(websocket.CloseGoingAway, "server shutting down")
+				}
+				return
+			}
+			if !c.matches(event.Article) {
+				continue
+			}
+			if err := c.write(models.WSArticleEvent{Type: "article", Event: string(event.Kind), Article: event.Article}); err != nil {
+				return
+			}
+		case <-ping.C:
+			if err := c.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// write sends msg as a JSON text message, giving up on clients that do not read it in time.
+func (c *wsConn) write(msg interface{}) error {
+	c.conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	return c.conn.WriteJSON(msg)
+}
+
+// close sends a close frame with the given code and reason and closes the connection.
+func (c *wsConn) close(code int, reason string) {
+	c.conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(wsWriteWait))
+	c.conn.Close()
+}
+
+// filterValues converts subscription filters to the query parameters they stand for: strings,
+// numbers and booleans become one value each, arrays one value per item, and null leaves the
+// filter unset.
+func filterValues(filters map[string]json.RawMessage) (url.Values, []models.InvalidParam) {
+	values := url.Values{}
+	var invalid []models.InvalidParam
+	names := make([]string, 0, len(filters))
+	for name := range filters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		raw := filters[name]
+		var items []json.RawMessage
+		if err := json.Unmarshal(raw, &items); err != nil {
+			items = []json.RawMessage{raw}
+		}
+		for _, item := range items {
+			value, ok := filterValue(item)
+			if !ok {
+				invalid = append(invalid, models.InvalidParam{Name: name, Reason: "must be a string, number, boolean or array of them"})
+				break
+			}
+			values.Add(name, value)
+		}
+	}
+	return values, invalid
+}
+
+// filterValue returns the query parameter value of a JSON string, number or boolean.
+func filterValue(raw json.RawMessage) (string, bool) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return "", false
+	}
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// dialWebSocket opens a WebSocket to handler, closed at the end of the test.
+func dialWebSocket(t *testing.T, handler http.Handler) *websocket.Conn {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), nil)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// wsMessage is any message of the WebSocket API.
+type wsMessage struct {
+	Type          string                `json:"type"`
+	ID            string                `json:"id"`
+	Event         string                `json:"event"`
+	Message       string                `json:"message"`
+	Article       *models.Article       `json:"article"`
+	Articles      []*models.Article     `json:"articles"`
+	InvalidParams []models.InvalidParam `json:"invalid_params"`
+}
+
+// roundTrip sends req and returns the next message, failing the test after a second.
+func roundTrip(t *testing.T, conn *websocket.Conn, req string) wsMessage {
+	t.Helper()
+	if req != "" {
+		if err := conn.WriteMessage(websocket.TextMessage, []byte(req)); err != nil {
+			t.Fatalf("Failed to send %s: %v", req, err)
+		}
+	}
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	var msg wsMessage
+	if err := conn.ReadJSON(&msg); err != nil {
+		t.Fatalf("Failed to read the reply to %s: %v", req, err)
+	}
+	return msg
+}
+
+// TestServeWebSocket verifies subscribing, changing filters, unsubscribing and pinging.
+func TestServeWebSocket(t *testing.T) {
+	h := newStreamHandler(nil)
+	conn := dialWebSocket(t, http.HandlerFunc(h.ServeWebSocket))
+	save := func(articles ...*models.Article) {
+		t.Helper()
+		if _, err := h.Store.SaveArticles(context.Background(), articles, store.SaveAtomic); err != nil {
+			t.Fatalf("SaveArticles failed: %v", err)
+		}
+	}
+	popular := &models.Article{HNID: 1, Title: "Popular", Summary: models.NewNullableString("Summary"), Upvotes: models.NewNullableInt(50)}
+
+	if msg := roundTrip(t, conn, `{"type":"subscribe","id":"1","filters":{"min_upvotes":10}}`); msg.Type != "subscribed" || msg.ID != "1" {
+		t.Fatalf("Expected subscribed, got %+v", msg)
+	}
+	save(popular, &models.Article{HNID: 2, Title: "Unpopular", Summary: models.NewNullableString("Summary")})
+	if msg := roundTrip(t, conn, ""); msg.Type != "article" || msg.Event != "created" || msg.Article.Title != "Popular" {
+		t.Errorf("Expected the created popular article, got %+v", msg)
+	}
+
+	// Resubscribing replaces the filters without reconnecting.
+	if msg := roundTrip(t, conn, `{"type":"subscribe","filters":{"min_upvotes":100}}`); msg.Type != "subscribed" {
+		t.Fatalf("Expected subscribed, got %+v", msg)
+	}
+	save(popular)
+	if msg := roundTrip(t, conn, `{"type":"ping","id":"p1"}`); msg.Type != "pong" || msg.ID != "p1" {
+		t.Errorf("Expected only a pong after saving a filtered out article, got %+v", msg)
+	}
+	if msg := roundTrip(t, conn, `{"type":"subscribe","filters":{"domain":["example.com"],"flagged":false}}`); msg.Type != "subscribed" {
+		t.Fatalf("Expected subscribed, got %+v", msg)
+	}
+	popular.Link = "https://example.com/popular"
+	save(popular)
+	if msg := roundTrip(t, conn, ""); msg.Type != "article" || msg.Event != "updated" || msg.Article.ID != 1 {
+		t.Errorf("Expected the updated popular article, got %+v", msg)
+	}
+
+	if msg := roundTrip(t, conn, `{"type":"unsubscribe"}`); msg.Type != "unsubscribed" {
+		t.Fatalf("Expected unsubscribed, got %+v", msg)
+	}
+	save(popular)
+	if msg := roundTrip(t, conn, `{"type":"ping"}`); msg.Type != "pong" {
+		t.Errorf("Expected only a pong after unsubscribing, got %+v", msg)
+	}
+}
+
+// TestServeWebSocket_Snapshot verifies that snapshots list the newest articles matching the subscription.
+func TestServeWebSocket_Snapshot(t *testing.T) {
+	h := newStreamHandler([]*models.Article{
+		{ID: 1, Title: "Old", Summary: models.NewNullableString("Summary"), Upvotes: models.NewNullableInt(50)},
+		{ID: 2, Title: "Unpopular", Summary: models.NewNullableString("Summary")},
+		{ID: 3, Title: "New", Summary: models.NewNullableString("Summary"), Upvotes: models.NewNullableInt(50)},
+	})
+	conn := dialWebSocket(t, http.HandlerFunc(h.ServeWebSocket))
+
+	if msg := roundTrip(t, conn, `{"type":"snapshot","id":"s1","limit":2}`); msg.Type != "snapshot" || msg.ID != "s1" || len(msg.Articles) != 2 {
+		t.Errorf("Expected a snapshot of 2 articles, got %+v", msg)
+	}
+	roundTrip(t, conn, `{"type":"subscribe","filters":{"min_upvotes":"10"}}`)
+	msg := roundTrip(t, conn, `{"type":"snapshot"}`)
+	if len(msg.Articles) != 2 || msg.Articles[0].ID != 3 || msg.Articles[1].ID != 1 {
+		t.Errorf("Expected articles 3 and 1, got %+v", msg.Articles)
+	}
+	if msg := roundTrip(t, conn, `{"type":"snapshot","limit":500}`); msg.Type != "error" || len(msg.InvalidParams) != 1 || msg.InvalidParams[0].Name != "limit" {
+		t.Errorf("Expected the limit to be rejected, got %+v", msg)
+	}
+}
+
+// TestServeWebSocket_Errors verifies the replies to invalid messages, which leave the connection open.
+func TestServeWebSocket_Errors(t *testing.T) {
+	conn := dialWebSocket(t, http.HandlerFunc(newStreamHandler(nil).ServeWebSocket))
+	for req, expected := range map[string][]string{
+		`not json`:                  nil,
+		`{"type":"shout","id":"x"}`: nil,
+		`{"type":"subscribe","filters":{"min_upvotes":-1,"sort":"hot","domain":{"a":1}}}`: {"domain", "min_upvotes", "sort"},
+		`{"type":"subscribe","filters":{"since":"yesterday","until":"2026-10-01"}}`:       {"since"},
+	} {
+		msg := roundTrip(t, conn, req)
+		if msg.Type != "error" || msg.Message == "" {
+			t.Errorf("%s: expected an error, got %+v", req, msg)
+		}
+		var names []string
+		for _, param := range msg.InvalidParams {
+			names = append(names, param.Name)
+		}
+		if strings.Join(names, ",") != strings.Join(expected, ",") {
+			t.Errorf("%s: expected invalid params %v, got %v", req, expected, names)
+		}
+	}
+	if msg := roundTrip(t, conn, `{"type":"ping"}`); msg.Type != "pong" {
+		t.Errorf("Expected the connection to stay usable, got %+v", msg)
+	}
+}
+
+// TestServeWebSocket_HubClosed verifies that closing the hub closes connections as going away.
+func TestServeWebSocket_HubClosed(t *testing.T) {
+	h := newStreamHandler(nil)
+	conn := dialWebSocket(t, http.HandlerFunc(h.ServeWebSocket))
+	roundTrip(t, conn, `{"type":"ping"}`)
+	h.Hub.Close()
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	_, _, err := conn.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.CloseGoingAway) {
+		t.Errorf("Expected a going away close, got %v", err)
+	}
+}
+
+// TestFilterValues verifies the conversion of subscription filters to query parameters.
+func TestFilterValues(t *testing.T) {
+	var filters map[string]json.RawMessage
+	json.Unmarshal([]byte(`{"flagged":true,"min_upvotes":10,"domain":["a.com","b.com"],"since":"24h","dead":null,"dupe":[]}`), &filters)
+	values, invalid := filterValues(filters)
+	if len(invalid) != 0 {
+		t.Errorf("Expected no invalid filters, got %+v", invalid)
+	}
+	if got := values.Encode(); got != "domain=a.com&domain=b.com&flagged=true&min_upvotes=10&since=24h" {
+		t.Errorf("Unexpected values %s", got)
+	}
+}
@@ -9,13 +9,19 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/k-zehnder/gophersignal/backend/config"
 	"github.com/k-zehnder/gophersignal/backend/internal/api/handlers"
+	"github.com/k-zehnder/gophersignal/backend/internal/pubsub"
 	"github.com/k-zehnder/gophersignal/backend/internal/store"
+	"github.com/k-zehnder/gophersignal/backend/internal/webhooks"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
 // NewRouter creates an http.Handler with configured routes and handlers.
-func NewRouter(store store.Store, cfg *config.AppConfig) http.Handler {
-	articlesHandler := handlers.NewArticlesHandler(store, cfg)
+// Articles saved through the handlers are published to hub for streaming and webhook delivery,
+// and dispatcher, which may be nil, backs the webhook admin API.
+func NewRouter(store store.Store, hub *pubsub.Hub, dispatcher *webhooks.Dispatcher, cfg *config.AppConfig) http.Handler {
+	articlesHandler := handlers.NewArticlesHandler(pubsub.NewPublishingStore(store, hub), cfg)
+	articlesHandler.Hub = hub
+	articlesHandler.Webhooks = dispatcher
 	return SetupRouter(articlesHandler)
 }
 
@@ -37,9 +43,37 @@ func SetupRouter(articlesHandler *handlers.ArticlesHandler) *mux.Router {
 	)
 	r.Use(cors)
 
+	// Compress responses for clients that talk to the server directly rather than through nginx.
+	r.Use(handlers.Compress(articlesHandler.Config.CompressMinSize))
+
 	// Setup API v1 routes.
 	apiRouter := r.PathPrefix("/api/v1").Subrouter()
+	apiRouter.Use(handlers.ConditionalGET)
 	apiRouter.Handle("/articles", articlesHandler).Methods("GET")
+	apiRouter.Handle("/articles", handlers.RequireToken(articlesHandler.Config.IngestToken)(http.HandlerFunc(articlesHandler.IngestArticles))).Methods("POST")
+	apiRouter.HandleFunc("/articles/stream", articlesHandler.StreamArticles).Methods("GET")
+	apiRouter.HandleFunc("/articles/{id:[0-9]+}", articlesHandler.GetArticle).Methods("GET")
+	apiRouter.HandleFunc("/articles/hn/{hn_id:[0-9]+}", articlesHandler.GetArticleByHNID).Methods("GET")
+	apiRouter.HandleFunc("/articles/{id:[0-9]+}/history", articlesHandler.GetArticleHistory).Methods("GET")
+	apiRouter.HandleFunc("/search", articlesHandler.SearchArticles).Methods("GET")
+	apiRouter.HandleFunc("/ws", articlesHandler.ServeWebSocket).Methods("GET")
+	apiRouter.HandleFunc("/domains", articlesHandler.GetDomains).Methods("GET")
+	apiRouter.HandleFunc("/facets", articlesHandler.GetFacets).Methods("GET")
+	apiRouter.HandleFunc("/feeds/rss.xml", articlesHandler.GetRSSFeed).Methods("GET")
+	apiRouter.HandleFunc("/feeds/atom.xml", articlesHandler.GetAtomFeed).Methods("GET")
+	apiRouter.HandleFunc("/feeds/feed.json", articlesHandler.GetJSONFeed).Methods("GET")
+
+	// Setup admin routes, which require the configured bearer token.
+	adminRouter := apiRouter.PathPrefix("/admin").Subrouter()
+	adminRouter.Use(handlers.RequireToken(articlesHandler.Config.AdminToken))
+	adminRouter.HandleFunc("/webhooks", articlesHandler.ListWebhooks).Methods("GET")
+	adminRouter.HandleFunc("/webhooks", articlesHandler.CreateWebhook).Methods("POST")
+	adminRouter.HandleFunc("/webhooks/{id:[0-9]+}", articlesHandler.GetWebhook).Methods("GET")
+	adminRouter.HandleFunc("/webhooks/{id:[0-9]+}", articlesHandler.UpdateWebhook).Methods("PUT")
+	adminRouter.HandleFunc("/webhooks/{id:[0-9]+}", articlesHandler.DeleteWebhook).Methods("DELETE")
+	adminRouter.HandleFunc("/webhooks/{id:[0-9]+}/deliveries", articlesHandler.ListWebhookDeliveries).Methods("GET")
+	adminRouter.HandleFunc("/deliveries/{id:[0-9]+}", articlesHandler.GetWebhookDelivery).Methods("GET")
+	adminRouter.HandleFunc("/deliveries/{id:[0-9]+}/replay", articlesHandler.ReplayWebhookDelivery).Methods("POST")
 
 	// Endpoint for Swagger documentation at '/swagger'.
 	r.PathPrefix("/swagger").Handler(httpSwagger.WrapHandler)
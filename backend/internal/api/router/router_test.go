@@ -2,13 +2,18 @@
 package router
 
 import (
+	"bufio"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/gorilla/websocket"
 	"github.com/k-zehnder/gophersignal/backend/config"
 	"github.com/k-zehnder/gophersignal/backend/internal/api/handlers"
 	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/pubsub"
 	"github.com/k-zehnder/gophersignal/backend/internal/store"
 )
 
@@ -36,3 +41,262 @@ func TestRouter_ArticlesRoute(t *testing.T) {
 		t.Errorf("handler returned wrong status code: got %v want %v", status, http.StatusOK)
 	}
 }
+
+// TestRouter_ArticleHistoryRoute tests that the history route resolves article IDs from the path.
+func TestRouter_ArticleHistoryRoute(t *testing.T) {
+	mockStore := store.NewMockStore([]*models.Article{{ID: 5, Title: "Test Article"}}, nil, nil)
+	router := SetupRouter(handlers.NewArticlesHandler(mockStore, config.NewConfig()))
+
+	for path, expected := range map[string]int{
+		"/api/v1/articles/5/history": http.StatusOK,
+		"/api/v1/articles/6/history": http.StatusNotFound,
+	} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+		if rr.Code != expected {
+			t.Errorf("%s: expected status %d, got %d", path, expected, rr.Code)
+		}
+	}
+}
+
+// TestRouter_SingleArticleRoutes tests the lookups by ID and by Hacker News ID.
+func TestRouter_SingleArticleRoutes(t *testing.T) {
+	mockStore := store.NewMockStore([]*models.Article{{ID: 5, HNID: 500, Title: "Test Article"}}, nil, nil)
+	router := SetupRouter(handlers.NewArticlesHandler(mockStore, config.NewConfig()))
+
+	for path, expected := range map[string]int{
+		"/api/v1/articles/5":      http.StatusOK,
+		"/api/v1/articles/hn/500": http.StatusOK,
+		"/api/v1/articles/6":      http.StatusNotFound,
+		"/api/v1/articles/hn/5":   http.StatusNotFound,
+	} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+		if rr.Code != expected {
+			t.Errorf("%s: expected status %d, got %d", path, expected, rr.Code)
+		}
+	}
+}
+
+// TestRouter_SummaryRoutes tests the per-domain summary and facet count routes.
+func TestRouter_SummaryRoutes(t *testing.T) {
+	router := SetupRouter(handlers.NewArticlesHandler(store.NewMockStore(nil, nil, nil), config.NewConfig()))
+	for _, path := range []string{"/api/v1/domains", "/api/v1/facets"} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+		if rr.Code != http.StatusOK {
+			t.Errorf("%s: expected status 200, got %d", path, rr.Code)
+		}
+	}
+}
+
+// TestRouter_ConditionalGET tests that API routes answer matching If-None-Match headers with 304.
+func TestRouter_ConditionalGET(t *testing.T) {
+	router := SetupRouter(handlers.NewArticlesHandler(store.NewMockStore(nil, nil, nil), config.NewConfig()))
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/articles", nil))
+
+	req := httptest.NewRequest("GET", "/api/v1/articles", nil)
+	req.Header.Set("If-None-Match", rr.Header().Get("ETag"))
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotModified {
+		t.Errorf("Expected status 304, got %d", rr.Code)
+	}
+}
+
+// TestRouter_Compression tests that responses are compressed when the client accepts it.
+func TestRouter_Compression(t *testing.T) {
+	articles := []*models.Article{{
+		ID:      1,
+		Title:   "Test Article",
+		Content: strings.Repeat("gopher ", 500),
+		Summary: models.NewNullableString("A summary."),
+	}}
+	router := SetupRouter(handlers.NewArticlesHandler(store.NewMockStore(articles, nil, nil), config.NewConfig()))
+	req := httptest.NewRequest("GET", "/api/v1/articles", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Header().Get("Content-Encoding") != "gzip" {
+		t.Errorf("Expected a gzip response, got Content-Encoding %q", rr.Header().Get("Content-Encoding"))
+	}
+	if rr.Header().Get("Vary") != "Accept-Encoding" {
+		t.Errorf("Expected Vary: Accept-Encoding, got %q", rr.Header().Get("Vary"))
+	}
+}
+
+// TestRouter_FeedRoutes tests the RSS, Atom and JSON Feed routes and their conditional GETs.
+func TestRouter_FeedRoutes(t *testing.T) {
+	router := SetupRouter(handlers.NewArticlesHandler(store.NewMockStore(nil, nil, nil), config.NewConfig()))
+	for _, path := range []string{"/api/v1/feeds/rss.xml", "/api/v1/feeds/atom.xml", "/api/v1/feeds/feed.json"} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest("GET", path, nil))
+		if rr.Code != http.StatusOK {
+			t.Errorf("%s: expected status 200, got %d", path, rr.Code)
+		}
+
+		req := httptest.NewRequest("GET", path, nil)
+		req.Header.Set("If-None-Match", rr.Header().Get("ETag"))
+		rr = httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		if rr.Code != http.StatusNotModified {
+			t.Errorf("%s: expected status 304, got %d", path, rr.Code)
+		}
+	}
+}
+
+// TestRouter_StreamRoute tests that saved articles are streamed through the middlewares unbuffered.
+func TestRouter_StreamRoute(t *testing.T) {
+	mockStore := store.NewMockStore(nil, nil, nil)
+	hub := pubsub.NewHub()
+	srv := httptest.NewServer(NewRouter(mockStore, hub, nil, config.NewConfig()))
+	defer srv.Close()
+	defer hub.Close()
+
+	req, _ := http.NewRequest("GET", srv.URL+"/api/v1/articles/stream", nil)
+	req.Header.Set("Accept-Encoding", "gzip")
+	resp, err := http.DefaultTransport.RoundTrip(req)
+	if err != nil {
+		t.Fatalf("Failed to open stream: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.Header.Get("Content-Encoding") != "" {
+		t.Errorf("Expected an uncompressed stream, got Content-Encoding %q", resp.Header.Get("Content-Encoding"))
+	}
+
+	lines := make(chan string)
+	go func() {
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+	if line := <-lines; line != "retry: 5000" {
+		t.Fatalf("Expected the retry hint, got %q", line)
+	}
+
+	// Publish directly, as a save through the router's store would.
+	hub.Publish(pubsub.Event{Kind: pubsub.Created, Article: &models.Article{ID: 7, Title: "Fresh", Summary: models.NewNullableString("Summary")}})
+	for {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				t.Fatal("Stream ended before the article event")
+			}
+			if line == "id: 7-0" {
+				return
+			}
+		case <-time.After(time.Second):
+			t.Fatal("Timed out waiting for the article event")
+		}
+	}
+}
+
+// TestRouter_WebSocketRoute tests that WebSocket upgrades pass through the middlewares and
+// receive events published to the hub.
+func TestRouter_WebSocketRoute(t *testing.T) {
+	hub := pubsub.NewHub()
+	srv := httptest.NewServer(NewRouter(store.NewMockStore(nil, nil, nil), hub, nil, config.NewConfig()))
+	defer srv.Close()
+
+	header := http.Header{"Accept-Encoding": {"gzip"}, "If-None-Match": {"*"}}
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/api/v1/ws", header)
+	if err != nil {
+		t.Fatalf("Failed to dial: %v", err)
+	}
+	defer conn.Close()
+
+	conn.SetReadDeadline(time.Now().Add(time.Second))
+	var reply models.WSReply
+	if err := conn.WriteJSON(models.WSRequest{Type: "subscribe"}); err != nil {
+		t.Fatalf("Failed to subscribe: %v", err)
+	}
+	if err := conn.ReadJSON(&reply); err != nil || reply.Type != "subscribed" {
+		t.Fatalf("Expected subscribed, got %+v (%v)", reply, err)
+	}
+
+	hub.Publish(pubsub.Event{Kind: pubsub.Created, Article: &models.Article{ID: 7, Title: "Fresh", Summary: models.NewNullableString("Summary")}})
+	var event models.WSArticleEvent
+	if err := conn.ReadJSON(&event); err != nil || event.Article == nil || event.Article.ID != 7 || event.Event != "created" {
+		t.Errorf("Expected the created article 7, got %+v (%v)", event, err)
+	}
+}
+
+// TestRouter_AdminRoutes tests that the admin routes require the configured bearer token.
+func TestRouter_AdminRoutes(t *testing.T) {
+	tests := []struct {
+		token, header string
+		expected      int
+	}{
+		{"", "Bearer anything", http.StatusForbidden},
+		{"s3cret", "", http.StatusUnauthorized},
+		{"s3cret", "Bearer wrong", http.StatusUnauthorized},
+		{"s3cret", "Bearer s3cret", http.StatusServiceUnavailable},
+	}
+	for _, tc := range tests {
+		cfg := config.NewConfig()
+		cfg.AdminToken = tc.token
+		router := SetupRouter(handlers.NewArticlesHandler(store.NewMockStore(nil, nil, nil), cfg))
+
+		req := httptest.NewRequest("GET", "/api/v1/admin/webhooks", nil)
+		if tc.header != "" {
+			req.Header.Set("Authorization", tc.header)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+
+		// The mock store has no webhooks, so admitted requests find the API unavailable.
+		if rr.Code != tc.expected {
+			t.Errorf("token %q, header %q: expected status %d, got %d", tc.token, tc.header, tc.expected, rr.Code)
+		}
+	}
+}
+
+// TestRouter_IngestRoute tests that POST /api/v1/articles requires the ingest token and publishes
+// the articles it saves, while GET stays public.
+func TestRouter_IngestRoute(t *testing.T) {
+	mockStore := store.NewMockStore(nil, nil, nil)
+	hub := pubsub.NewHub()
+	defer hub.Close()
+	subscription := hub.Subscribe(1)
+	defer subscription.Close()
+	cfg := config.NewConfig()
+	cfg.IngestToken = "ingest"
+	cfg.AdminToken = "admin"
+	router := NewRouter(mockStore, hub, nil, cfg)
+
+	body := `[{"hn_id": 1, "title": "Ingested", "link": "https://go.dev"}]`
+	for header, expected := range map[string]int{
+		"":              http.StatusUnauthorized,
+		"Bearer admin":  http.StatusUnauthorized,
+		"Bearer ingest": http.StatusOK,
+	} {
+		req := httptest.NewRequest("POST", "/api/v1/articles", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		if rr.Code != expected {
+			t.Errorf("header %q: expected status %d, got %d: %s", header, expected, rr.Code, rr.Body)
+		}
+	}
+
+	select {
+	case event := <-subscription.Events():
+		if event.Kind != pubsub.Created || event.Article.Title != "Ingested" {
+			t.Errorf("Expected a created event for the ingested article, got %+v", event)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Timed out waiting for the ingested article to be published")
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest("GET", "/api/v1/articles", nil))
+	if rr.Code != http.StatusOK {
+		t.Errorf("Expected GET /api/v1/articles to stay public, got %d", rr.Code)
+	}
+}
@@ -34,6 +34,11 @@ func GracefulShutdown(server *http.Server) {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 	if err := server.Shutdown(ctx); err != nil {
-		log.Fatalf("Failed to shutdown server: %v", err)
+		// Closing the remaining connections cancels their request contexts,
+		// which aborts any store queries still running on their behalf.
+		log.Printf("Graceful shutdown timed out, closing active connections: %v", err)
+		if err := server.Close(); err != nil {
+			log.Fatalf("Failed to shutdown server: %v", err)
+		}
 	}
 }
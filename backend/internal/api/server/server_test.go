@@ -10,23 +10,26 @@ import (
 	"github.com/k-zehnder/gophersignal/backend/config"
 	"github.com/k-zehnder/gophersignal/backend/internal/api/router"
 	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/pubsub"
 	"github.com/k-zehnder/gophersignal/backend/internal/store"
 )
 
 // TestNewServer validates the server's behavior by simulating HTTP requests with mock data
 // and verifying the responses, thus confirming the accuracy and reliability of the API's outputs.
 func TestNewServer(t *testing.T) {
-	// Initialize test articles
+	// Initialize test articles, newest first as the API returns them
 	mockArticles := []*models.Article{
-		{
-			ID:      1,
-			Title:   "Test Article 1",
-			Content: "Content of Test Article 1",
-		},
 		{
 			ID:      2,
 			Title:   "Test Article 2",
 			Content: "Content of Test Article 2",
+			Summary: models.NewNullableString("Summary of Test Article 2"),
+		},
+		{
+			ID:      1,
+			Title:   "Test Article 1",
+			Content: "Content of Test Article 1",
+			Summary: models.NewNullableString("Summary of Test Article 1"),
 		},
 	}
 
@@ -35,7 +38,7 @@ func TestNewServer(t *testing.T) {
 
 	// Initialize configuration and then the router with the mock store
 	cfg := config.NewConfig()
-	handler := router.NewRouter(mockStore, cfg)
+	handler := router.NewRouter(mockStore, pubsub.NewHub(), nil, cfg)
 
 	// Adjust the request URL to include the API prefix
 	req, err := http.NewRequest("GET", "/api/v1/articles", nil)
@@ -0,0 +1,327 @@
+// Package migrations manages versioned schema changes for the SQL stores.
+// Migrations are embedded SQL files named "<version>_<name>.up.sql" and "<version>_<name>.down.sql",
+// kept in one directory per dialect, and applied versions are recorded in a schema_migrations table.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//go:embed mysql/*.sql sqlite/*.sql postgres/*.sql
+var files embed.FS
+
+// Supported dialects, which are also the names of the embedded migration directories.
+const (
+	MySQL    = "mysql"
+	SQLite   = "sqlite"
+	Postgres = "postgres"
+)
+
+// bookkeepingTables creates the schema_migrations table in each dialect.
+var bookkeepingTables = map[string]string{
+	MySQL: `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INT PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	)`,
+	SQLite: `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	)`,
+	Postgres: `CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL
+	)`,
+}
+
+// Migration is a single versioned schema change.
+type Migration struct {
+	Version int    // Position in the sequence, starting at 1.
+	Name    string // Human-readable name taken from the file name.
+	Up      string // SQL applying the change.
+	Down    string // SQL reverting the change.
+}
+
+// Status describes whether a migration has been applied.
+type Status struct {
+	Migration
+	Applied   bool      // Whether the migration is recorded in schema_migrations.
+	AppliedAt time.Time // When the migration was applied; zero if pending.
+}
+
+// Migrator applies and reverts the embedded migrations of one dialect against a database.
+type Migrator struct {
+	db         *sql.DB
+	dialect    string
+	migrations []Migration
+}
+
+// New creates a Migrator for db using the migrations of the given dialect.
+func New(db *sql.DB, dialect string) (*Migrator, error) {
+	if _, ok := bookkeepingTables[dialect]; !ok {
+		return nil, fmt.Errorf("unsupported migration dialect %q", dialect)
+	}
+	migrations, err := load(dialect)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, dialect: dialect, migrations: migrations}, nil
+}
+
+// load reads and validates the embedded migrations of dialect, ordered by version.
+func load(dialect string) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, dialect)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s migrations: %w", dialect, err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, entry := range entries {
+		name := entry.Name()
+		base, direction, ok := strings.Cut(strings.TrimSuffix(name, ".sql"), ".")
+		versionStr, title, found := strings.Cut(base, "_")
+		version, err := strconv.Atoi(versionStr)
+		if !ok || !found || err != nil || version <= 0 || (direction != "up" && direction != "down") {
+			return nil, fmt.Errorf("invalid migration file name %q", name)
+		}
+		body, err := fs.ReadFile(files, path.Join(dialect, name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %q: %w", name, err)
+		}
+
+		m, exists := byVersion[version]
+		if !exists {
+			m = &Migration{Version: version, Name: title}
+			byVersion[version] = m
+		} else if m.Name != title {
+			return nil, fmt.Errorf("migration %d has conflicting names %q and %q", version, m.Name, title)
+		}
+		if direction == "up" {
+			m.Up = string(body)
+		} else {
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migration %d_%s needs both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	for i, m := range migrations {
+		if m.Version != i+1 {
+			return nil, fmt.Errorf("%s migrations are not contiguous: expected version %d, found %d", dialect, i+1, m.Version)
+		}
+	}
+	return migrations, nil
+}
+
+// Migrations returns the known migrations in version order.
+func (m *Migrator) Migrations() []Migration {
+	return m.migrations
+}
+
+// Latest returns the version of the newest known migration.
+func (m *Migrator) Latest() int {
+	return len(m.migrations)
+}
+
+// Version returns the version of the newest applied migration, or 0 for an unmigrated database.
+func (m *Migrator) Version(ctx context.Context) (int, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return 0, err
+	}
+	version := 0
+	for v := range applied {
+		if v > version {
+			version = v
+		}
+	}
+	return version, nil
+}
+
+// Status reports every known migration and whether it has been applied.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, len(m.migrations))
+	for i, migration := range m.migrations {
+		appliedAt, ok := applied[migration.Version]
+		statuses[i] = Status{Migration: migration, Applied: ok, AppliedAt: appliedAt}
+	}
+	return statuses, nil
+}
+
+// Up applies every pending migration.
+func (m *Migrator) Up(ctx context.Context) error {
+	return m.To(ctx, m.Latest())
+}
+
+// Down reverts the most recently applied migration, if any.
+func (m *Migrator) Down(ctx context.Context) error {
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		return nil
+	}
+	return m.To(ctx, version-1)
+}
+
+// To applies or reverts migrations until the database is at target.
+// A target of 0 reverts every migration.
+func (m *Migrator) To(ctx context.Context, target int) error {
+	if target < 0 || target > m.Latest() {
+		return fmt.Errorf("target version %d out of range 0-%d", target, m.Latest())
+	}
+	version, err := m.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if version > m.Latest() {
+		return fmt.Errorf("database is at version %d, newer than the latest known migration %d", version, m.Latest())
+	}
+
+	for version < target {
+		if err := m.apply(ctx, m.migrations[version], true); err != nil {
+			return err
+		}
+		version++
+	}
+	for version > target {
+		if err := m.apply(ctx, m.migrations[version-1], false); err != nil {
+			return err
+		}
+		version--
+	}
+	return nil
+}
+
+// apply runs one direction of a migration and records the outcome in a single transaction.
+// MySQL commits DDL implicitly, so a failed MySQL migration may need manual repair.
+func (m *Migrator) apply(ctx context.Context, migration Migration, up bool) error {
+	direction, body := "up", migration.Up
+	if !up {
+		direction, body = "down", migration.Down
+	}
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin migration %d: %w", migration.Version, err)
+	}
+	defer tx.Rollback()
+
+	for _, stmt := range splitStatements(body) {
+		if _, err := tx.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("migration %d_%s %s failed: %w", migration.Version, migration.Name, direction, err)
+		}
+	}
+
+	if up {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO schema_migrations (version, name, applied_at) VALUES ("+m.placeholders(3)+")",
+			migration.Version, migration.Name, time.Now().UTC())
+	} else {
+		_, err = tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = "+m.placeholders(1), migration.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+	}
+	return tx.Commit()
+}
+
+// applied returns the applied migration versions and when they were applied,
+// creating the bookkeeping table on first use.
+func (m *Migrator) applied(ctx context.Context) (map[int]time.Time, error) {
+	if _, err := m.db.ExecContext(ctx, bookkeepingTables[m.dialect]); err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations table: %w", err)
+	}
+	rows, err := m.db.QueryContext(ctx, "SELECT version, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]time.Time)
+	for rows.Next() {
+		var version int
+		var appliedAt time.Time
+		if err := rows.Scan(&version, &appliedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan schema_migrations: %w", err)
+		}
+		applied[version] = appliedAt
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+	return applied, nil
+}
+
+// placeholders returns n comma-separated bind parameters in the dialect's syntax.
+func (m *Migrator) placeholders(n int) string {
+	params := make([]string, n)
+	for i := range params {
+		if m.dialect == Postgres {
+			params[i] = "$" + strconv.Itoa(i+1)
+		} else {
+			params[i] = "?"
+		}
+	}
+	return strings.Join(params, ", ")
+}
+
+// splitStatements splits a migration file into individual statements, since not every
+// driver accepts several statements per Exec. Statements are separated by semicolons at
+// the end of a line, and lines starting with "--" are comments. A line ending in BEGIN opens
+// a block, such as a trigger body, that only a line reading "END;" closes.
+func splitStatements(body string) []string {
+	var statements []string
+	var current strings.Builder
+	inBlock := false
+	for _, line := range strings.Split(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		current.WriteString(line)
+		current.WriteString("\n")
+		upper := strings.ToUpper(trimmed)
+		if strings.HasSuffix(upper, "BEGIN") {
+			inBlock = true
+		}
+		if inBlock {
+			if upper == "END;" {
+				inBlock = false
+				statements = append(statements, strings.TrimSpace(current.String()))
+				current.Reset()
+			}
+			continue
+		}
+		if strings.HasSuffix(trimmed, ";") {
+			statements = append(statements, strings.TrimSpace(current.String()))
+			current.Reset()
+		}
+	}
+	if rest := strings.TrimSpace(current.String()); rest != "" {
+		statements = append(statements, rest)
+	}
+	return statements
+}
@@ -0,0 +1,290 @@
+// Package migrations contains tests for loading, applying and reverting schema migrations.
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	_ "github.com/go-sql-driver/mysql"
+	_ "modernc.org/sqlite"
+)
+
+// newTestMigrator returns a Migrator for a fresh SQLite database.
+func newTestMigrator(t *testing.T) (*Migrator, *sql.DB) {
+	t.Helper()
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "migrations.db"))
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+	t.Cleanup(func() { db.Close() })
+
+	m, err := New(db, SQLite)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+	return m, db
+}
+
+// tableExists reports whether the named table exists in the SQLite database.
+func tableExists(t *testing.T, db *sql.DB, name string) bool {
+	t.Helper()
+	var count int
+	if err := db.QueryRow("SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?", name).Scan(&count); err != nil {
+		t.Fatalf("Failed to inspect schema: %v", err)
+	}
+	return count > 0
+}
+
+// TestLoad verifies that every dialect ships a contiguous, complete migration set of the same length.
+func TestLoad(t *testing.T) {
+	var latest int
+	for i, dialect := range []string{MySQL, SQLite, Postgres} {
+		migrations, err := load(dialect)
+		if err != nil {
+			t.Fatalf("load(%s) failed: %v", dialect, err)
+		}
+		if len(migrations) == 0 {
+			t.Fatalf("Expected %s migrations", dialect)
+		}
+		if i == 0 {
+			latest = len(migrations)
+		} else if len(migrations) != latest {
+			t.Errorf("Expected %d %s migrations to match MySQL, got %d", latest, dialect, len(migrations))
+		}
+	}
+}
+
+// TestNew_UnknownDialect verifies that unsupported dialects are rejected.
+func TestNew_UnknownDialect(t *testing.T) {
+	if _, err := New(nil, "oracle"); err == nil {
+		t.Fatal("Expected error for unknown dialect")
+	}
+}
+
+// TestMigrator_UpDown verifies applying and reverting every migration.
+func TestMigrator_UpDown(t *testing.T) {
+	ctx := context.Background()
+	m, db := newTestMigrator(t)
+
+	if version, err := m.Version(ctx); err != nil || version != 0 {
+		t.Fatalf("Expected version 0, got %d (%v)", version, err)
+	}
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	if version, err := m.Version(ctx); err != nil || version != m.Latest() {
+		t.Fatalf("Expected version %d, got %d (%v)", m.Latest(), version, err)
+	}
+	if !tableExists(t, db, "articles") {
+		t.Fatal("Expected articles table after Up")
+	}
+
+	// Up is idempotent once the schema is current.
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("Second Up failed: %v", err)
+	}
+
+	if err := m.To(ctx, 0); err != nil {
+		t.Fatalf("To(0) failed: %v", err)
+	}
+	if version, err := m.Version(ctx); err != nil || version != 0 {
+		t.Fatalf("Expected version 0 after reverting, got %d (%v)", version, err)
+	}
+	if tableExists(t, db, "articles") {
+		t.Fatal("Expected articles table to be dropped")
+	}
+}
+
+// TestMigrator_Down verifies that Down reverts exactly one migration.
+func TestMigrator_Down(t *testing.T) {
+	ctx := context.Background()
+	m, _ := newTestMigrator(t)
+
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	if err := m.Down(ctx); err != nil {
+		t.Fatalf("Down failed: %v", err)
+	}
+	if version, err := m.Version(ctx); err != nil || version != m.Latest()-1 {
+		t.Fatalf("Expected version %d, got %d (%v)", m.Latest()-1, version, err)
+	}
+}
+
+// TestMigrator_Status verifies that Status reports applied and pending migrations.
+func TestMigrator_Status(t *testing.T) {
+	ctx := context.Background()
+	m, _ := newTestMigrator(t)
+
+	if err := m.To(ctx, 1); err != nil {
+		t.Fatalf("To(1) failed: %v", err)
+	}
+	statuses, err := m.Status(ctx)
+	if err != nil {
+		t.Fatalf("Status failed: %v", err)
+	}
+	if len(statuses) != m.Latest() {
+		t.Fatalf("Expected %d statuses, got %d", m.Latest(), len(statuses))
+	}
+	for _, status := range statuses {
+		applied := status.Version <= 1
+		if status.Applied != applied || status.AppliedAt.IsZero() == applied {
+			t.Errorf("Unexpected status for version %d: %+v", status.Version, status)
+		}
+	}
+}
+
+// TestMigrator_ToOutOfRange verifies that targets beyond the known migrations are rejected.
+func TestMigrator_ToOutOfRange(t *testing.T) {
+	m, _ := newTestMigrator(t)
+	if err := m.To(context.Background(), m.Latest()+1); err == nil {
+		t.Fatal("Expected error for out-of-range target")
+	}
+	if err := m.To(context.Background(), -1); err == nil {
+		t.Fatal("Expected error for negative target")
+	}
+}
+
+// TestSplitStatements verifies statement splitting and comment removal.
+func TestSplitStatements(t *testing.T) {
+	body := `-- Add a column.
+ALTER TABLE articles ADD COLUMN domain VARCHAR(255);
+
+CREATE INDEX idx_articles_domain
+    ON articles (domain);
+`
+	expected := []string{
+		"ALTER TABLE articles ADD COLUMN domain VARCHAR(255);",
+		"CREATE INDEX idx_articles_domain\n    ON articles (domain);",
+	}
+	if got := splitStatements(body); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+// TestSplitStatements_Blocks verifies that semicolons inside BEGIN ... END blocks do not end a statement.
+func TestSplitStatements_Blocks(t *testing.T) {
+	body := `CREATE TRIGGER articles_ai AFTER INSERT ON articles BEGIN
+  INSERT INTO log (id) VALUES (new.id);
+  DELETE FROM log WHERE id < 0;
+END;
+DROP TABLE stale;
+`
+	expected := []string{
+		"CREATE TRIGGER articles_ai AFTER INSERT ON articles BEGIN\n  INSERT INTO log (id) VALUES (new.id);\n  DELETE FROM log WHERE id < 0;\nEND;",
+		"DROP TABLE stale;",
+	}
+	if got := splitStatements(body); !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+// TestMigrator_ArticleKeyBackfill verifies that adding article keys merges previously appended duplicates
+// into the newest copy while keeping the date of the first sighting.
+func TestMigrator_ArticleKeyBackfill(t *testing.T) {
+	ctx := context.Background()
+	m, db := newTestMigrator(t)
+	if err := m.To(ctx, 1); err != nil {
+		t.Fatalf("To(1) failed: %v", err)
+	}
+	for _, row := range []struct {
+		hnID      int
+		upvotes   int
+		createdAt string
+	}{
+		{7, 10, "2026-10-01 10:00:00"},
+		{8, 5, "2026-10-01 11:00:00"},
+		{7, 30, "2026-10-01 12:00:00"},
+	} {
+		if _, err := db.Exec(
+			"INSERT INTO articles (hn_id, title, link, article_rank, source, upvotes, created_at, updated_at) VALUES (?, 'Title', 'https://example.com', 1, 'Hacker News', ?, ?, ?)",
+			row.hnID, row.upvotes, row.createdAt, row.createdAt,
+		); err != nil {
+			t.Fatalf("Failed to insert article: %v", err)
+		}
+	}
+	if err := m.To(ctx, 2); err != nil {
+		t.Fatalf("To(2) failed: %v", err)
+	}
+
+	var id, upvotes, count int
+	var createdAt string
+	if err := db.QueryRow("SELECT COUNT(*) FROM articles").Scan(&count); err != nil || count != 2 {
+		t.Fatalf("Expected 2 articles, got %d (%v)", count, err)
+	}
+	if err := db.QueryRow("SELECT id, upvotes, created_at FROM articles WHERE article_key = 'hn:7'").Scan(&id, &upvotes, &createdAt); err != nil {
+		t.Fatalf("Failed to read merged article: %v", err)
+	}
+	if id != 3 || upvotes != 30 || createdAt[:19] != "2026-10-01T10:00:00" {
+		t.Errorf("Expected newest copy dated by first sighting, got id %d, %d upvotes, created %s", id, upvotes, createdAt)
+	}
+}
+
+// legacyMySQLSchema is the articles table of the original schema.sql as deployed before the
+// summarizer columns commit_hash and model_name were added to it.
+const legacyMySQLSchema = `CREATE TABLE articles (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    hn_id INT NOT NULL DEFAULT 0,
+    title VARCHAR(255) NOT NULL,
+    link VARCHAR(512) NOT NULL,
+    article_rank INT NOT NULL,
+    content TEXT,
+    summary VARCHAR(2000),
+    source VARCHAR(100) NOT NULL,
+    upvotes INT DEFAULT 0,
+    comment_count INT DEFAULT 0,
+    comment_link VARCHAR(255),
+    flagged BOOLEAN NOT NULL DEFAULT FALSE,
+    dead BOOLEAN NOT NULL DEFAULT FALSE,
+    dupe BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+)`
+
+// TestMigrator_MySQLLegacySchema verifies that migrating a database created from the legacy
+// schema adds the columns it lacks, when MYSQL_TEST_DSN names a disposable MySQL database.
+func TestMigrator_MySQLLegacySchema(t *testing.T) {
+	dsn := os.Getenv("MYSQL_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_TEST_DSN not set; skipping MySQL migration tests")
+	}
+	ctx := context.Background()
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	m, err := New(db, MySQL)
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	if err := m.To(ctx, 0); err != nil {
+		t.Fatalf("To(0) failed: %v", err)
+	}
+	for _, stmt := range []string{
+		"DROP TABLE IF EXISTS articles",
+		legacyMySQLSchema,
+		"INSERT INTO articles (hn_id, title, link, article_rank, source, created_at, updated_at) VALUES (1, 'Legacy', 'https://example.com', 1, 'Hacker News', NOW(), NOW())",
+	} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("Failed to create legacy schema: %v", err)
+		}
+	}
+
+	if err := m.Up(ctx); err != nil {
+		t.Fatalf("Up failed: %v", err)
+	}
+	var commitHash, modelName string
+	if err := db.QueryRowContext(ctx, "SELECT commit_hash, model_name FROM articles WHERE hn_id = 1").Scan(&commitHash, &modelName); err != nil {
+		t.Fatalf("Expected the summarizer columns after Up: %v", err)
+	}
+	if commitHash != "" || modelName != "" {
+		t.Errorf("Expected empty defaults, got %q and %q", commitHash, modelName)
+	}
+}
@@ -0,0 +1,44 @@
+CREATE TABLE IF NOT EXISTS articles (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    hn_id INT NOT NULL DEFAULT 0,
+    title VARCHAR(255) NOT NULL,
+    link VARCHAR(512) NOT NULL,
+    article_rank INT NOT NULL,
+    content TEXT,
+    summary VARCHAR(2000),
+    source VARCHAR(100) NOT NULL,
+    upvotes INT DEFAULT 0,
+    comment_count INT DEFAULT 0,
+    comment_link VARCHAR(255),
+    flagged BOOLEAN NOT NULL DEFAULT FALSE,
+    dead BOOLEAN NOT NULL DEFAULT FALSE,
+    dupe BOOLEAN NOT NULL DEFAULT FALSE,
+    commit_hash VARCHAR(7)   NOT NULL DEFAULT '',
+    model_name  VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL
+);
+
+-- Databases created from the original schema.sql may predate the summarizer columns, which
+-- CREATE TABLE IF NOT EXISTS would not add, so add each of them where it is missing.
+SET @add_commit_hash = (
+    SELECT IF(COUNT(*) = 0,
+              'ALTER TABLE articles ADD COLUMN commit_hash VARCHAR(7) NOT NULL DEFAULT '''' AFTER dupe',
+              'DO 0')
+    FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'articles' AND column_name = 'commit_hash'
+);
+PREPARE add_column FROM @add_commit_hash;
+EXECUTE add_column;
+DEALLOCATE PREPARE add_column;
+
+SET @add_model_name = (
+    SELECT IF(COUNT(*) = 0,
+              'ALTER TABLE articles ADD COLUMN model_name VARCHAR(100) NOT NULL DEFAULT '''' AFTER commit_hash',
+              'DO 0')
+    FROM information_schema.columns
+    WHERE table_schema = DATABASE() AND table_name = 'articles' AND column_name = 'model_name'
+);
+PREPARE add_column FROM @add_model_name;
+EXECUTE add_column;
+DEALLOCATE PREPARE add_column;
@@ -0,0 +1,23 @@
+-- Identify each article by its Hacker News ID so that repeated scrapes update one row.
+-- Rows without an HN ID are keyed by their normalized link after migrating (see BackfillArticleKeys).
+ALTER TABLE articles ADD COLUMN article_key VARCHAR(512) NULL;
+UPDATE articles SET article_key = CONCAT('hn:', hn_id) WHERE hn_id > 0;
+
+-- Keep the newest copy of each article, dated by its first sighting.
+UPDATE articles a
+  JOIN (
+    SELECT article_key, MAX(id) AS max_id, MIN(created_at) AS first_seen
+    FROM articles
+    WHERE article_key IS NOT NULL
+    GROUP BY article_key
+  ) b ON a.id = b.max_id
+   SET a.created_at = b.first_seen;
+DELETE a FROM articles a
+  JOIN (
+    SELECT article_key, MAX(id) AS max_id
+    FROM articles
+    WHERE article_key IS NOT NULL
+    GROUP BY article_key
+  ) b ON a.article_key = b.article_key AND a.id < b.max_id;
+
+CREATE UNIQUE INDEX idx_articles_article_key ON articles (article_key);
@@ -0,0 +1,15 @@
+-- One row per sighting of an article, recording where it stood on the front page.
+CREATE TABLE IF NOT EXISTS article_snapshots (
+    id INT AUTO_INCREMENT PRIMARY KEY,
+    article_id INT NOT NULL,
+    article_rank INT NOT NULL,
+    upvotes INT,
+    comment_count INT,
+    captured_at TIMESTAMP NOT NULL,
+    FOREIGN KEY (article_id) REFERENCES articles (id) ON DELETE CASCADE
+);
+CREATE INDEX idx_article_snapshots_article ON article_snapshots (article_id, captured_at);
+
+-- Seed the history with the latest known state of every stored article.
+INSERT INTO article_snapshots (article_id, article_rank, upvotes, comment_count, captured_at)
+SELECT id, article_rank, upvotes, comment_count, updated_at FROM articles;
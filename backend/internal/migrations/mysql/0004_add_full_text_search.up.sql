@@ -0,0 +1,2 @@
+-- Full-text index backing the search endpoint.
+CREATE FULLTEXT INDEX idx_articles_search ON articles (title, content, summary);
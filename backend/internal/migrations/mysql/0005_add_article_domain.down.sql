@@ -0,0 +1,2 @@
+DROP INDEX idx_articles_domain ON articles;
+ALTER TABLE articles DROP COLUMN domain;
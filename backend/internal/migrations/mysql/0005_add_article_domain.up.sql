@@ -0,0 +1,5 @@
+-- Record the registrable domain of each article's link, such as "github.com" for
+-- "https://gist.github.com/...", for filtering and per-site statistics. Domains need the
+-- public suffix list, so existing rows are backfilled by the store after migrating.
+ALTER TABLE articles ADD COLUMN domain VARCHAR(255) NOT NULL DEFAULT '';
+CREATE INDEX idx_articles_domain ON articles (domain);
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS articles (
+    id SERIAL PRIMARY KEY,
+    hn_id INTEGER NOT NULL DEFAULT 0,
+    title VARCHAR(255) NOT NULL,
+    link VARCHAR(512) NOT NULL,
+    article_rank INTEGER NOT NULL,
+    content TEXT,
+    summary VARCHAR(2000),
+    source VARCHAR(100) NOT NULL,
+    upvotes INTEGER DEFAULT 0,
+    comment_count INTEGER DEFAULT 0,
+    comment_link VARCHAR(255),
+    flagged BOOLEAN NOT NULL DEFAULT FALSE,
+    dead BOOLEAN NOT NULL DEFAULT FALSE,
+    dupe BOOLEAN NOT NULL DEFAULT FALSE,
+    commit_hash VARCHAR(7) NOT NULL DEFAULT '',
+    model_name VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
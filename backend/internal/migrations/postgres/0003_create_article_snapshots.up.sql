@@ -0,0 +1,14 @@
+-- One row per sighting of an article, recording where it stood on the front page.
+CREATE TABLE IF NOT EXISTS article_snapshots (
+    id SERIAL PRIMARY KEY,
+    article_id INTEGER NOT NULL REFERENCES articles (id) ON DELETE CASCADE,
+    article_rank INTEGER NOT NULL,
+    upvotes INTEGER,
+    comment_count INTEGER,
+    captured_at TIMESTAMPTZ NOT NULL
+);
+CREATE INDEX idx_article_snapshots_article ON article_snapshots (article_id, captured_at);
+
+-- Seed the history with the latest known state of every stored article.
+INSERT INTO article_snapshots (article_id, article_rank, upvotes, comment_count, captured_at)
+SELECT id, article_rank, upvotes, comment_count, updated_at FROM articles;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_articles_search;
+ALTER TABLE articles DROP COLUMN search_vector;
@@ -0,0 +1,7 @@
+-- Weighted text search vector backing the search endpoint: title ranks above summary above content.
+ALTER TABLE articles ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(summary, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(content, '')), 'C')
+) STORED;
+CREATE INDEX idx_articles_search ON articles USING GIN (search_vector);
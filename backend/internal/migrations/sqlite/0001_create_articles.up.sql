@@ -1,23 +1,20 @@
-CREATE DATABASE IF NOT EXISTS gophersignal;
-USE gophersignal;
-
 CREATE TABLE IF NOT EXISTS articles (
-    id INT AUTO_INCREMENT PRIMARY KEY,
-    hn_id INT NOT NULL DEFAULT 0, 
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    hn_id INTEGER NOT NULL DEFAULT 0,
     title VARCHAR(255) NOT NULL,
     link VARCHAR(512) NOT NULL,
-    article_rank INT NOT NULL, 
+    article_rank INTEGER NOT NULL,
     content TEXT,
     summary VARCHAR(2000),
     source VARCHAR(100) NOT NULL,
-    upvotes INT DEFAULT 0,
-    comment_count INT DEFAULT 0,
+    upvotes INTEGER DEFAULT 0,
+    comment_count INTEGER DEFAULT 0,
     comment_link VARCHAR(255),
     flagged BOOLEAN NOT NULL DEFAULT FALSE,
     dead BOOLEAN NOT NULL DEFAULT FALSE,
     dupe BOOLEAN NOT NULL DEFAULT FALSE,
-    commit_hash VARCHAR(7)   NOT NULL DEFAULT '',
-    model_name  VARCHAR(100) NOT NULL DEFAULT '',
+    commit_hash VARCHAR(7) NOT NULL DEFAULT '',
+    model_name VARCHAR(100) NOT NULL DEFAULT '',
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP NOT NULL
 );
@@ -0,0 +1,2 @@
+DROP INDEX idx_articles_article_key;
+ALTER TABLE articles DROP COLUMN article_key;
@@ -0,0 +1,14 @@
+-- Identify each article by its Hacker News ID so that repeated scrapes update one row.
+-- Rows without an HN ID are keyed by their normalized link after migrating (see BackfillArticleKeys).
+ALTER TABLE articles ADD COLUMN article_key VARCHAR(512);
+UPDATE articles SET article_key = 'hn:' || hn_id WHERE hn_id > 0;
+
+-- Keep the newest copy of each article, dated by its first sighting.
+UPDATE articles
+   SET created_at = (SELECT MIN(b.created_at) FROM articles b WHERE b.article_key = articles.article_key)
+ WHERE article_key IS NOT NULL;
+DELETE FROM articles
+ WHERE article_key IS NOT NULL
+   AND id < (SELECT MAX(b.id) FROM articles b WHERE b.article_key = articles.article_key);
+
+CREATE UNIQUE INDEX idx_articles_article_key ON articles (article_key);
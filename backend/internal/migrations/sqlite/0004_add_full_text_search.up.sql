@@ -0,0 +1,23 @@
+-- FTS5 index over the articles table, kept in sync by triggers, backing the search endpoint.
+CREATE VIRTUAL TABLE articles_fts USING fts5(
+    title, content, summary,
+    content = 'articles', content_rowid = 'id'
+);
+
+CREATE TRIGGER articles_fts_insert AFTER INSERT ON articles BEGIN
+    INSERT INTO articles_fts (rowid, title, content, summary)
+    VALUES (new.id, new.title, new.content, new.summary);
+END;
+CREATE TRIGGER articles_fts_delete AFTER DELETE ON articles BEGIN
+    INSERT INTO articles_fts (articles_fts, rowid, title, content, summary)
+    VALUES ('delete', old.id, old.title, old.content, old.summary);
+END;
+CREATE TRIGGER articles_fts_update AFTER UPDATE ON articles BEGIN
+    INSERT INTO articles_fts (articles_fts, rowid, title, content, summary)
+    VALUES ('delete', old.id, old.title, old.content, old.summary);
+    INSERT INTO articles_fts (rowid, title, content, summary)
+    VALUES (new.id, new.title, new.content, new.summary);
+END;
+
+-- Index the articles stored before this migration.
+INSERT INTO articles_fts (articles_fts) VALUES ('rebuild');
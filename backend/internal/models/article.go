@@ -2,8 +2,13 @@
 package models
 
 import (
+	"bytes"
 	"database/sql"
 	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -48,6 +53,11 @@ type NullableString struct {
 	sql.NullString `swaggerignore:"true"`
 }
 
+// NewNullableString returns a new NullableString with the given value.
+func NewNullableString(value string) NullableString {
+	return NullableString{sql.NullString{String: value, Valid: true}}
+}
+
 // MarshalJSON ensures string or null output.
 func (n NullableString) MarshalJSON() ([]byte, error) {
 	if n.Valid {
@@ -73,6 +83,7 @@ type Article struct {
 	HNID         int            `json:"hn_id"`
 	Title        string         `json:"title"`
 	Link         string         `json:"link"`
+	Domain       string         `json:"domain"`
 	ArticleRank  int            `json:"article_rank"`
 	Content      string         `json:"content"`
 	Summary      NullableString `json:"summary"`
@@ -127,10 +138,173 @@ func NewArticle(
 
 // ArticlesResponse represents the response for multiple articles.
 type ArticlesResponse struct {
-	Code       int        `json:"code"`        // HTTP status code
-	Status     string     `json:"status"`      // Response status message
-	TotalCount int        `json:"total_count"` // Total number of articles
-	Articles   []*Article `json:"articles"`    // List of articles
+	Code       int        `json:"code"`                  // HTTP status code
+	Status     string     `json:"status"`                // Response status message
+	TotalCount *int       `json:"total_count,omitempty"` // Number of matching articles across all pages; omitted with count=false
+	Limit      int        `json:"limit"`                 // Page size
+	Offset     int        `json:"offset"`                // Offset of this page
+	Next       string     `json:"next,omitempty"`        // URL of the next page, if any
+	Prev       string     `json:"prev,omitempty"`        // URL of the previous page, if any
+	Articles   []*Article `json:"articles"`              // List of articles
+	HasMore    bool       `json:"has_more"`              // Whether more articles follow this page
+	NextCursor string     `json:"next_cursor,omitempty"` // Cursor for the next page, if any
+	// Fields limits the encoding of each article to the named fields; nil encodes them whole.
+	Fields []string `json:"-"`
+}
+
+// MarshalJSON encodes the response, limiting its articles to Fields if set.
+func (r ArticlesResponse) MarshalJSON() ([]byte, error) {
+	type response ArticlesResponse
+	if r.Fields == nil {
+		return json.Marshal(response(r))
+	}
+	articles := make([]SparseArticle, len(r.Articles))
+	for i, article := range r.Articles {
+		articles[i] = SparseArticle{Article: article, Fields: r.Fields}
+	}
+	return json.Marshal(struct {
+		response
+		Articles []SparseArticle `json:"articles"`
+	}{response(r), articles})
+}
+
+// ArticleFields returns the JSON names of the Article fields in declaration order.
+func ArticleFields() []string {
+	t := reflect.TypeOf(Article{})
+	fields := make([]string, t.NumField())
+	for i := range fields {
+		fields[i], _, _ = strings.Cut(t.Field(i).Tag.Get("json"), ",")
+	}
+	return fields
+}
+
+// SparseArticle encodes only the named fields of an article, in declaration order.
+type SparseArticle struct {
+	Article *Article
+	Fields  []string
+}
+
+// MarshalJSON encodes the selected fields of the article as a JSON object.
+func (a SparseArticle) MarshalJSON() ([]byte, error) {
+	v := reflect.ValueOf(a.Article).Elem()
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, name := range ArticleFields() {
+		if !slices.Contains(a.Fields, name) {
+			continue
+		}
+		value, err := json.Marshal(v.Field(i).Interface())
+		if err != nil {
+			return nil, err
+		}
+		if buf.Len() > 1 {
+			buf.WriteByte(',')
+		}
+		fmt.Fprintf(&buf, "%q:", name)
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// ArticleResponse represents the response for a single article.
+type ArticleResponse struct {
+	Code    int      `json:"code"`    // HTTP status code
+	Status  string   `json:"status"`  // Response status message
+	Article *Article `json:"article"` // The requested article
+}
+
+// SearchResult represents an article matching a full-text search.
+type SearchResult struct {
+	Article   *Article `json:"article"`   // The matching article
+	Relevance float64  `json:"relevance"` // How well the article matches; higher is better
+	Snippet   string   `json:"snippet"`   // HTML excerpt of the summary with matches wrapped in <mark>
+}
+
+// SearchResponse represents the response for a full-text search.
+type SearchResponse struct {
+	Code       int             `json:"code"`        // HTTP status code
+	Status     string          `json:"status"`      // Response status message
+	Query      string          `json:"query"`       // The search text
+	TotalCount int             `json:"total_count"` // Number of results on this page
+	Results    []*SearchResult `json:"results"`     // Matching articles, best first
+}
+
+// ArticleSnapshot records an article's position and engagement at one point in time.
+type ArticleSnapshot struct {
+	ArticleRank  int         `json:"article_rank"`  // Front page rank when captured
+	Upvotes      NullableInt `json:"upvotes"`       // Upvotes when captured
+	CommentCount NullableInt `json:"comment_count"` // Comment count when captured
+	CapturedAt   time.Time   `json:"captured_at"`   // When the article was seen
+}
+
+// ArticleHistoryResponse represents the response for an article's snapshot history.
+type ArticleHistoryResponse struct {
+	Code      int                `json:"code"`       // HTTP status code
+	Status    string             `json:"status"`     // Response status message
+	ArticleID int                `json:"article_id"` // ID of the article
+	Snapshots []*ArticleSnapshot `json:"snapshots"`  // Snapshots in chronological order
+}
+
+// DomainStats summarizes the articles linking to one site.
+type DomainStats struct {
+	Domain         string  `json:"domain"`          // Registrable domain, e.g. github.com
+	ArticleCount   int     `json:"article_count"`   // Number of matching articles
+	AverageUpvotes float64 `json:"average_upvotes"` // Mean upvotes of those articles, counting unknown as zero
+}
+
+// DomainsResponse represents the response for the per-domain article summary.
+type DomainsResponse struct {
+	Code    int            `json:"code"`    // HTTP status code
+	Status  string         `json:"status"`  // Response status message
+	Domains []*DomainStats `json:"domains"` // Domains with the most articles first
+}
+
+// FacetCount counts the matching articles with one value of a facet.
+type FacetCount struct {
+	Value string `json:"value"` // Facet value, empty when unrecorded
+	Count int    `json:"count"` // Number of matching articles with the value
+}
+
+// FlagCounts counts the matching articles with a flag set and unset.
+type FlagCounts struct {
+	True  int `json:"true"`  // Number of matching articles with the flag set
+	False int `json:"false"` // Number of matching articles without the flag
+}
+
+// Facets breaks the articles matching a filter set down by summarizer and flag state. The counts
+// of each facet ignore the filter on that facet, so they show how the other values compare.
+type Facets struct {
+	ModelName  []*FacetCount `json:"model_name"`  // Counts per summarizing model, most first
+	CommitHash []*FacetCount `json:"commit_hash"` // Counts per summarizing build, most first
+	Source     []*FacetCount `json:"source"`      // Counts per source, most first
+	Flagged    FlagCounts    `json:"flagged"`     // Counts by flagged status
+	Dead       FlagCounts    `json:"dead"`        // Counts by dead status
+	Dupe       FlagCounts    `json:"dupe"`        // Counts by duplicate status
+}
+
+// FacetsResponse represents the response for the facet counts of a filter set.
+type FacetsResponse struct {
+	Code       int     `json:"code"`        // HTTP status code
+	Status     string  `json:"status"`      // Response status message
+	TotalCount int     `json:"total_count"` // Number of articles matching every filter
+	Facets     *Facets `json:"facets"`      // Counts per facet value
+}
+
+// ProblemDetails is an RFC 7807 problem response, served as application/problem+json.
+type ProblemDetails struct {
+	Type          string         `json:"type"`                     // URI identifying the problem type
+	Title         string         `json:"title"`                    // Short summary of the problem type
+	Status        int            `json:"status"`                   // HTTP status code
+	Detail        string         `json:"detail,omitempty"`         // Explanation of this occurrence
+	Instance      string         `json:"instance,omitempty"`       // Request URI of this occurrence
+	InvalidParams []InvalidParam `json:"invalid_params,omitempty"` // Every rejected request parameter
+}
+
+// InvalidParam names a rejected request parameter and why it was rejected.
+type InvalidParam struct {
+	Name   string `json:"name"`   // Parameter name
+	Reason string `json:"reason"` // Why the value was rejected
 }
 
 // ErrorResponse represents the error response format.
@@ -0,0 +1,99 @@
+package models
+
+import "encoding/xml"
+
+// RSSFeed is an RSS 2.0 document.
+type RSSFeed struct {
+	XMLName   xml.Name   `xml:"rss" swaggerignore:"true"`
+	Version   string     `xml:"version,attr"`
+	AtomXMLNS string     `xml:"xmlns:atom,attr"`
+	Channel   RSSChannel `xml:"channel"`
+}
+
+// RSSChannel describes an RSS feed and holds its items.
+type RSSChannel struct {
+	Title         string    `xml:"title"`                   // Feed title
+	Link          string    `xml:"link"`                    // Site the feed belongs to
+	Description   string    `xml:"description"`             // Feed description
+	SelfLink      AtomLink  `xml:"atom:link"`               // URL of the feed itself
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"` // RFC 1123 time of the latest article update
+	Items         []RSSItem `xml:"item"`                    // Articles, newest first
+}
+
+// RSSItem is an article in an RSS feed.
+type RSSItem struct {
+	Title       string  `xml:"title"`              // Article title
+	Link        string  `xml:"link"`               // Linked article
+	Description string  `xml:"description"`        // HTML summary and metadata
+	Comments    string  `xml:"comments,omitempty"` // Hacker News discussion
+	GUID        RSSGUID `xml:"guid"`               // Stable identifier
+	PubDate     string  `xml:"pubDate"`            // RFC 1123 creation time
+}
+
+// RSSGUID identifies an RSS item, optionally by a permanent URL.
+type RSSGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+// AtomFeed is an Atom 1.0 document.
+type AtomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed" swaggerignore:"true"`
+	Title   string      `xml:"title"`   // Feed title
+	ID      string      `xml:"id"`      // Permanent feed IRI
+	Updated string      `xml:"updated"` // RFC 3339 time of the latest article update
+	Links   []AtomLink  `xml:"link"`    // The feed itself and the site it belongs to
+	Author  AtomPerson  `xml:"author"`  // Feed author, inherited by every entry
+	Entries []AtomEntry `xml:"entry"`   // Articles, newest first
+}
+
+// AtomEntry is an article in an Atom feed.
+type AtomEntry struct {
+	Title     string     `xml:"title"`     // Article title
+	ID        string     `xml:"id"`        // Stable identifier
+	Updated   string     `xml:"updated"`   // RFC 3339 time of the last update
+	Published string     `xml:"published"` // RFC 3339 creation time
+	Links     []AtomLink `xml:"link"`      // Linked article and Hacker News discussion
+	Summary   AtomText   `xml:"summary"`   // HTML summary and metadata
+}
+
+// AtomLink is an Atom link element, also used for the self link of RSS channels.
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr,omitempty"`
+	Type string `xml:"type,attr,omitempty"`
+}
+
+// AtomPerson names the author of an Atom feed.
+type AtomPerson struct {
+	Name string `xml:"name"`
+}
+
+// AtomText is Atom text content of the given type, such as html.
+type AtomText struct {
+	Type  string `xml:"type,attr,omitempty"`
+	Value string `xml:",chardata"`
+}
+
+// JSONFeed is a JSON Feed 1.1 document.
+type JSONFeed struct {
+	Version     string          `json:"version"`               // JSON Feed version URL
+	Title       string          `json:"title"`                 // Feed title
+	HomePageURL string          `json:"home_page_url"`         // Site the feed belongs to
+	FeedURL     string          `json:"feed_url"`              // URL of the feed itself
+	Description string          `json:"description,omitempty"` // Feed description
+	Items       []*JSONFeedItem `json:"items"`                 // Articles, newest first
+}
+
+// JSONFeedItem is an article in a JSON Feed.
+type JSONFeedItem struct {
+	ID            string   `json:"id"`                     // Stable identifier
+	URL           string   `json:"url,omitempty"`          // Hacker News discussion
+	ExternalURL   string   `json:"external_url,omitempty"` // Linked article
+	Title         string   `json:"title"`                  // Article title
+	ContentHTML   string   `json:"content_html"`           // HTML summary and metadata
+	Summary       string   `json:"summary,omitempty"`      // Plain text summary
+	DatePublished string   `json:"date_published"`         // RFC 3339 creation time
+	DateModified  string   `json:"date_modified"`          // RFC 3339 time of the last update
+	Tags          []string `json:"tags,omitempty"`         // Linked site
+}
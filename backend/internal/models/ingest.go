@@ -0,0 +1,24 @@
+package models
+
+// Outcomes of an ingested article.
+const (
+	IngestSaved    = "saved"
+	IngestRejected = "rejected"
+)
+
+// IngestResult reports the outcome of one article of an ingested batch.
+type IngestResult struct {
+	Index  int    `json:"index"`           // Position of the article in the request body
+	HNID   int    `json:"hn_id"`           // Hacker News ID of the article, or 0 if unknown
+	Status string `json:"status"`          // saved or rejected
+	Error  string `json:"error,omitempty"` // Why the article was rejected
+}
+
+// IngestResponse represents the response for an ingested batch of articles.
+type IngestResponse struct {
+	Code     int             `json:"code"`     // HTTP status code
+	Status   string          `json:"status"`   // success if every article was saved, partial if some were, error if none were
+	Saved    int             `json:"saved"`    // Number of articles saved
+	Rejected int             `json:"rejected"` // Number of articles rejected
+	Results  []*IngestResult `json:"results"`  // Outcome of every article in request order
+}
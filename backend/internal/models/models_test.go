@@ -3,6 +3,7 @@ package models
 import (
 	"database/sql"
 	"encoding/json"
+	"reflect"
 	"testing"
 	"time"
 
@@ -125,3 +126,27 @@ func TestArticleMarshaling(t *testing.T) {
 	assert.Equal(t, article.Dead, result.Dead)
 	assert.Equal(t, article.Dupe, result.Dupe)
 }
+
+// TestArticlesResponseSparseMarshaling verifies that Fields limits each article to the named fields.
+func TestArticlesResponseSparseMarshaling(t *testing.T) {
+	article := &Article{ID: 7, Title: "Sparse", Content: "Long content", Summary: NewNullableString("Short"), Upvotes: NewNullableInt(3)}
+	response := ArticlesResponse{Code: 200, Status: "success", Articles: []*Article{article}, Fields: []string{"upvotes", "id", "title", "summary"}}
+
+	data, err := json.Marshal(response)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"articles":[{"id":7,"title":"Sparse","summary":"Short","upvotes":3}]`)
+	assert.Contains(t, string(data), `"status":"success"`)
+	assert.NotContains(t, string(data), "Fields")
+
+	response.Fields = nil
+	data, err = json.Marshal(response)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"content":"Long content"`)
+}
+
+// TestArticleFields verifies that the field names follow the JSON tags in declaration order.
+func TestArticleFields(t *testing.T) {
+	fields := ArticleFields()
+	assert.Equal(t, []string{"id", "hn_id", "title", "link"}, fields[:4])
+	assert.Len(t, fields, reflect.TypeOf(Article{}).NumField())
+}
@@ -0,0 +1,86 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Webhook is a URL that receives a signed POST for every saved article matching its filter.
+type Webhook struct {
+	ID        int       `json:"id"`
+	URL       string    `json:"url"`              // Endpoint receiving the deliveries
+	Filter    string    `json:"filter"`           // Filter expression articles must match; empty matches every article
+	Secret    string    `json:"secret,omitempty"` // Key signing the deliveries; only returned when the webhook is created
+	Active    bool      `json:"active"`           // Whether deliveries are enqueued and sent
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// WebhookRequest is the body of requests creating or updating a webhook.
+type WebhookRequest struct {
+	URL    string `json:"url" example:"https://example.com/hooks/gophersignal"` // Endpoint receiving the deliveries
+	Filter string `json:"filter" example:"min_upvotes>=200 AND NOT flagged"`    // Filter expression; empty matches every article
+	Secret string `json:"secret,omitempty"`                                     // Signing key; generated on creation and kept on update if empty
+	Active *bool  `json:"active,omitempty"`                                     // Whether the webhook receives deliveries; defaults to true on creation
+}
+
+// Delivery statuses of a WebhookDelivery.
+const (
+	DeliveryPending   = "pending"   // Waiting for its next attempt
+	DeliveryDelivered = "delivered" // Acknowledged with a 2xx response
+	DeliveryFailed    = "failed"    // Gave up after the maximum number of attempts
+)
+
+// WebhookDelivery is one article sent, or still to be sent, to a webhook, along with the
+// outcome of its latest attempt.
+type WebhookDelivery struct {
+	ID             int             `json:"id"`
+	WebhookID      int             `json:"webhook_id"`
+	ArticleID      int             `json:"article_id"`
+	Event          string          `json:"event"`                        // "article.created" or "article.updated"
+	Payload        json.RawMessage `json:"payload" swaggertype:"object"` // Signed JSON body, a WebhookPayload
+	Status         string          `json:"status"`                       // pending, delivered or failed
+	Attempts       int             `json:"attempts"`                     // Attempts made so far
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`              // When a pending delivery is attempted next
+	LastError      string          `json:"last_error,omitempty"`         // Why the latest attempt failed
+	ResponseStatus int             `json:"response_status,omitempty"`    // HTTP status of the latest response
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`       // When the receiver acknowledged the delivery
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+// WebhookPayload is the JSON body POSTed to webhooks.
+type WebhookPayload struct {
+	Event      string    `json:"event"`       // "article.created" or "article.updated"
+	WebhookID  int       `json:"webhook_id"`  // Webhook the payload is addressed to
+	OccurredAt time.Time `json:"occurred_at"` // When the article was saved
+	Article    *Article  `json:"article"`     // The article as stored, without its content
+}
+
+// WebhookResponse represents the response for a single webhook.
+type WebhookResponse struct {
+	Code    int      `json:"code"`    // HTTP status code
+	Status  string   `json:"status"`  // Response status message
+	Webhook *Webhook `json:"webhook"` // The webhook
+}
+
+// WebhooksResponse represents the response for the list of webhooks.
+type WebhooksResponse struct {
+	Code     int        `json:"code"`     // HTTP status code
+	Status   string     `json:"status"`   // Response status message
+	Webhooks []*Webhook `json:"webhooks"` // Registered webhooks, oldest first
+}
+
+// WebhookDeliveryResponse represents the response for a single delivery.
+type WebhookDeliveryResponse struct {
+	Code     int              `json:"code"`     // HTTP status code
+	Status   string           `json:"status"`   // Response status message
+	Delivery *WebhookDelivery `json:"delivery"` // The delivery
+}
+
+// WebhookDeliveriesResponse represents the response for the delivery log of a webhook.
+type WebhookDeliveriesResponse struct {
+	Code       int                `json:"code"`       // HTTP status code
+	Status     string             `json:"status"`     // Response status message
+	Deliveries []*WebhookDelivery `json:"deliveries"` // Deliveries, newest first
+}
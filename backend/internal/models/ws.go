@@ -0,0 +1,39 @@
+package models
+
+import "encoding/json"
+
+// WSRequest is a message sent by a client of the WebSocket API.
+type WSRequest struct {
+	Type    string                     `json:"type"`              // subscribe, unsubscribe, ping or snapshot
+	ID      string                     `json:"id,omitempty"`      // Echoed in the reply to match it to the request
+	Filters map[string]json.RawMessage `json:"filters,omitempty"` // subscribe: filters named and valued as the query parameters of GET /articles
+	Limit   int                        `json:"limit,omitempty"`   // snapshot: number of newest matching articles, 30 by default
+}
+
+// WSReply acknowledges a subscribe, unsubscribe or ping request.
+type WSReply struct {
+	Type string `json:"type"`         // subscribed, unsubscribed or pong
+	ID   string `json:"id,omitempty"` // ID of the request
+}
+
+// WSSnapshot answers a snapshot request with the newest articles matching the subscription.
+type WSSnapshot struct {
+	Type     string     `json:"type"`         // Always snapshot
+	ID       string     `json:"id,omitempty"` // ID of the request
+	Articles []*Article `json:"articles"`     // Matching articles, newest first
+}
+
+// WSArticleEvent announces a saved article matching the subscription.
+type WSArticleEvent struct {
+	Type    string   `json:"type"`    // Always article
+	Event   string   `json:"event"`   // created for a new article, updated for a new sighting of a stored one
+	Article *Article `json:"article"` // The article as stored
+}
+
+// WSError reports a request that could not be carried out.
+type WSError struct {
+	Type          string         `json:"type"`                     // Always error
+	ID            string         `json:"id,omitempty"`             // ID of the request, if any
+	Message       string         `json:"message"`                  // What went wrong
+	InvalidParams []InvalidParam `json:"invalid_params,omitempty"` // Every rejected filter
+}
@@ -0,0 +1,127 @@
+// Package pubsub fans saved articles out to in-process subscribers, such as streaming clients.
+package pubsub
+
+import (
+	"sync"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// EventKind tells whether an event announces a new article or a new sighting of a stored one.
+type EventKind string
+
+const (
+	// Created announces an article saved for the first time.
+	Created EventKind = "created"
+	// Updated announces an article saved again, with refreshed rank, engagement or flags.
+	Updated EventKind = "updated"
+)
+
+// Event announces a saved article. Subscribers share the article, which must not be modified.
+type Event struct {
+	Kind    EventKind
+	Article *models.Article
+}
+
+// Hub delivers every published event to every current subscriber. Publishing never blocks:
+// a subscriber whose buffer is full is dropped and marked as lagged, so that one slow client
+// cannot hold up the others, and can catch up from the store instead.
+type Hub struct {
+	mu     sync.Mutex
+	subs   map[*Subscription]struct{}
+	closed bool
+}
+
+// NewHub creates a Hub without subscribers.
+func NewHub() *Hub {
+	return &Hub{subs: make(map[*Subscription]struct{})}
+}
+
+// Subscription receives the events published to a Hub after it subscribed.
+type Subscription struct {
+	hub    *Hub
+	events chan Event
+	lagged bool // Set before events is closed for falling behind.
+}
+
+// Subscribe registers a subscriber buffering up to buffer undelivered events.
+// Subscribing to a closed Hub returns an already closed Subscription.
+func (h *Hub) Subscribe(buffer int) *Subscription {
+	sub := &Subscription{hub: h, events: make(chan Event, buffer)}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.closed {
+		close(sub.events)
+		return sub
+	}
+	h.subs[sub] = struct{}{}
+	return sub
+}
+
+// HasSubscribers reports whether anyone would receive a published event, letting publishers
+// skip the work of preparing events nobody reads.
+func (h *Hub) HasSubscribers() bool {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return len(h.subs) > 0
+}
+
+// Publish delivers events to every subscriber, in order.
+func (h *Hub) Publish(events ...Event) {
+	if len(events) == 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for sub := range h.subs {
+		for _, event := range events {
+			select {
+			case sub.events <- event:
+				continue
+			default:
+			}
+			sub.lagged = true
+			h.remove(sub)
+			break
+		}
+	}
+}
+
+// Close ends every subscription and refuses new ones, letting streams finish on shutdown.
+func (h *Hub) Close() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.closed = true
+	for sub := range h.subs {
+		h.remove(sub)
+	}
+}
+
+// remove unregisters sub and closes its channel. The caller must hold h.mu.
+func (h *Hub) remove(sub *Subscription) {
+	if _, ok := h.subs[sub]; ok {
+		delete(h.subs, sub)
+		close(sub.events)
+	}
+}
+
+// Events returns the channel of published events, which is closed when the subscription
+// ends: because it was closed, it lagged behind, or the Hub was closed.
+func (s *Subscription) Events() <-chan Event {
+	return s.events
+}
+
+// Lagged reports whether the subscription was ended for falling behind. It is only meaningful
+// once the Events channel is closed.
+func (s *Subscription) Lagged() bool {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	return s.lagged
+}
+
+// Close ends the subscription. It is safe to call more than once.
+func (s *Subscription) Close() {
+	s.hub.mu.Lock()
+	defer s.hub.mu.Unlock()
+	s.hub.remove(s)
+}
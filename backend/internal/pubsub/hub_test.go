@@ -0,0 +1,75 @@
+package pubsub
+
+import (
+	"testing"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// created returns an event announcing a new article with the given ID.
+func created(id int) Event {
+	return Event{Kind: Created, Article: &models.Article{ID: id}}
+}
+
+// TestHub_Publish verifies that every subscriber receives the published events in order.
+func TestHub_Publish(t *testing.T) {
+	hub := NewHub()
+	if hub.HasSubscribers() {
+		t.Error("Expected a new hub to have no subscribers")
+	}
+	first, second := hub.Subscribe(4), hub.Subscribe(4)
+	hub.Publish(created(1), created(2))
+
+	for _, sub := range []*Subscription{first, second} {
+		for _, expected := range []int{1, 2} {
+			if event := <-sub.Events(); event.Article.ID != expected {
+				t.Errorf("Expected article %d, got %d", expected, event.Article.ID)
+			}
+		}
+	}
+
+	first.Close()
+	first.Close()
+	hub.Publish(created(3))
+	if _, ok := <-first.Events(); ok {
+		t.Error("Expected no events after unsubscribing")
+	}
+	if event := <-second.Events(); event.Article.ID != 3 {
+		t.Errorf("Expected article 3, got %d", event.Article.ID)
+	}
+	second.Close()
+	if hub.HasSubscribers() {
+		t.Error("Expected no subscribers after both unsubscribed")
+	}
+}
+
+// TestHub_Lagged verifies that a subscriber with a full buffer is dropped without blocking others.
+func TestHub_Lagged(t *testing.T) {
+	hub := NewHub()
+	slow, fast := hub.Subscribe(1), hub.Subscribe(3)
+	hub.Publish(created(1), created(2), created(3))
+
+	if event := <-slow.Events(); event.Article.ID != 1 {
+		t.Errorf("Expected the buffered article 1, got %d", event.Article.ID)
+	}
+	if _, ok := <-slow.Events(); ok || !slow.Lagged() {
+		t.Error("Expected the slow subscription to end as lagged")
+	}
+	if len(fast.Events()) != 3 || fast.Lagged() {
+		t.Errorf("Expected the fast subscription to receive every event, got %d", len(fast.Events()))
+	}
+}
+
+// TestHub_Close verifies that closing the hub ends current subscriptions and refuses new ones.
+func TestHub_Close(t *testing.T) {
+	hub := NewHub()
+	sub := hub.Subscribe(1)
+	hub.Close()
+	if _, ok := <-sub.Events(); ok || sub.Lagged() {
+		t.Error("Expected the subscription to end without lagging")
+	}
+	if _, ok := <-hub.Subscribe(1).Events(); ok {
+		t.Error("Expected subscriptions to a closed hub to be closed")
+	}
+	hub.Publish(created(1))
+}
@@ -0,0 +1,45 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// PublishingStore wraps a Store and publishes the articles it saves to a Hub.
+type PublishingStore struct {
+	store.Store
+	hub *Hub
+}
+
+// NewPublishingStore returns s publishing every article saved through it to hub.
+func NewPublishingStore(s store.Store, hub *Hub) *PublishingStore {
+	return &PublishingStore{Store: s, hub: hub}
+}
+
+// SaveArticles saves articles with the wrapped store, then publishes each article as the save
+// transaction stored it, with its ID and first sighting, so that subscribers see the same article a
+// query would return. Articles the save created are published as Created, the rest as Updated.
+// Articles rejected by a partial save are not published.
+func (s *PublishingStore) SaveArticles(ctx context.Context, articles []*models.Article, mode store.SaveMode) ([]store.SavedArticle, error) {
+	saved, err := s.Store.SaveArticles(ctx, articles, mode)
+	var batchErr *store.BatchSaveError
+	if err != nil && !errors.As(err, &batchErr) {
+		return saved, err
+	}
+
+	events := make([]Event, 0, len(saved))
+	for _, result := range saved {
+		kind := Updated
+		if result.Created {
+			kind = Created
+		}
+		// Stores may hand out articles they keep, which subscribers must not share with them.
+		published := *result.Article
+		events = append(events, Event{Kind: kind, Article: &published})
+	}
+	s.hub.Publish(events...)
+	return saved, err
+}
@@ -0,0 +1,84 @@
+package pubsub
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// TestPublishingStore verifies that saved articles, with or without an HN ID, are published as
+// stored and rejected ones are not.
+func TestPublishingStore(t *testing.T) {
+	created := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	mock := store.NewMockStore(nil, nil, nil)
+	mock.ArticleErrors = map[int]error{300: errors.New("rejected")}
+	hub := NewHub()
+	sub := hub.Subscribe(10)
+	s := NewPublishingStore(mock, hub)
+
+	_, err := s.SaveArticles(context.Background(), []*models.Article{
+		{HNID: 100, Title: "First", Link: "https://example.com/1", CreatedAt: created},
+		{HNID: 300, Title: "Rejected"},
+		{Title: "Without HN ID", Link: "https://example.com/2"},
+		{HNID: 200, Title: "Second"},
+	}, store.SavePartial)
+	var batchErr *store.BatchSaveError
+	if !errors.As(err, &batchErr) || len(batchErr.Failures) != 1 {
+		t.Fatalf("Expected the rejection to be reported, got %v", err)
+	}
+
+	// Saving again keeps the first sighting, which is what gets published.
+	if _, err := s.SaveArticles(context.Background(), []*models.Article{
+		{HNID: 100, Title: "First", Link: "https://example.com/1", CreatedAt: created.Add(time.Hour)},
+	}, store.SaveAtomic); err != nil {
+		t.Fatalf("SaveArticles failed: %v", err)
+	}
+
+	var published []Event
+	for len(sub.Events()) > 0 {
+		published = append(published, <-sub.Events())
+	}
+	if len(published) != 4 {
+		t.Fatalf("Expected 4 published events, got %d", len(published))
+	}
+	first, linkOnly, second, again := published[0], published[1], published[2], published[3]
+	if first.Kind != Created || first.Article.HNID != 100 || first.Article.ID == 0 || second.Kind != Created || second.Article.HNID != 200 {
+		t.Errorf("Unexpected events %+v and %+v", first, second)
+	}
+	if linkOnly.Kind != Created || linkOnly.Article.Title != "Without HN ID" || linkOnly.Article.ID == 0 {
+		t.Errorf("Expected the article without HN ID to be published, got %+v", linkOnly)
+	}
+	if again.Kind != Updated || again.Article.ID != first.Article.ID || !again.Article.CreatedAt.Equal(created) {
+		t.Errorf("Expected the update of article %d as first seen at %v, got %+v", first.Article.ID, created, again)
+	}
+}
+
+// TestPublishingStore_NoSubscribers verifies that saving without subscribers publishes nothing.
+func TestPublishingStore_NoSubscribers(t *testing.T) {
+	mock := store.NewMockStore(nil, nil, nil)
+	hub := NewHub()
+	s := NewPublishingStore(mock, hub)
+	if _, err := s.SaveArticles(context.Background(), []*models.Article{{HNID: 1, Title: "Article"}}, store.SaveAtomic); err != nil {
+		t.Fatalf("SaveArticles failed: %v", err)
+	}
+	if len(mock.Articles) != 1 {
+		t.Errorf("Expected the article to be saved, got %d articles", len(mock.Articles))
+	}
+}
+
+// TestPublishingStore_Error verifies that nothing is published when the save fails.
+func TestPublishingStore_Error(t *testing.T) {
+	hub := NewHub()
+	sub := hub.Subscribe(1)
+	s := NewPublishingStore(store.NewMockStore(nil, errors.New("database error"), nil), hub)
+	if _, err := s.SaveArticles(context.Background(), []*models.Article{{HNID: 1, Title: "Article"}}, store.SaveAtomic); err == nil {
+		t.Fatal("Expected the save error")
+	}
+	if len(sub.Events()) != 0 {
+		t.Error("Expected no published events")
+	}
+}
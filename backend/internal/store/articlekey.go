@@ -0,0 +1,173 @@
+package store
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// maxArticleKeyLength is the width of the article_key column in every dialect.
+const maxArticleKeyLength = 512
+
+// articleKey returns the identity under which article is upserted: its Hacker News ID when
+// known, otherwise its normalized link. Normalized links too long for the article_key column
+// are replaced by their SHA-256 digest. Articles with neither have no key and are always inserted.
+func articleKey(article *models.Article) sql.NullString {
+	if article.HNID > 0 {
+		return sql.NullString{String: "hn:" + strconv.Itoa(article.HNID), Valid: true}
+	}
+	link := normalizeLink(article.Link)
+	if link == "" {
+		return sql.NullString{}
+	}
+	if key := "link:" + link; len(key) <= maxArticleKeyLength {
+		return sql.NullString{String: key, Valid: true}
+	}
+	digest := sha256.Sum256([]byte(link))
+	return sql.NullString{String: "link-sha256:" + hex.EncodeToString(digest[:]), Valid: true}
+}
+
+// normalizeLink reduces link to a canonical form so that trivially different URLs of the same page
+// compare equal: the scheme, "www." prefix, default ports, fragments, trailing slashes and utm_*
+// tracking parameters are dropped, the host is lower-cased and the query is sorted.
+func normalizeLink(link string) string {
+	link = strings.TrimSpace(link)
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" {
+		return strings.ToLower(link)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	if port := u.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	query := u.Query()
+	for param := range query {
+		if strings.HasPrefix(strings.ToLower(param), "utm_") {
+			query.Del(param)
+		}
+	}
+
+	normalized := host + strings.TrimRight(u.EscapedPath(), "/")
+	if encoded := query.Encode(); encoded != "" {
+		normalized += "?" + encoded
+	}
+	return normalized
+}
+
+// Schema versions that add the article_key column and the article_snapshots table.
+const (
+	articleKeyMigration = 2
+	snapshotMigration   = 3
+)
+
+// BackfillArticleKeys keys every article stored without an article key, returning the number of
+// articles keyed. Migration 0002 keys articles by their Hacker News ID, but normalizing links
+// takes Go, so articles known only by their link are keyed after migrating. An article whose key
+// is already taken is merged into the newer of the two rows, which keeps the earlier creation
+// time and the snapshots of both. Schemas older than the article_key column are left alone.
+func (store *sqlStore) BackfillArticleKeys(ctx context.Context) (int, error) {
+	migrator, err := store.Migrator()
+	if err != nil {
+		return 0, err
+	}
+	version, err := migrator.Version(ctx)
+	if err != nil || version < articleKeyMigration {
+		return 0, err
+	}
+
+	keyed, lastID := 0, 0
+	for {
+		rows, err := store.db.QueryContext(ctx, store.dialect.rebind(
+			"SELECT id, hn_id, link, created_at FROM articles WHERE article_key IS NULL AND id > ? ORDER BY id LIMIT ?"), lastID, backfillBatchSize)
+		if err != nil {
+			return keyed, fmt.Errorf("failed to read articles without keys: %w", err)
+		}
+		var unkeyed []*models.Article
+		for rows.Next() {
+			var article models.Article
+			if err := rows.Scan(&article.ID, &article.HNID, &article.Link, &article.CreatedAt); err != nil {
+				rows.Close()
+				return keyed, fmt.Errorf("failed to scan article: %w", err)
+			}
+			lastID = article.ID
+			unkeyed = append(unkeyed, &article)
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return keyed, fmt.Errorf("iteration error: %w", err)
+		}
+
+		n, err := store.keyArticles(ctx, unkeyed, version >= snapshotMigration)
+		keyed += n
+		if err != nil {
+			return keyed, err
+		}
+		if len(unkeyed) < backfillBatchSize {
+			return keyed, nil
+		}
+	}
+}
+
+// keyArticles records the keys of articles in one transaction, merging each into the article
+// already holding its key, if any. Snapshots are moved to the surviving row when hasSnapshots is set.
+func (store *sqlStore) keyArticles(ctx context.Context, articles []*models.Article, hasSnapshots bool) (int, error) {
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	exec := func(query string, args ...interface{}) error {
+		_, err := tx.ExecContext(ctx, store.dialect.rebind(query), args...)
+		return err
+	}
+	keyed := 0
+	for _, article := range articles {
+		key := articleKey(article)
+		if !key.Valid {
+			continue
+		}
+		var holderID int
+		var holderCreatedAt time.Time
+		err := tx.QueryRowContext(ctx, store.dialect.rebind("SELECT id, created_at FROM articles WHERE article_key = ?"), key.String).
+			Scan(&holderID, &holderCreatedAt)
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			err = exec("UPDATE articles SET article_key = ? WHERE id = ?", key.String, article.ID)
+		case err == nil:
+			keep, drop := max(article.ID, holderID), min(article.ID, holderID)
+			createdAt := article.CreatedAt
+			if holderCreatedAt.Before(createdAt) {
+				createdAt = holderCreatedAt
+			}
+			if hasSnapshots {
+				err = exec("UPDATE article_snapshots SET article_id = ? WHERE article_id = ?", keep, drop)
+			}
+			if err == nil {
+				err = exec("DELETE FROM articles WHERE id = ?", drop)
+			}
+			if err == nil {
+				err = exec("UPDATE articles SET article_key = ?, created_at = ? WHERE id = ?", key.String, createdAt, keep)
+			}
+		}
+		if err != nil {
+			return 0, fmt.Errorf("failed to key article %d: %w", article.ID, err)
+		}
+		keyed++
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit article keys: %w", err)
+	}
+	return keyed, nil
+}
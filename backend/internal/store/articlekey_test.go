@@ -0,0 +1,44 @@
+package store
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// TestNormalizeLink verifies that equivalent URLs normalize to the same value.
+func TestNormalizeLink(t *testing.T) {
+	cases := map[string]string{
+		"https://www.Example.com/post/":                  "example.com/post",
+		"http://example.com/post#comments":               "example.com/post",
+		"https://example.com:443/post":                   "example.com/post",
+		"https://example.com:8443/post":                  "example.com:8443/post",
+		"https://example.com/post?utm_source=hn&b=2&a=1": "example.com/post?a=1&b=2",
+		"  https://example.com/Case/Sensitive/Path  ":    "example.com/Case/Sensitive/Path",
+		"item?id=123": "item?id=123",
+		"":            "",
+	}
+	for link, expected := range cases {
+		if got := normalizeLink(link); got != expected {
+			t.Errorf("normalizeLink(%q) = %q; want %q", link, got, expected)
+		}
+	}
+}
+
+// TestArticleKey verifies that the Hacker News ID takes precedence over the link.
+func TestArticleKey(t *testing.T) {
+	if key := articleKey(&models.Article{HNID: 42, Link: "https://example.com"}); key.String != "hn:42" {
+		t.Errorf("Expected hn:42, got %q", key.String)
+	}
+	if key := articleKey(&models.Article{Link: "https://www.example.com/a/"}); key.String != "link:example.com/a" {
+		t.Errorf("Expected link:example.com/a, got %q", key.String)
+	}
+	long := articleKey(&models.Article{Link: "https://example.com/" + strings.Repeat("a", maxLinkLength)})
+	if !strings.HasPrefix(long.String, "link-sha256:") || len(long.String) > maxArticleKeyLength {
+		t.Errorf("Expected a digest key for a long link, got %q", long.String)
+	}
+	if key := articleKey(&models.Article{}); key.Valid {
+		t.Errorf("Expected no key, got %q", key.String)
+	}
+}
@@ -0,0 +1,147 @@
+package store
+
+import (
+	"cmp"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// ErrInvalidCursor is wrapped by the errors of cursors that are malformed or belong to another sort order.
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// ArticlePage is one page of the results of an ArticleQuery.
+type ArticlePage struct {
+	Articles   []*models.Article // Articles on this page, in query order.
+	HasMore    bool              // Whether more articles follow this page.
+	NextCursor string            // Cursor continuing after this page; empty unless HasMore.
+	Limit      int               // Effective page size.
+	Offset     int               // Effective offset.
+}
+
+// cursor is the decoded form of the opaque tokens in ArticleQuery.Cursor and ArticlePage.NextCursor.
+// It records the sort key of the last article of a page so that the next page resumes after it,
+// regardless of articles inserted in the meantime.
+type cursor struct {
+	Sort     SortOrder     `json:"s"`
+	Order    SortDirection `json:"o"`
+	Value    interface{}   `json:"v,omitempty"` // Sort value of the article, unless sorted by ID alone.
+	ID       int           `json:"id"`
+	HotEpoch int64         `json:"t,omitempty"` // Unix time the hot scores of the walk are computed as of.
+}
+
+// sortValue returns the value article is ordered by under q's sort order before its ID,
+// or nil if it is ordered by ID alone.
+func sortValue(q ArticleQuery, article *models.Article) interface{} {
+	switch q.Sort {
+	case SortUpvotes:
+		return article.Upvotes.Int64
+	case SortComments:
+		return article.CommentCount.Int64
+	case SortRank:
+		return int64(article.ArticleRank)
+	case SortUpdated:
+		return article.UpdatedAt
+	case SortHot:
+		return hotScore(article, q.hotEpoch)
+	}
+	return nil
+}
+
+// encodeCursor returns the token resuming the walk of q after article, whose sort value is value.
+func encodeCursor(q ArticleQuery, article *models.Article, value interface{}) string {
+	c := cursor{Sort: q.Sort, Order: q.Order, Value: value, ID: article.ID}
+	if q.Sort == SortHot {
+		c.HotEpoch = q.hotEpoch
+	}
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// AfterIDCursor returns the cursor resuming a SortOldest walk after the article with the given ID,
+// such as the last article a client has seen, so that only articles stored since then follow.
+func AfterIDCursor(id int) string {
+	return encodeCursor(ArticleQuery{Sort: SortOldest, Order: Ascending}, &models.Article{ID: id}, nil)
+}
+
+// decodeCursor parses token and checks that it was issued for a query sorted by sort in order.
+// The sort value is restored to the type sortValue returns.
+func decodeCursor(token string, sort SortOrder, order SortDirection) (*cursor, error) {
+	invalid := fmt.Errorf("%w: %q is not a cursor", ErrInvalidCursor, token)
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, invalid
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID <= 0 {
+		return nil, invalid
+	}
+	if c.Sort != sort || c.Order != order {
+		return nil, fmt.Errorf("%w: cursor was issued for sort order %q %s, not %q %s", ErrInvalidCursor, c.Sort, c.Order, sort, order)
+	}
+
+	switch sort {
+	case SortUpvotes, SortComments, SortRank:
+		n, ok := c.Value.(float64)
+		if !ok || n != math.Trunc(n) {
+			return nil, invalid
+		}
+		c.Value = int64(n)
+	case SortUpdated:
+		s, ok := c.Value.(string)
+		if !ok {
+			return nil, invalid
+		}
+		if c.Value, err = time.Parse(time.RFC3339Nano, s); err != nil {
+			return nil, invalid
+		}
+	case SortHot:
+		if _, ok := c.Value.(float64); !ok || c.HotEpoch <= 0 {
+			return nil, invalid
+		}
+	default:
+		if c.Value != nil {
+			return nil, invalid
+		}
+	}
+	return &c, nil
+}
+
+// newArticlePage builds the page for a normalized query from up to q.Limit+1 matching articles,
+// the extra article only signalling that more follow. Stores that compute sort values themselves
+// pass them in values, index for index; otherwise they are derived with sortValue.
+func newArticlePage(q ArticleQuery, articles []*models.Article, values []interface{}) *ArticlePage {
+	page := &ArticlePage{Articles: articles, Limit: q.Limit, Offset: q.Offset}
+	if len(articles) > q.Limit {
+		last := q.Limit - 1
+		page.Articles = articles[:q.Limit]
+		page.HasMore = true
+		value := sortValue(q, articles[last])
+		if values != nil {
+			value = values[last]
+		}
+		page.NextCursor = encodeCursor(q, articles[last], value)
+	}
+	if page.Articles == nil {
+		page.Articles = []*models.Article{}
+	}
+	return page
+}
+
+// compareSortValues compares two sort values of the same sort order, returning -1, 0 or +1.
+func compareSortValues(a, b interface{}) int {
+	switch a := a.(type) {
+	case int64:
+		return cmp.Compare(a, b.(int64))
+	case float64:
+		return cmp.Compare(a, b.(float64))
+	case time.Time:
+		return a.Compare(b.(time.Time))
+	}
+	return 0
+}
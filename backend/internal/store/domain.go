@@ -0,0 +1,156 @@
+package store
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"golang.org/x/net/publicsuffix"
+)
+
+// linkDomain returns the registrable domain of link, its public suffix plus one label, such as
+// "github.com" for "https://gist.github.com/x" or "bbc.co.uk" for "http://www.bbc.co.uk/news".
+// Bare hosts with at least one dot are accepted as well. IP addresses and hosts without a
+// registrable part, such as "localhost", are returned whole without a "www." prefix; relative
+// links and links without a host yield "".
+func linkDomain(link string) string {
+	link = strings.TrimSpace(link)
+	bare := !strings.Contains(link, "://")
+	if bare {
+		link = "http://" + link
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return ""
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" || net.ParseIP(host) != nil {
+		return host
+	}
+	if bare && !strings.Contains(host, ".") {
+		return ""
+	}
+	host = strings.TrimPrefix(host, "www.")
+	if domain, err := publicsuffix.EffectiveTLDPlusOne(host); err == nil {
+		return domain
+	}
+	return host
+}
+
+// normalizeDomains reduces each of domains, which may also be hosts or links, to its registrable
+// domain as stored, dropping empty and repeated entries.
+func normalizeDomains(domains []string) []string {
+	var normalized []string
+	for _, domain := range domains {
+		if domain = linkDomain(domain); domain != "" && !slices.Contains(normalized, domain) {
+			normalized = append(normalized, domain)
+		}
+	}
+	return normalized
+}
+
+// buildDomainsQuery renders the per-domain summary of the articles matched by q's filters,
+// thresholds and time bounds, paginated by q's limit and offset.
+func buildDomainsQuery(d sqlDialect, q ArticleQuery) (string, []interface{}) {
+	conditions, args := filterConditions(d, q)
+	conditions = append(conditions, "domain != ''")
+	args = append(args, q.Limit, q.Offset)
+	return `
+		SELECT domain, COUNT(*), AVG(COALESCE(upvotes, 0))
+		FROM articles a
+		WHERE ` + strings.Join(conditions, "\n\t\t  AND ") + `
+		GROUP BY domain
+		ORDER BY COUNT(*) DESC, domain ASC
+		LIMIT ? OFFSET ?;
+	`, args
+}
+
+// Domains summarizes the articles matching q's filters per linked domain, most articles first.
+// Sort orders and cursors do not apply.
+func (store *sqlStore) Domains(ctx context.Context, q ArticleQuery) ([]*models.DomainStats, error) {
+	q.Cursor = ""
+	q, err := q.normalize()
+	if err != nil {
+		return nil, err
+	}
+	query, args := buildDomainsQuery(store.dialect, q)
+	rows, err := store.db.QueryContext(ctx, store.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	domains := []*models.DomainStats{}
+	for rows.Next() {
+		var stats models.DomainStats
+		if err := rows.Scan(&stats.Domain, &stats.ArticleCount, &stats.AverageUpvotes); err != nil {
+			return nil, fmt.Errorf("failed to scan domain: %w", err)
+		}
+		domains = append(domains, &stats)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+	return domains, nil
+}
+
+// domainMigration is the schema version that adds the domain column.
+const domainMigration = 5
+
+// backfillBatchSize is the number of articles BackfillDomains reads at a time.
+const backfillBatchSize = 500
+
+// BackfillDomains records the domain of every article stored before domains were, returning
+// the number of articles updated. Migration 0005 adds the column empty, and computing domains
+// needs the public suffix list, so this runs after migrating. Schemas older than the domain
+// column are left alone.
+func (store *sqlStore) BackfillDomains(ctx context.Context) (int, error) {
+	migrator, err := store.Migrator()
+	if err != nil {
+		return 0, err
+	}
+	version, err := migrator.Version(ctx)
+	if err != nil || version < domainMigration {
+		return 0, err
+	}
+
+	updated, lastID := 0, 0
+	for {
+		rows, err := store.db.QueryContext(ctx, store.dialect.rebind(
+			"SELECT id, link FROM articles WHERE domain = '' AND id > ? ORDER BY id LIMIT ?"), lastID, backfillBatchSize)
+		if err != nil {
+			return updated, fmt.Errorf("failed to read articles without domains: %w", err)
+		}
+		domains := make(map[int]string)
+		n := 0
+		for rows.Next() {
+			var link string
+			if err := rows.Scan(&lastID, &link); err != nil {
+				rows.Close()
+				return updated, fmt.Errorf("failed to scan article link: %w", err)
+			}
+			n++
+			if domain := linkDomain(link); domain != "" {
+				domains[lastID] = domain
+			}
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return updated, fmt.Errorf("iteration error: %w", err)
+		}
+
+		for id, domain := range domains {
+			if _, err := store.db.ExecContext(ctx, store.dialect.rebind("UPDATE articles SET domain = ? WHERE id = ?"), domain, id); err != nil {
+				return updated, fmt.Errorf("failed to record domain of article %d: %w", id, err)
+			}
+			updated++
+		}
+		if n < backfillBatchSize {
+			return updated, nil
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"fmt"
+	"testing"
+)
+
+// TestLinkDomain verifies that links reduce to their registrable domain.
+func TestLinkDomain(t *testing.T) {
+	cases := map[string]string{
+		"https://github.com/golang/go":        "github.com",
+		"https://gist.github.com/someone/1":   "github.com",
+		"https://www.Example.com/post":        "example.com",
+		"http://www.bbc.co.uk/news":           "bbc.co.uk",
+		"https://news.bbc.co.uk/story":        "bbc.co.uk",
+		"https://example.com.:8443/post":      "example.com",
+		"https://someone.github.io/blog":      "someone.github.io",
+		"Blog.Example.COM":                    "example.com",
+		"http://127.0.0.1:8080/debug":         "127.0.0.1",
+		"http://[::1]/status":                 "::1",
+		"http://localhost:3000":               "localhost",
+		"item?id=123":                         "",
+		"":                                    "",
+		"https://":                            "",
+		"  https://blog.rust-lang.org/2026/ ": "rust-lang.org",
+	}
+	for link, expected := range cases {
+		if got := linkDomain(link); got != expected {
+			t.Errorf("linkDomain(%q) = %q; want %q", link, got, expected)
+		}
+	}
+}
+
+// TestNormalizeDomains verifies that domain filters are reduced to stored domains without repeats.
+func TestNormalizeDomains(t *testing.T) {
+	got := normalizeDomains([]string{"www.github.com", "gist.github.com", "", "https://lwn.net/Articles/1"})
+	if fmt.Sprint(got) != "[github.com lwn.net]" {
+		t.Errorf("Expected [github.com lwn.net], got %v", got)
+	}
+}
@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// buildFacetQuery renders the counts per value of column among the articles matched by q,
+// lifting q's filter on column itself.
+func buildFacetQuery(d sqlDialect, q ArticleQuery, column string) (string, []interface{}) {
+	q.facet = column
+	conditions, args := filterConditions(d, q)
+	return `
+		SELECT ` + column + `, COUNT(*)
+		FROM articles a
+		WHERE ` + strings.Join(conditions, "\n\t\t  AND ") + `
+		GROUP BY ` + column + `
+		ORDER BY COUNT(*) DESC, ` + column + ` ASC;
+	`, args
+}
+
+// Facets counts the articles matching q's filters per summarizing model, build, source and flag
+// state. Each facet is counted with its own filter lifted; pagination, sorting and cursors do not apply.
+func (store *sqlStore) Facets(ctx context.Context, q ArticleQuery) (*models.Facets, error) {
+	q.Cursor = ""
+	q, err := q.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	facets := &models.Facets{}
+	for _, facet := range []struct {
+		column string
+		counts *[]*models.FacetCount
+	}{
+		{"model_name", &facets.ModelName},
+		{"commit_hash", &facets.CommitHash},
+		{"source", &facets.Source},
+	} {
+		*facet.counts = []*models.FacetCount{}
+		err := store.scanFacet(ctx, q, facet.column, func(rows *sql.Rows) error {
+			var count models.FacetCount
+			if err := rows.Scan(&count.Value, &count.Count); err != nil {
+				return err
+			}
+			*facet.counts = append(*facet.counts, &count)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for _, flag := range []struct {
+		column string
+		counts *models.FlagCounts
+	}{
+		{"flagged", &facets.Flagged},
+		{"dead", &facets.Dead},
+		{"dupe", &facets.Dupe},
+	} {
+		err := store.scanFacet(ctx, q, flag.column, func(rows *sql.Rows) error {
+			var set bool
+			var count int
+			if err := rows.Scan(&set, &count); err != nil {
+				return err
+			}
+			if set {
+				flag.counts.True = count
+			} else {
+				flag.counts.False = count
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return facets, nil
+}
+
+// scanFacet runs the facet query of column and passes each row to scanRow.
+func (store *sqlStore) scanFacet(ctx context.Context, q ArticleQuery, column string, scanRow func(*sql.Rows) error) error {
+	query, args := buildFacetQuery(store.dialect, q, column)
+	rows, err := store.db.QueryContext(ctx, store.dialect.rebind(query), args...)
+	if err != nil {
+		return fmt.Errorf("failed to count %s facet: %w", column, err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		if err := scanRow(rows); err != nil {
+			return fmt.Errorf("failed to scan %s facet: %w", column, err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iteration error: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,14 @@
 package store
 
 import (
+	"cmp"
+	"context"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
 	"github.com/k-zehnder/gophersignal/backend/internal/models"
 )
 
@@ -11,6 +19,14 @@ type MockStore struct {
 	Articles    []*models.Article
 	SaveError   error
 	GetAllError error
+	// ArticleErrors injects per-article save failures, keyed by HN ID.
+	ArticleErrors map[int]error
+	// Snapshots holds the history of each article, keyed by article ID.
+	Snapshots map[int][]*models.ArticleSnapshot
+
+	// mu guards Articles and Snapshots against concurrent saves. Tests may access them directly
+	// while no call is in flight.
+	mu sync.RWMutex
 }
 
 // NewMockStore initializes a MockStore with predefined articles and potential errors.
@@ -23,134 +39,505 @@ func NewMockStore(articles []*models.Article, saveError error, getAllError error
 }
 
 // SaveArticles simulates storing articles, returning a predefined error if set.
-func (ms *MockStore) SaveArticles(articles []*models.Article) error {
+// Articles that fail validation or have an entry in ArticleErrors are rejected according to mode,
+// exactly as the SQL stores reject them. Like the SQL stores it upserts: an article whose key
+// matches a stored one replaces it but keeps the stored ID and CreatedAt, as well as the content,
+// summary and model metadata the new copy lacks, and new articles are assigned increasing IDs. The domain of each saved article is derived from its link. Copies of
+// the articles are stored, leaving the caller's articles untouched.
+func (ms *MockStore) SaveArticles(ctx context.Context, articles []*models.Article, mode SaveMode) ([]SavedArticle, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if ms.SaveError != nil {
-		return ms.SaveError
+		return nil, ms.SaveError
+	}
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	batchErr := &BatchSaveError{Total: len(articles)}
+	accepted := make([]pendingArticle, 0, len(articles))
+	for i, article := range articles {
+		err := validateArticle(article)
+		if err == nil {
+			err = ms.ArticleErrors[article.HNID]
+		}
+		if err == nil {
+			accepted = append(accepted, pendingArticle{index: i, article: article})
+			continue
+		}
+		if mode == SaveAtomic {
+			return nil, newArticleError(i, article, err)
+		}
+		batchErr.add(i, article, err)
+	}
+
+	maxID := 0
+	stored := make(map[string]int)
+	for i, article := range ms.Articles {
+		if article.ID > maxID {
+			maxID = article.ID
+		}
+		if key := articleKey(article); key.Valid {
+			stored[key.String] = i
+		}
+	}
+	saved := make([]SavedArticle, 0, len(accepted))
+	for _, p := range accepted {
+		article := new(models.Article)
+		*article = *p.article
+		article.Domain = linkDomain(article.Link)
+		key := articleKey(article)
+		i, exists := stored[key.String]
+		if exists = exists && key.Valid; exists {
+			fillArticle(article, ms.Articles[i])
+			ms.Articles[i] = article
+		} else {
+			if article.ID == 0 {
+				maxID++
+				article.ID = maxID
+			}
+			if key.Valid {
+				stored[key.String] = len(ms.Articles)
+			}
+			ms.Articles = append(ms.Articles, article)
+		}
+		ms.recordSnapshot(article)
+		result := *article
+		saved = append(saved, SavedArticle{Index: p.index, Article: &result, Created: !exists})
 	}
-	ms.Articles = articles
-	return nil
+	return saved, batchErr.errOrNil()
 }
 
-// GetArticles simulates fetching articles, returning a predefined error if set.
-func (ms *MockStore) GetArticles(limit, offset int) ([]*models.Article, error) {
+// fillArticle carries over to a new sighting of an article what the SQL stores keep of the stored
+// one: its ID, its first sighting, and the content, summary and model metadata the sighting lacks.
+func fillArticle(article, stored *models.Article) {
+	article.ID = stored.ID
+	article.CreatedAt = stored.CreatedAt
+	if article.Content == "" {
+		article.Content = stored.Content
+	}
+	if !article.Summary.Valid || article.Summary.String == "" {
+		article.Summary = stored.Summary
+	}
+	if article.CommitHash == "" {
+		article.CommitHash = stored.CommitHash
+	}
+	if article.ModelName == "" {
+		article.ModelName = stored.ModelName
+	}
+}
+
+// recordSnapshot appends the current rank and engagement of article to its history.
+func (ms *MockStore) recordSnapshot(article *models.Article) {
+	if ms.Snapshots == nil {
+		ms.Snapshots = make(map[int][]*models.ArticleSnapshot)
+	}
+	ms.Snapshots[article.ID] = append(ms.Snapshots[article.ID], &models.ArticleSnapshot{
+		ArticleRank:  article.ArticleRank,
+		Upvotes:      article.Upvotes,
+		CommentCount: article.CommentCount,
+		CapturedAt:   article.UpdatedAt,
+	})
+}
+
+// History returns the recorded snapshots of the article with the given ID,
+// or ErrArticleNotFound if the store holds no such article.
+func (ms *MockStore) History(ctx context.Context, articleID int) ([]*models.ArticleSnapshot, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if ms.GetAllError != nil {
 		return nil, ms.GetAllError
 	}
-	if offset >= len(ms.Articles) {
-		return []*models.Article{}, nil
-	}
-	end := offset + limit
-	if end > len(ms.Articles) {
-		end = len(ms.Articles)
+	for _, article := range ms.Articles {
+		if article.ID == articleID {
+			return append([]*models.ArticleSnapshot{}, ms.Snapshots[articleID]...), nil
+		}
 	}
-	return ms.Articles[offset:end], nil
+	return nil, ErrArticleNotFound
 }
 
-// GetFilteredArticles simulates fetching articles based on optional filter criteria.
-func (ms *MockStore) GetFilteredArticles(flagged, dead, dupe *bool, limit, offset int) ([]*models.Article, error) {
+// Query evaluates q against the in-memory articles, mirroring the semantics of the SQL stores:
+// filters, thresholds and the cursor are applied first, and the result is sorted, paginated and
+// trimmed to the fields of q.
+func (ms *MockStore) Query(ctx context.Context, q ArticleQuery) (*ArticlePage, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if ms.GetAllError != nil {
 		return nil, ms.GetAllError
 	}
+	q, err := q.normalize()
+	if err != nil {
+		return nil, err
+	}
 
 	var filtered []*models.Article
 	for _, article := range ms.Articles {
-		if flagged != nil && article.Flagged != *flagged {
-			continue
-		}
-		if dead != nil && article.Dead != *dead {
-			continue
-		}
-		if dupe != nil && article.Dupe != *dupe {
-			continue
+		if matchesQuery(q, article) && afterCursor(q, article) {
+			filtered = append(filtered, article)
 		}
-		filtered = append(filtered, article)
 	}
-	if offset >= len(filtered) {
-		return []*models.Article{}, nil
+
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return compareInOrder(q, filtered[i], sortValue(q, filtered[j]), filtered[j].ID) < 0
+	})
+
+	if q.Offset >= len(filtered) {
+		return newArticlePage(q, nil, nil), nil
 	}
-	end := offset + limit
+	end := q.Offset + q.Limit + 1
 	if end > len(filtered) {
 		end = len(filtered)
 	}
-	return filtered[offset:end], nil
+	page := newArticlePage(q, filtered[q.Offset:end], nil)
+	if len(q.Fields) > 0 {
+		for i, article := range page.Articles {
+			page.Articles[i] = loadedCopy(q, article)
+		}
+	}
+	return page, nil
+}
+
+// loadedCopy returns a copy of article carrying only the columns q loads, as the SQL stores return it.
+func loadedCopy(q ArticleQuery, article *models.Article) *models.Article {
+	loaded := *article
+	v := reflect.ValueOf(&loaded).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		name, _, _ := strings.Cut(v.Type().Field(i).Tag.Get("json"), ",")
+		for _, column := range articleColumns {
+			if column.name == name && !q.loads(column) {
+				v.Field(i).SetZero()
+			}
+		}
+	}
+	return &loaded
 }
 
-// GetArticlesWithThresholds implements threshold-based filtering.
-// It converts models.NullableInt values to int64 for comparison.
-// If minUpvotes or minComments is 0, that metric is not filtered.
-func (ms *MockStore) GetArticlesWithThresholds(limit, offset, minUpvotes, minComments int) ([]*models.Article, error) {
+// Count returns the number of in-memory articles matching the filters and thresholds of q.
+func (ms *MockStore) Count(ctx context.Context, q ArticleQuery) (int, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+	if ms.GetAllError != nil {
+		return 0, ms.GetAllError
+	}
+	q, err := q.normalize()
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, article := range ms.Articles {
+		if matchesQuery(q, article) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Search approximates the full-text search of the SQL stores: every term must match the title,
+// content or summary as a word or word prefix, and relevance counts the matches, weighting
+// titles above summaries above content.
+func (ms *MockStore) Search(ctx context.Context, q SearchQuery) ([]*models.SearchResult, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if ms.GetAllError != nil {
 		return nil, ms.GetAllError
 	}
+	q, terms, err := q.normalize()
+	if err != nil {
+		return nil, err
+	}
 
-	var filtered []*models.Article
+	results := []*models.SearchResult{}
 	for _, article := range ms.Articles {
-		// Convert Upvotes and CommentCount from NullableInt to int64.
-		upvotes := int64(0)
-		if article.Upvotes.Valid {
-			upvotes = article.Upvotes.Int64
+		if !matchesQuery(q.ArticleQuery, article) {
+			continue
 		}
-		comments := int64(0)
-		if article.CommentCount.Valid {
-			comments = article.CommentCount.Int64
+		relevance, matched := 0.0, true
+		for _, term := range terms {
+			pattern := termPattern([]searchTerm{term})
+			hits := 10*len(pattern.FindAllStringIndex(article.Title, -1)) +
+				5*len(pattern.FindAllStringIndex(article.Summary.String, -1)) +
+				len(pattern.FindAllStringIndex(article.Content, -1))
+			if hits == 0 {
+				matched = false
+				break
+			}
+			relevance += float64(hits)
 		}
-
-		if upvotes >= int64(minUpvotes) && comments >= int64(minComments) {
-			filtered = append(filtered, article)
+		if matched {
+			results = append(results, newSearchResult(article, relevance, terms))
 		}
 	}
 
-	if offset >= len(filtered) {
-		return []*models.Article{}, nil
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		switch {
+		case q.Sort == SortOldest:
+			return a.Article.ID < b.Article.ID
+		case q.Sort == SortRelevance && a.Relevance != b.Relevance:
+			return a.Relevance > b.Relevance
+		}
+		return a.Article.ID > b.Article.ID
+	})
+
+	if q.Offset >= len(results) {
+		return []*models.SearchResult{}, nil
 	}
-	end := offset + limit
-	if end > len(filtered) {
-		end = len(filtered)
+	end := q.Offset + q.Limit
+	if end > len(results) {
+		end = len(results)
 	}
-	return filtered[offset:end], nil
+	return results[q.Offset:end], nil
 }
 
-// GetArticlesWithThresholdsAndFilters implements combined filtering:
-// both threshold conditions (minUpvotes and minComments) and additional boolean filters.
-func (ms *MockStore) GetArticlesWithThresholdsAndFilters(limit, offset, minUpvotes, minComments int, flagged, dead, dupe *bool) ([]*models.Article, error) {
+// Domains summarizes the in-memory articles matching the filters of q per linked domain,
+// most articles first, as the SQL stores do.
+func (ms *MockStore) Domains(ctx context.Context, q ArticleQuery) ([]*models.DomainStats, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	if ms.GetAllError != nil {
 		return nil, ms.GetAllError
 	}
+	q.Cursor = ""
+	q, err := q.normalize()
+	if err != nil {
+		return nil, err
+	}
 
-	var filtered []*models.Article
+	byDomain := make(map[string]*models.DomainStats)
+	domains := []*models.DomainStats{}
 	for _, article := range ms.Articles {
-		// Apply boolean filters.
-		if flagged != nil && article.Flagged != *flagged {
+		domain := linkDomain(article.Link)
+		if domain == "" || !matchesQuery(q, article) {
 			continue
 		}
-		if dead != nil && article.Dead != *dead {
-			continue
+		stats, ok := byDomain[domain]
+		if !ok {
+			stats = &models.DomainStats{Domain: domain}
+			byDomain[domain] = stats
+			domains = append(domains, stats)
 		}
-		if dupe != nil && article.Dupe != *dupe {
-			continue
+		stats.ArticleCount++
+		stats.AverageUpvotes += float64(article.Upvotes.Int64)
+	}
+	for _, stats := range domains {
+		stats.AverageUpvotes /= float64(stats.ArticleCount)
+	}
+	sort.Slice(domains, func(i, j int) bool {
+		if domains[i].ArticleCount != domains[j].ArticleCount {
+			return domains[i].ArticleCount > domains[j].ArticleCount
 		}
+		return domains[i].Domain < domains[j].Domain
+	})
 
-		// Convert Upvotes and CommentCount from NullableInt to int64.
-		upvotes := int64(0)
-		if article.Upvotes.Valid {
-			upvotes = article.Upvotes.Int64
+	if q.Offset >= len(domains) {
+		return []*models.DomainStats{}, nil
+	}
+	end := q.Offset + q.Limit
+	if end > len(domains) {
+		end = len(domains)
+	}
+	return domains[q.Offset:end], nil
+}
+
+// Facets counts the in-memory articles matching the filters of q per summarizing model, build,
+// source and flag state, lifting the filter on each facet while counting it as the SQL stores do.
+func (ms *MockStore) Facets(ctx context.Context, q ArticleQuery) (*models.Facets, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if ms.GetAllError != nil {
+		return nil, ms.GetAllError
+	}
+	q.Cursor = ""
+	q, err := q.normalize()
+	if err != nil {
+		return nil, err
+	}
+
+	facets := &models.Facets{}
+	for _, facet := range []struct {
+		column string
+		counts *[]*models.FacetCount
+		value  func(*models.Article) string
+	}{
+		{"model_name", &facets.ModelName, func(a *models.Article) string { return a.ModelName }},
+		{"commit_hash", &facets.CommitHash, func(a *models.Article) string { return a.CommitHash }},
+		{"source", &facets.Source, func(a *models.Article) string { return a.Source }},
+	} {
+		q.facet = facet.column
+		byValue := make(map[string]*models.FacetCount)
+		*facet.counts = []*models.FacetCount{}
+		for _, article := range ms.Articles {
+			if !matchesQuery(q, article) {
+				continue
+			}
+			value := facet.value(article)
+			if byValue[value] == nil {
+				byValue[value] = &models.FacetCount{Value: value}
+				*facet.counts = append(*facet.counts, byValue[value])
+			}
+			byValue[value].Count++
 		}
-		comments := int64(0)
-		if article.CommentCount.Valid {
-			comments = article.CommentCount.Int64
+		sort.Slice(*facet.counts, func(i, j int) bool {
+			a, b := (*facet.counts)[i], (*facet.counts)[j]
+			if a.Count != b.Count {
+				return a.Count > b.Count
+			}
+			return a.Value < b.Value
+		})
+	}
+
+	for _, flag := range []struct {
+		column string
+		counts *models.FlagCounts
+		value  func(*models.Article) bool
+	}{
+		{"flagged", &facets.Flagged, func(a *models.Article) bool { return a.Flagged }},
+		{"dead", &facets.Dead, func(a *models.Article) bool { return a.Dead }},
+		{"dupe", &facets.Dupe, func(a *models.Article) bool { return a.Dupe }},
+	} {
+		q.facet = flag.column
+		for _, article := range ms.Articles {
+			switch {
+			case !matchesQuery(q, article):
+			case flag.value(article):
+				flag.counts.True++
+			default:
+				flag.counts.False++
+			}
 		}
+	}
+	return facets, nil
+}
 
-		if upvotes >= int64(minUpvotes) && comments >= int64(minComments) {
-			filtered = append(filtered, article)
+// Article returns the article with the given ID, or ErrArticleNotFound.
+func (ms *MockStore) Article(ctx context.Context, id int) (*models.Article, error) {
+	return ms.lookupArticle(ctx, func(article *models.Article) bool { return article.ID == id })
+}
+
+// ArticleByHNID returns the article with the given Hacker News ID, or ErrArticleNotFound.
+func (ms *MockStore) ArticleByHNID(ctx context.Context, hnID int) (*models.Article, error) {
+	return ms.lookupArticle(ctx, func(article *models.Article) bool { return article.HNID == hnID })
+}
+
+// lookupArticle returns the matching article with the highest ID, ignoring visibility filters.
+func (ms *MockStore) lookupArticle(ctx context.Context, match func(*models.Article) bool) (*models.Article, error) {
+	ms.mu.RLock()
+	defer ms.mu.RUnlock()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if ms.GetAllError != nil {
+		return nil, ms.GetAllError
+	}
+	var found *models.Article
+	for _, article := range ms.Articles {
+		if match(article) && (found == nil || article.ID > found.ID) {
+			found = article
 		}
 	}
+	if found == nil {
+		return nil, ErrArticleNotFound
+	}
+	return found, nil
+}
 
-	if offset >= len(filtered) {
-		return []*models.Article{}, nil
+// matchesQuery reports whether article satisfies the filters, thresholds and time bounds of q,
+// except the filter on the facet being counted, if any.
+// Domains are derived from links, so articles given to NewMockStore need not set them.
+// Unset flag filters exclude flagged, dead and duplicate articles, and NULL counts compare as zero.
+// Articles without a usable summary are excluded unless q filters by flag status alone.
+func matchesQuery(q ArticleQuery, article *models.Article) bool {
+	if q.requiresSummary() && (!article.Summary.Valid ||
+		strings.TrimSpace(article.Summary.String) == "" ||
+		strings.HasPrefix(article.Summary.String, "No summary available")) {
+		return false
 	}
-	end := offset + limit
-	if end > len(filtered) {
-		end = len(filtered)
+	for _, flag := range []struct {
+		column string
+		filter *bool
+		value  bool
+	}{
+		{"flagged", q.Flagged, article.Flagged},
+		{"dead", q.Dead, article.Dead},
+		{"dupe", q.Dupe, article.Dupe},
+	} {
+		if flag.column != q.facet && !matchesFlag(flag.filter, flag.value) {
+			return false
+		}
+	}
+	for _, filter := range []struct {
+		column string
+		values []string
+		value  string
+	}{
+		{"model_name", q.ModelNames, article.ModelName},
+		{"commit_hash", q.CommitHashes, article.CommitHash},
+	} {
+		if len(filter.values) > 0 && filter.column != q.facet && !slices.Contains(filter.values, filter.value) {
+			return false
+		}
+	}
+
+	upvotes := int64(0)
+	if article.Upvotes.Valid {
+		upvotes = article.Upvotes.Int64
+	}
+	comments := int64(0)
+	if article.CommentCount.Valid {
+		comments = article.CommentCount.Int64
+	}
+	if upvotes < int64(q.MinUpvotes) || comments < int64(q.MinComments) {
+		return false
+	}
+	domain := linkDomain(article.Link)
+	if (len(q.Domains) > 0 && !slices.Contains(q.Domains, domain)) || slices.Contains(q.ExcludeDomains, domain) {
+		return false
+	}
+	return (q.Since.IsZero() || !article.CreatedAt.Before(q.Since)) &&
+		(q.Until.IsZero() || article.CreatedAt.Before(q.Until)) &&
+		(q.UpdatedSince.IsZero() || !article.UpdatedAt.Before(q.UpdatedSince))
+}
+
+// afterCursor reports whether article follows the cursor of q in its sort order.
+func afterCursor(q ArticleQuery, article *models.Article) bool {
+	return q.after == nil || compareInOrder(q, article, q.after.Value, q.after.ID) > 0
+}
+
+// compareInOrder returns -1, 0 or +1 as article comes before, at or after the position of
+// the article with the given sort value and ID in the order of q.
+func compareInOrder(q ArticleQuery, article *models.Article, value interface{}, id int) int {
+	c := compareSortValues(sortValue(q, article), value)
+	if c == 0 {
+		c = cmp.Compare(article.ID, id)
+	}
+	if q.Order == Descending {
+		return -c
+	}
+	return c
+}
+
+// matchesFlag compares a boolean article attribute against an optional filter value.
+func matchesFlag(filter *bool, value bool) bool {
+	if filter == nil {
+		return !value
 	}
-	return filtered[offset:end], nil
+	return value == *filter
 }
@@ -3,24 +3,33 @@
 package store
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/k-zehnder/gophersignal/backend/internal/models"
 )
 
-// TestMockStore_GetArticles verifies basic article retrieval functionality with pagination.
-func TestMockStore_GetArticles(t *testing.T) {
+// summarized gives article a usable summary so that it is visible to queries.
+func summarized(article *models.Article) *models.Article {
+	article.Summary = models.NewNullableString("Summary of " + article.Title)
+	return article
+}
+
+// TestMockStore_Query verifies basic article retrieval functionality with pagination.
+func TestMockStore_Query(t *testing.T) {
 	// Setup test data and mock store.
 	expectedArticles := []*models.Article{
-		{Title: "Test Article 1"},
+		summarized(&models.Article{Title: "Test Article 1"}),
 	}
 	mockStore := NewMockStore(expectedArticles, nil, nil)
 
-	// Execute GetArticles with full result range.
-	articles, err := mockStore.GetArticles(len(expectedArticles), 0)
+	// Execute Query with full result range.
+	articles, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{Limit: len(expectedArticles)}))
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -46,7 +55,7 @@ func TestMockStore_SaveArticles(t *testing.T) {
 		{Title: "Test Article 2"},
 	}
 
-	err := mockStore.SaveArticles(articles)
+	_, err := mockStore.SaveArticles(context.Background(), articles, SaveAtomic)
 	if err != nil {
 		t.Fatalf("Expected no error, got %v", err)
 	}
@@ -62,59 +71,140 @@ func TestMockStore_SaveArticles(t *testing.T) {
 	}
 }
 
+// TestMockStore_SaveArticles_Copies verifies that saves store copies and can run concurrently.
+func TestMockStore_SaveArticles_Copies(t *testing.T) {
+	mockStore := NewMockStore(nil, nil, nil)
+	article := &models.Article{HNID: 1, Title: "Original", Link: "https://go.dev/blog"}
+	if _, err := mockStore.SaveArticles(context.Background(), []*models.Article{article}, SaveAtomic); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+	if article.ID != 0 || article.Domain != "" {
+		t.Errorf("Expected the caller's article to be untouched, got %+v", article)
+	}
+	article.Title = "Changed"
+	if stored, err := mockStore.ArticleByHNID(context.Background(), 1); err != nil || stored.Title != "Original" {
+		t.Errorf("Expected the stored copy to keep its title, got %+v (%v)", stored, err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 2; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mockStore.SaveArticles(context.Background(), []*models.Article{{HNID: i, Title: "Concurrent"}}, SaveAtomic)
+			mockStore.Count(context.Background(), ArticleQuery{})
+		}()
+	}
+	wg.Wait()
+	if len(mockStore.Articles) != 9 {
+		t.Errorf("Expected 9 articles, got %d", len(mockStore.Articles))
+	}
+}
+
 // TestMockStore_SaveArticles_Error verifies error propagation in save operations.
 func TestMockStore_SaveArticles_Error(t *testing.T) {
 	expectedErr := errors.New("save error")
 	mockStore := NewMockStore(nil, expectedErr, nil)
 
-	err := mockStore.SaveArticles([]*models.Article{{Title: "Test Article"}})
+	_, err := mockStore.SaveArticles(context.Background(), []*models.Article{{Title: "Test Article"}}, SaveAtomic)
 	if err != expectedErr {
 		t.Fatalf("Expected error: %v, got: %v", expectedErr, err)
 	}
 }
 
-// TestMockStore_GetArticles_Error checks error handling in retrieval operations.
-func TestMockStore_GetArticles_Error(t *testing.T) {
+// TestMockStore_SaveArticles_InjectedFailures verifies per-article failure injection in both save modes.
+func TestMockStore_SaveArticles_InjectedFailures(t *testing.T) {
+	injected := errors.New("injected failure")
+	articles := func() []*models.Article {
+		return []*models.Article{
+			{HNID: 1, Title: "Accepted"},
+			{HNID: 2, Title: "Rejected"},
+			{HNID: 3, Title: ""},
+			{HNID: 4, Title: "Also Accepted"},
+		}
+	}
+
+	t.Run("Atomic", func(t *testing.T) {
+		mockStore := NewMockStore(nil, nil, nil)
+		mockStore.ArticleErrors = map[int]error{2: injected}
+		_, err := mockStore.SaveArticles(context.Background(), articles(), SaveAtomic)
+		if !errors.Is(err, injected) {
+			t.Fatalf("Expected injected error, got %v", err)
+		}
+		if len(mockStore.Articles) != 0 {
+			t.Errorf("Expected nothing saved, got %d articles", len(mockStore.Articles))
+		}
+	})
+
+	t.Run("Partial", func(t *testing.T) {
+		mockStore := NewMockStore(nil, nil, nil)
+		mockStore.ArticleErrors = map[int]error{2: injected}
+		_, err := mockStore.SaveArticles(context.Background(), articles(), SavePartial)
+
+		var batchErr *BatchSaveError
+		if !errors.As(err, &batchErr) {
+			t.Fatalf("Expected *BatchSaveError, got %v", err)
+		}
+		if batchErr.Total != 4 || len(batchErr.Failures) != 2 {
+			t.Fatalf("Expected 2 of 4 failures, got %+v", batchErr)
+		}
+		if f := batchErr.Failures[0]; f.Index != 1 || f.HNID != 2 || !errors.Is(f, injected) {
+			t.Errorf("Unexpected first failure: %+v", f)
+		}
+		if f := batchErr.Failures[1]; f.Index != 2 || f.HNID != 3 || !errors.Is(f, ErrInvalidArticle) {
+			t.Errorf("Unexpected second failure: %+v", f)
+		}
+		if len(mockStore.Articles) != 2 || mockStore.Articles[0].HNID != 1 || mockStore.Articles[1].HNID != 4 {
+			t.Errorf("Expected articles 1 and 4 saved, got %v", mockStore.Articles)
+		}
+	})
+}
+
+// TestMockStore_Query_Error checks error handling in retrieval operations.
+func TestMockStore_Query_Error(t *testing.T) {
 	expectedErr := errors.New("get error")
 	mockStore := NewMockStore(nil, nil, expectedErr)
 
-	_, err := mockStore.GetArticles(10, 0)
+	_, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{}))
 	if err != expectedErr {
 		t.Fatalf("Expected error: %v, got: %v", expectedErr, err)
 	}
 }
 
-// TestMockStore_GetFilteredArticles_NoFilter verifies unfiltered retrieval.
-func TestMockStore_GetFilteredArticles_NoFilter(t *testing.T) {
+// TestMockStore_Query_DefaultFilters verifies that flagged, dead, duplicate and unsummarized
+// articles are hidden when no flag filters are given.
+func TestMockStore_Query_DefaultFilters(t *testing.T) {
 	articles := []*models.Article{
-		{Title: "Article 1", Flagged: false, Dead: false, Dupe: false},
-		{Title: "Article 2", Flagged: true, Dead: false, Dupe: false},
-		{Title: "Article 3", Flagged: false, Dead: true, Dupe: false},
-		{Title: "Article 4", Flagged: false, Dead: false, Dupe: true},
+		summarized(&models.Article{Title: "Article 1", Flagged: false, Dead: false, Dupe: false}),
+		summarized(&models.Article{Title: "Article 2", Flagged: true, Dead: false, Dupe: false}),
+		summarized(&models.Article{Title: "Article 3", Flagged: false, Dead: true, Dupe: false}),
+		summarized(&models.Article{Title: "Article 4", Flagged: false, Dead: false, Dupe: true}),
+		{Title: "Article 5"},
+		{Title: "Article 6", Summary: models.NewNullableString("No summary available.")},
 	}
 	mockStore := NewMockStore(articles, nil, nil)
 
-	filtered, err := mockStore.GetFilteredArticles(nil, nil, nil, len(articles), 0)
+	filtered, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{}))
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
-	if len(filtered) != len(articles) {
-		t.Fatalf("Expected %d articles, got %d", len(articles), len(filtered))
+	if len(filtered) != 1 || filtered[0].Title != "Article 1" {
+		t.Fatalf("Expected only Article 1, got %v", filtered)
 	}
 }
 
-// TestMockStore_GetFilteredArticles_Flagged validates single-flag filtering.
-func TestMockStore_GetFilteredArticles_Flagged(t *testing.T) {
+// TestMockStore_Query_Flagged validates single-flag filtering.
+func TestMockStore_Query_Flagged(t *testing.T) {
 	articles := []*models.Article{
-		{Title: "Article 1", Flagged: false, Dead: false, Dupe: false},
-		{Title: "Article 2", Flagged: true, Dead: false, Dupe: false},
-		{Title: "Article 3", Flagged: true, Dead: true, Dupe: false},
-		{Title: "Article 4", Flagged: false, Dead: false, Dupe: true},
+		summarized(&models.Article{Title: "Article 1", Flagged: false, Dead: false, Dupe: false}),
+		summarized(&models.Article{Title: "Article 2", Flagged: true, Dead: false, Dupe: false}),
+		summarized(&models.Article{Title: "Article 3", Flagged: true, Dead: true, Dupe: false}),
+		summarized(&models.Article{Title: "Article 4", Flagged: true, Dead: false, Dupe: false}),
 	}
 	mockStore := NewMockStore(articles, nil, nil)
 
 	flagged := true
-	filtered, err := mockStore.GetFilteredArticles(&flagged, nil, nil, len(articles), 0)
+	filtered, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{Flagged: &flagged}))
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -122,25 +212,25 @@ func TestMockStore_GetFilteredArticles_Flagged(t *testing.T) {
 		t.Fatalf("Expected 2 articles, got %d", len(filtered))
 	}
 	for _, a := range filtered {
-		if !a.Flagged {
-			t.Errorf("Expected flagged article, got %v", a.Title)
+		if !a.Flagged || a.Dead {
+			t.Errorf("Expected flagged, live article, got %v", a.Title)
 		}
 	}
 }
 
-// TestMockStore_GetFilteredArticles_DeadAndDupe validates combined flag filtering.
-func TestMockStore_GetFilteredArticles_DeadAndDupe(t *testing.T) {
+// TestMockStore_Query_DeadAndDupe validates combined flag filtering.
+func TestMockStore_Query_DeadAndDupe(t *testing.T) {
 	articles := []*models.Article{
-		{Title: "Article 1", Flagged: false, Dead: false, Dupe: false},
-		{Title: "Article 2", Flagged: true, Dead: true, Dupe: false},
-		{Title: "Article 3", Flagged: true, Dead: true, Dupe: true},
-		{Title: "Article 4", Flagged: false, Dead: false, Dupe: true},
+		summarized(&models.Article{Title: "Article 1", Flagged: false, Dead: false, Dupe: false}),
+		summarized(&models.Article{Title: "Article 2", Flagged: false, Dead: true, Dupe: false}),
+		summarized(&models.Article{Title: "Article 3", Flagged: false, Dead: true, Dupe: true}),
+		summarized(&models.Article{Title: "Article 4", Flagged: true, Dead: true, Dupe: true}),
 	}
 	mockStore := NewMockStore(articles, nil, nil)
 
 	dead := true
 	dupe := true
-	filtered, err := mockStore.GetFilteredArticles(nil, &dead, &dupe, len(articles), 0)
+	filtered, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{Dead: &dead, Dupe: &dupe}))
 	if err != nil {
 		t.Fatalf("Unexpected error: %v", err)
 	}
@@ -152,28 +242,18 @@ func TestMockStore_GetFilteredArticles_DeadAndDupe(t *testing.T) {
 	}
 }
 
-// TestMockStore_GetFilteredArticles_Error verifies error handling in filtered retrieval.
-func TestMockStore_GetFilteredArticles_Error(t *testing.T) {
-	expectedErr := errors.New("get error")
-	mockStore := NewMockStore(nil, nil, expectedErr)
-
-	_, err := mockStore.GetFilteredArticles(nil, nil, nil, 10, 0)
-	if err != expectedErr {
-		t.Fatalf("Expected error: %v, got: %v", expectedErr, err)
-	}
-}
-
-// TestMockStore_GetArticlesWithThresholds validates threshold-based filtering.
-func TestMockStore_GetArticlesWithThresholds(t *testing.T) {
+// TestMockStore_Query_Thresholds validates threshold-based filtering.
+func TestMockStore_Query_Thresholds(t *testing.T) {
 	articles := []*models.Article{
-		{Title: "Article 1", Upvotes: models.NewNullableInt(50), CommentCount: models.NewNullableInt(10)},
-		{Title: "Article 2", Upvotes: models.NewNullableInt(30), CommentCount: models.NewNullableInt(5)},
-		{Title: "Article 3", Upvotes: models.NewNullableInt(40), CommentCount: models.NewNullableInt(15)},
+		summarized(&models.Article{Title: "Article 1", Upvotes: models.NewNullableInt(50), CommentCount: models.NewNullableInt(10)}),
+		summarized(&models.Article{Title: "Article 2", Upvotes: models.NewNullableInt(30), CommentCount: models.NewNullableInt(5)}),
+		summarized(&models.Article{Title: "Article 3", Upvotes: models.NewNullableInt(40), CommentCount: models.NewNullableInt(15)}),
+		summarized(&models.Article{Title: "Article 4"}),
 	}
 	mockStore := NewMockStore(articles, nil, nil)
 
 	t.Run("MeetBothThresholds", func(t *testing.T) {
-		result, err := mockStore.GetArticlesWithThresholds(10, 0, 40, 10)
+		result, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{MinUpvotes: 40, MinComments: 10}))
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -183,7 +263,7 @@ func TestMockStore_GetArticlesWithThresholds(t *testing.T) {
 	})
 
 	t.Run("ZeroThresholds", func(t *testing.T) {
-		result, err := mockStore.GetArticlesWithThresholds(10, 0, 0, 0)
+		result, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{}))
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -191,15 +271,27 @@ func TestMockStore_GetArticlesWithThresholds(t *testing.T) {
 			t.Fatalf("Expected %d articles, got %d", len(articles), len(result))
 		}
 	})
+
+	t.Run("NullCountsFailThresholds", func(t *testing.T) {
+		result, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{MinUpvotes: 1}))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		for _, article := range result {
+			if article.Title == "Article 4" {
+				t.Errorf("Expected article without upvotes to be excluded")
+			}
+		}
+	})
 }
 
-// TestMockStore_GetArticlesWithThresholdsAndFilters validates combined filtering.
-func TestMockStore_GetArticlesWithThresholdsAndFilters(t *testing.T) {
+// TestMockStore_Query_CombinedFilters validates thresholds combined with flag filters.
+func TestMockStore_Query_CombinedFilters(t *testing.T) {
 	articles := []*models.Article{
-		{Title: "Article 1", Upvotes: models.NewNullableInt(50), CommentCount: models.NewNullableInt(10), Flagged: false, Dead: false, Dupe: false},
-		{Title: "Article 2", Upvotes: models.NewNullableInt(30), CommentCount: models.NewNullableInt(5), Flagged: true, Dead: false, Dupe: false},
-		{Title: "Article 3", Upvotes: models.NewNullableInt(40), CommentCount: models.NewNullableInt(15), Flagged: false, Dead: true, Dupe: false},
-		{Title: "Article 4", Upvotes: models.NewNullableInt(35), CommentCount: models.NewNullableInt(20), Flagged: true, Dead: true, Dupe: true},
+		summarized(&models.Article{Title: "Article 1", Upvotes: models.NewNullableInt(50), CommentCount: models.NewNullableInt(10), Flagged: false, Dead: false, Dupe: false}),
+		summarized(&models.Article{Title: "Article 2", Upvotes: models.NewNullableInt(30), CommentCount: models.NewNullableInt(5), Flagged: true, Dead: false, Dupe: false}),
+		summarized(&models.Article{Title: "Article 3", Upvotes: models.NewNullableInt(40), CommentCount: models.NewNullableInt(15), Flagged: false, Dead: true, Dupe: false}),
+		summarized(&models.Article{Title: "Article 4", Upvotes: models.NewNullableInt(35), CommentCount: models.NewNullableInt(20), Flagged: true, Dead: true, Dupe: true}),
 	}
 	mockStore := NewMockStore(articles, nil, nil)
 
@@ -207,7 +299,9 @@ func TestMockStore_GetArticlesWithThresholdsAndFilters(t *testing.T) {
 		flagged := true
 		dead := true
 		dupe := true
-		result, err := mockStore.GetArticlesWithThresholdsAndFilters(10, 0, 30, 5, &flagged, &dead, &dupe)
+		result, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{
+			Flagged: &flagged, Dead: &dead, Dupe: &dupe, MinUpvotes: 30, MinComments: 5,
+		}))
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -218,61 +312,177 @@ func TestMockStore_GetArticlesWithThresholdsAndFilters(t *testing.T) {
 
 	t.Run("PartialFilters", func(t *testing.T) {
 		flagged := false
-		result, err := mockStore.GetArticlesWithThresholdsAndFilters(10, 0, 40, 10, &flagged, nil, nil)
+		dead := true
+		result, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{
+			Flagged: &flagged, Dead: &dead, MinUpvotes: 40, MinComments: 10,
+		}))
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
-		if len(result) != 2 {
-			t.Fatalf("Expected 2 articles, got %d", len(result))
+		if len(result) != 1 || result[0].Title != "Article 3" {
+			t.Fatalf("Expected Article 3, got %v", result)
 		}
+	})
+}
+
+// TestMockStore_SaveArticles_Upsert verifies that saving a known article updates it in place.
+func TestMockStore_SaveArticles_Upsert(t *testing.T) {
+	firstSeen := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	mockStore := NewMockStore(nil, nil, nil)
+	_, err := mockStore.SaveArticles(context.Background(), []*models.Article{
+		summarized(&models.Article{HNID: 1, Title: "Repeated", Upvotes: models.NewNullableInt(10), CreatedAt: firstSeen}),
+		summarized(&models.Article{Title: "By Link", Link: "https://www.example.com/post/"}),
+	}, SaveAtomic)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_, err = mockStore.SaveArticles(context.Background(), []*models.Article{
+		summarized(&models.Article{HNID: 1, Title: "Repeated", Upvotes: models.NewNullableInt(20), CreatedAt: firstSeen.Add(time.Hour)}),
+		summarized(&models.Article{Title: "By Link", Link: "https://example.com/post"}),
+		summarized(&models.Article{HNID: 2, Title: "Unique"}),
+	}, SaveAtomic)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(mockStore.Articles) != 3 {
+		t.Fatalf("Expected 3 articles, got %d", len(mockStore.Articles))
+	}
+	repeated := mockStore.Articles[0]
+	if repeated.ID != 1 || repeated.Upvotes.Int64 != 20 || !repeated.CreatedAt.Equal(firstSeen) {
+		t.Errorf("Expected article 1 updated with its first sighting kept, got %+v", repeated)
+	}
+	if mockStore.Articles[1].ID != 2 || mockStore.Articles[2].ID != 3 {
+		t.Errorf("Expected IDs [1 2 3], got [%d %d %d]", repeated.ID, mockStore.Articles[1].ID, mockStore.Articles[2].ID)
+	}
+}
 
-		// Verify both expected articles are present
-		titles := map[string]bool{
-			"Article 1": false,
-			"Article 3": false,
+// TestMockStore_SaveArticles_FillsSummary verifies that a later sighting fills in a missing
+// summary, and that a sighting without one keeps the stored summary.
+func TestMockStore_SaveArticles_FillsSummary(t *testing.T) {
+	mockStore := NewMockStore(nil, nil, nil)
+	for _, article := range []*models.Article{
+		{HNID: 1, Title: "Pending"},
+		summarized(&models.Article{HNID: 1, Title: "Pending", ModelName: "llama3:8b"}),
+		{HNID: 1, Title: "Pending"},
+	} {
+		if _, err := mockStore.SaveArticles(context.Background(), []*models.Article{article}, SaveAtomic); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
 		}
-		for _, article := range result {
-			titles[article.Title] = true
+	}
+	if got := mockStore.Articles[0]; len(mockStore.Articles) != 1 || !got.Summary.Valid || got.ModelName != "llama3:8b" {
+		t.Errorf("Expected one article with the summary and model of the second save, got %+v", mockStore.Articles)
+	}
+}
+
+// TestMockStore_Search verifies term matching, relevance ordering and snippets of the mock search.
+func TestMockStore_Search(t *testing.T) {
+	articles := []*models.Article{
+		summarized(&models.Article{ID: 1, Title: "Go generics", Content: "Notes on rust too."}),
+		summarized(&models.Article{ID: 2, Title: "Rust in production"}),
+		summarized(&models.Article{ID: 3, Title: "Unrelated"}),
+	}
+	mockStore := NewMockStore(articles, nil, nil)
+
+	results, err := mockStore.Search(context.Background(), SearchQuery{Text: "rust"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(results) != 2 || results[0].Article.ID != 2 || results[1].Article.ID != 1 {
+		t.Fatalf("Expected articles [2 1], got %v", results)
+	}
+	if results[0].Snippet != "Summary of <mark>Rust</mark> in production" {
+		t.Errorf("Unexpected snippet: %q", results[0].Snippet)
+	}
+
+	if _, err := mockStore.Search(context.Background(), SearchQuery{Text: "  "}); !errors.Is(err, ErrInvalidSearch) {
+		t.Errorf("Expected ErrInvalidSearch, got %v", err)
+	}
+}
+
+// TestMockStore_Query_Sort validates the supported sort orders.
+func TestMockStore_Query_Sort(t *testing.T) {
+	articles := []*models.Article{
+		summarized(&models.Article{ID: 2, Title: "Article 2"}),
+		summarized(&models.Article{ID: 1, Title: "Article 1"}),
+		summarized(&models.Article{ID: 3, Title: "Article 3"}),
+	}
+	mockStore := NewMockStore(articles, nil, nil)
+
+	t.Run("Newest", func(t *testing.T) {
+		result, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{Sort: SortNewest}))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
 		}
+		if result[0].ID != 3 || result[2].ID != 1 {
+			t.Errorf("Expected newest first, got %v", result)
+		}
+	})
 
-		for title, found := range titles {
-			if !found {
-				t.Errorf("Expected article %q not found in results", title)
-			}
+	t.Run("Oldest", func(t *testing.T) {
+		result, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{Sort: SortOldest}))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result[0].ID != 1 || result[2].ID != 3 {
+			t.Errorf("Expected oldest first, got %v", result)
 		}
 	})
-}
 
-// TestMockStore_ThresholdMethods_Error verifies error propagation in threshold methods.
-func TestMockStore_ThresholdMethods_Error(t *testing.T) {
-	expectedErr := errors.New("get error")
-	mockStore := NewMockStore(nil, nil, expectedErr)
+	t.Run("Keys", func(t *testing.T) {
+		now := time.Now()
+		keyed := []*models.Article{
+			summarized(&models.Article{ID: 1, Title: "A", Upvotes: models.NewNullableInt(10), ArticleRank: 2, CreatedAt: now.Add(-time.Hour)}),
+			summarized(&models.Article{ID: 2, Title: "B", Upvotes: models.NewNullableInt(50), ArticleRank: 1, CreatedAt: now.Add(-48 * time.Hour)}),
+			summarized(&models.Article{ID: 3, Title: "C", Upvotes: models.NewNullableInt(10), ArticleRank: 3, CreatedAt: now.Add(-10 * time.Hour)}),
+		}
+		keyedStore := NewMockStore(keyed, nil, nil)
+		for _, tc := range []struct {
+			query    ArticleQuery
+			expected []string
+		}{
+			{ArticleQuery{Sort: SortUpvotes}, []string{"B", "C", "A"}},
+			{ArticleQuery{Sort: SortUpvotes, Order: Ascending}, []string{"A", "C", "B"}},
+			{ArticleQuery{Sort: SortRank}, []string{"B", "A", "C"}},
+			{ArticleQuery{Sort: SortHot}, []string{"A", "C", "B"}},
+		} {
+			result, err := articlesOf(keyedStore.Query(context.Background(), tc.query))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			expectTitles(t, result, tc.expected...)
 
-	t.Run("ThresholdsError", func(t *testing.T) {
-		_, err := mockStore.GetArticlesWithThresholds(10, 0, 0, 0)
-		if err != expectedErr {
-			t.Fatalf("Expected error: %v, got: %v", expectedErr, err)
+			tc.query.Limit = 2
+			page, err := keyedStore.Query(context.Background(), tc.query)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			tc.query.Cursor = page.NextCursor
+			result, err = articlesOf(keyedStore.Query(context.Background(), tc.query))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			expectTitles(t, result, tc.expected[2])
 		}
 	})
 
-	t.Run("CombinedError", func(t *testing.T) {
-		_, err := mockStore.GetArticlesWithThresholdsAndFilters(10, 0, 0, 0, nil, nil, nil)
-		if err != expectedErr {
-			t.Fatalf("Expected error: %v, got: %v", expectedErr, err)
+	t.Run("Unknown", func(t *testing.T) {
+		if _, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{Sort: "sideways"})); err == nil {
+			t.Fatal("Expected error for unknown sort order")
 		}
 	})
 }
 
-// TestMockStore_Pagination validates pagination behavior across methods.
+// TestMockStore_Pagination validates pagination behavior.
 func TestMockStore_Pagination(t *testing.T) {
 	articles := make([]*models.Article, 20)
 	for i := range articles {
-		articles[i] = &models.Article{Title: fmt.Sprintf("Article %d", i+1)}
+		articles[i] = summarized(&models.Article{Title: fmt.Sprintf("Article %d", i+1)})
 	}
 	mockStore := NewMockStore(articles, nil, nil)
 
 	t.Run("LimitOffset", func(t *testing.T) {
-		result, err := mockStore.GetArticles(5, 10)
+		result, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{Limit: 5, Offset: 10}))
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -284,8 +494,22 @@ func TestMockStore_Pagination(t *testing.T) {
 		}
 	})
 
+	t.Run("DefaultLimit", func(t *testing.T) {
+		many := make([]*models.Article, DefaultLimit+5)
+		for i := range many {
+			many[i] = summarized(&models.Article{Title: fmt.Sprintf("Article %d", i+1)})
+		}
+		result, err := articlesOf(NewMockStore(many, nil, nil).Query(context.Background(), ArticleQuery{}))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(result) != DefaultLimit {
+			t.Fatalf("Expected %d articles, got %d", DefaultLimit, len(result))
+		}
+	})
+
 	t.Run("OffsetExceedsResults", func(t *testing.T) {
-		result, err := mockStore.GetArticlesWithThresholds(10, 25, 0, 0)
+		result, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{Limit: 10, Offset: 25}))
 		if err != nil {
 			t.Fatalf("Unexpected error: %v", err)
 		}
@@ -294,3 +518,93 @@ func TestMockStore_Pagination(t *testing.T) {
 		}
 	})
 }
+
+// TestMockStore_Count verifies that Count applies the query filters but not its pagination.
+func TestMockStore_Count(t *testing.T) {
+	articles := make([]*models.Article, 12)
+	for i := range articles {
+		articles[i] = summarized(&models.Article{Title: fmt.Sprintf("Article %d", i+1), Upvotes: models.NewNullableInt(int64(i))})
+	}
+	articles[0].Dead = true
+	mockStore := NewMockStore(articles, nil, nil)
+
+	count, err := mockStore.Count(context.Background(), ArticleQuery{Limit: 5, Offset: 10})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 11 {
+		t.Errorf("Expected 11 visible articles, got %d", count)
+	}
+	if count, _ = mockStore.Count(context.Background(), ArticleQuery{MinUpvotes: 10}); count != 2 {
+		t.Errorf("Expected 2 articles with at least 10 upvotes, got %d", count)
+	}
+}
+
+// TestMockStore_Query_TimeBounds verifies the since, until and updated-since bounds.
+func TestMockStore_Query_TimeBounds(t *testing.T) {
+	day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	mockStore := NewMockStore([]*models.Article{
+		summarized(&models.Article{ID: 1, Title: "Before", CreatedAt: day.Add(-time.Minute), UpdatedAt: day.Add(48 * time.Hour)}),
+		summarized(&models.Article{ID: 2, Title: "During", CreatedAt: day, UpdatedAt: day}),
+		summarized(&models.Article{ID: 3, Title: "After", CreatedAt: day.Add(24 * time.Hour), UpdatedAt: day.Add(24 * time.Hour)}),
+	}, nil, nil)
+
+	result, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{Since: day, Until: day.Add(24 * time.Hour)}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectTitles(t, result, "During")
+
+	result, err = articlesOf(mockStore.Query(context.Background(), ArticleQuery{UpdatedSince: day.Add(time.Hour)}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectTitles(t, result, "After", "Before")
+}
+
+// TestMockStore_Domains verifies the domain filters and the per-domain summary.
+func TestMockStore_Domains(t *testing.T) {
+	link := func(title, link string, upvotes int64) *models.Article {
+		return summarized(&models.Article{Title: title, Link: link, Upvotes: models.NewNullableInt(upvotes)})
+	}
+	mockStore := NewMockStore(nil, nil, nil)
+	if _, err := mockStore.SaveArticles(context.Background(), []*models.Article{
+		link("Go", "https://github.com/golang/go", 10),
+		link("Gist", "https://gist.github.com/someone/1", 20),
+		link("LWN", "https://www.lwn.net/Articles/1/", 3),
+	}, SaveAtomic); err != nil {
+		t.Fatalf("SaveArticles failed: %v", err)
+	}
+	if domain := mockStore.Articles[1].Domain; domain != "github.com" {
+		t.Errorf("Expected the gist to be saved under github.com, got %q", domain)
+	}
+
+	result, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{ExcludeDomains: []string{"gist.github.com"}}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expectTitles(t, result, "LWN")
+
+	domains, err := mockStore.Domains(context.Background(), ArticleQuery{})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := fmt.Sprint(domainSummary(domains)); got != "[github.com:2:15 lwn.net:1:3]" {
+		t.Errorf("Unexpected domain summary %s", got)
+	}
+}
+
+// TestMockStore_Query_Fields verifies that fieldsets trim copies, leaving the stored articles whole.
+func TestMockStore_Query_Fields(t *testing.T) {
+	mockStore := NewMockStore([]*models.Article{summarized(&models.Article{ID: 1, Title: "Whole", Content: "Content"})}, nil, nil)
+	result, err := articlesOf(mockStore.Query(context.Background(), ArticleQuery{Fields: []string{"title"}}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result) != 1 || result[0].Title != "Whole" || result[0].Content != "" || result[0].Summary.Valid || result[0].ID != 1 {
+		t.Errorf("Expected only the title and ID, got %+v", result)
+	}
+	if mockStore.Articles[0].Content != "Content" {
+		t.Error("Expected the stored article to keep its content")
+	}
+}
@@ -0,0 +1,40 @@
+package store
+
+import (
+	"context"
+	"os"
+	"testing"
+)
+
+// newMySQLTestStore connects to the database named by MYSQL_TEST_DSN, migrates it and empties its tables.
+func newMySQLTestStore(t *testing.T) Store {
+	t.Helper()
+	dsn := os.Getenv("MYSQL_TEST_DSN")
+	if dsn == "" {
+		t.Skip("MYSQL_TEST_DSN not set; skipping MySQL store tests")
+	}
+	s, err := NewMySQLStore(dsn)
+	if err != nil {
+		t.Fatalf("NewMySQLStore failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	migrator, err := s.Migrator()
+	if err != nil {
+		t.Fatalf("Migrator failed: %v", err)
+	}
+	if err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("Failed to migrate database: %v", err)
+	}
+	// Snapshots and deliveries reference articles, so they must go first; MySQL cannot truncate a referenced table.
+	for _, stmt := range []string{"DELETE FROM webhook_deliveries", "DELETE FROM webhooks", "DELETE FROM article_snapshots", "DELETE FROM articles", "ALTER TABLE articles AUTO_INCREMENT = 1"} {
+		if _, err := s.db.ExecContext(context.Background(), stmt); err != nil {
+			t.Fatalf("Failed to reset tables: %v", err)
+		}
+	}
+	return s
+}
+
+// TestMySQLStore runs the shared store behavior tests against MySQL when MYSQL_TEST_DSN is set.
+func TestMySQLStore(t *testing.T) {
+	runStoreBehaviorTests(t, newMySQLTestStore)
+}
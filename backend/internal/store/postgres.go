@@ -0,0 +1,34 @@
+package store
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	_ "github.com/jackc/pgx/v5/stdlib"
+)
+
+// PostgresStore implements Store using a PostgreSQL database.
+// It shares the MySQL store's queries, rewriting placeholders and upserts into PostgreSQL syntax.
+type PostgresStore struct {
+	sqlStore
+}
+
+// NewPostgresStore connects to the PostgreSQL database at dataSourceName.
+// Its schema is managed with the "migrate" subcommand.
+func NewPostgresStore(dataSourceName string) (*PostgresStore, error) {
+	db, err := sql.Open("pgx", dataSourceName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	if err = db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+	return &PostgresStore{sqlStore{db: db, dialect: postgresDialect}}, nil
+}
+
+// isPostgresDSN reports whether dataSourceName is a PostgreSQL connection URL.
+func isPostgresDSN(dataSourceName string) bool {
+	return strings.HasPrefix(dataSourceName, "postgres://") || strings.HasPrefix(dataSourceName, "postgresql://")
+}
@@ -0,0 +1,387 @@
+package store
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// SortOrder determines the order in which articles are returned.
+type SortOrder string
+
+const (
+	// SortNewest returns the most recently stored articles first.
+	SortNewest SortOrder = "newest"
+	// SortOldest returns the earliest stored articles first.
+	SortOldest SortOrder = "oldest"
+	// SortUpvotes returns the most upvoted articles first.
+	SortUpvotes SortOrder = "upvotes"
+	// SortComments returns the most commented articles first.
+	SortComments SortOrder = "comments"
+	// SortRank returns articles by their Hacker News front page rank, top first.
+	SortRank SortOrder = "rank"
+	// SortHot returns articles by upvotes decayed with age, hottest first.
+	SortHot SortOrder = "hot"
+	// SortUpdated returns the most recently updated articles first.
+	SortUpdated SortOrder = "updated"
+)
+
+// SortDirection determines whether a sort order is applied ascending or descending.
+type SortDirection string
+
+const (
+	// Ascending returns articles with the smallest sort values first.
+	Ascending SortDirection = "asc"
+	// Descending returns articles with the largest sort values first.
+	Descending SortDirection = "desc"
+)
+
+// naturalDirection returns the direction in which sort lists its documented first articles first.
+func naturalDirection(sort SortOrder) SortDirection {
+	if sort == SortOldest || sort == SortRank {
+		return Ascending
+	}
+	return Descending
+}
+
+// hotGravity is the exponent with which the age of an article in hours decays its hot score.
+const hotGravity = 1.8
+
+// hotScore returns the hot score of article as of the Unix time epoch:
+// its upvotes divided by its age in hours plus two, raised to hotGravity.
+func hotScore(article *models.Article, epoch int64) float64 {
+	age := float64(max(epoch-article.CreatedAt.Unix(), 0))
+	return float64(article.Upvotes.Int64) / math.Pow(age/3600+2, hotGravity)
+}
+
+// Page sizes of article queries.
+const (
+	DefaultLimit = 30  // Used when ArticleQuery.Limit is not set.
+	MaxLimit     = 100 // Larger limits are reduced to this.
+)
+
+// ArticleQuery describes which articles to retrieve and how to page through them.
+// The zero value selects the newest summarized articles that are not flagged, dead or duplicates.
+// Articles without a usable summary are left out, except when filtering by flagged, dead or
+// duplicate status without a threshold.
+type ArticleQuery struct {
+	Flagged        *bool         // Filter by flagged status; nil excludes flagged articles.
+	Dead           *bool         // Filter by dead status; nil excludes dead articles.
+	Dupe           *bool         // Filter by duplicate status; nil excludes duplicates.
+	MinUpvotes     int           // Minimum upvotes; 0 disables the threshold.
+	MinComments    int           // Minimum comment count; 0 disables the threshold.
+	Since          time.Time     // Only articles created at or after Since; zero disables the bound.
+	Until          time.Time     // Only articles created before Until; zero disables the bound.
+	UpdatedSince   time.Time     // Only articles updated at or after UpdatedSince; zero disables the bound.
+	Domains        []string      // Only articles linking to one of these sites; empty disables the filter.
+	ExcludeDomains []string      // Exclude articles linking to these sites.
+	ModelNames     []string      // Only articles summarized by one of these models; empty disables the filter.
+	CommitHashes   []string      // Only articles summarized by one of these builds; empty disables the filter.
+	Sort           SortOrder     // Result order; empty defaults to SortNewest.
+	Order          SortDirection // Direction of Sort; empty uses the direction the SortOrder documents.
+	Limit          int           // Maximum number of articles to return; 0 uses DefaultLimit, capped at MaxLimit.
+	Offset         int           // Number of matching articles to skip, after the cursor if one is given.
+	Cursor         string        // Resume after the page that returned this ArticlePage.NextCursor.
+	Fields         []string      // Columns to load, named as in JSON; empty loads all. Others are left zero.
+
+	after    *cursor // Decoded Cursor, set by normalize.
+	hotEpoch int64   // Unix time SortHot scores are computed as of, set by normalize.
+	facet    string  // Column whose own filter is lifted to count all its values, set by Facets.
+}
+
+// Matches reports whether a store would select article for q's filters, thresholds and time
+// bounds, ignoring its sort order and paging. It lets holders of articles that did not come from
+// a query, such as subscribers to saved articles, apply the same filters.
+func (q ArticleQuery) Matches(article *models.Article) bool {
+	q.Domains = normalizeDomains(q.Domains)
+	q.ExcludeDomains = normalizeDomains(q.ExcludeDomains)
+	q.facet = ""
+	return matchesQuery(q, article)
+}
+
+// requiresSummary reports whether q only selects articles with a usable summary. Filtering by
+// flagged, dead or duplicate status alone also selects unsummarized articles, which are often the
+// ones being moderated; any threshold requires a summary again.
+func (q ArticleQuery) requiresSummary() bool {
+	flagFiltered := q.Flagged != nil || q.Dead != nil || q.Dupe != nil
+	return !flagFiltered || q.MinUpvotes > 0 || q.MinComments > 0
+}
+
+// normalize fills in defaults and rejects queries no store can execute.
+func (q ArticleQuery) normalize() (ArticleQuery, error) {
+	switch q.Sort {
+	case "":
+		q.Sort = SortNewest
+	case SortNewest, SortOldest, SortUpvotes, SortComments, SortRank, SortHot, SortUpdated:
+	default:
+		return q, fmt.Errorf("unknown sort order %q", q.Sort)
+	}
+	switch q.Order {
+	case "":
+		q.Order = naturalDirection(q.Sort)
+	case Ascending, Descending:
+	default:
+		return q, fmt.Errorf("unknown sort direction %q", q.Order)
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultLimit
+	}
+	if q.Limit > MaxLimit {
+		q.Limit = MaxLimit
+	}
+	if q.Offset < 0 {
+		q.Offset = 0
+	}
+	for _, field := range q.Fields {
+		if !slices.ContainsFunc(articleColumns, func(c articleColumn) bool { return c.name == field }) {
+			return q, fmt.Errorf("unknown article field %q", field)
+		}
+	}
+	q.Domains = normalizeDomains(q.Domains)
+	q.ExcludeDomains = normalizeDomains(q.ExcludeDomains)
+	if q.Cursor != "" {
+		after, err := decodeCursor(q.Cursor, q.Sort, q.Order)
+		if err != nil {
+			return q, err
+		}
+		q.after = after
+	}
+	if q.Sort == SortHot {
+		// Hot scores decay over time, so every page of a walk scores as of its first page.
+		q.hotEpoch = time.Now().Unix()
+		if q.after != nil {
+			q.hotEpoch = q.after.HotEpoch
+		}
+	}
+	return q, nil
+}
+
+// filterConditions returns the SQL conditions and arguments selecting the articles matched by q.
+func filterConditions(d sqlDialect, q ArticleQuery) ([]string, []interface{}) {
+	var conditions []string
+	if q.requiresSummary() {
+		conditions = append(conditions,
+			"summary IS NOT NULL",
+			"TRIM(summary) != ''",
+			"summary NOT LIKE 'No summary available%'",
+		)
+	}
+	var args []interface{}
+
+	for _, flag := range []struct {
+		column string
+		value  *bool
+	}{
+		{"flagged", q.Flagged},
+		{"dead", q.Dead},
+		{"dupe", q.Dupe},
+	} {
+		if flag.column == q.facet {
+			continue
+		}
+		if flag.value != nil {
+			conditions = append(conditions, flag.column+" = ?")
+			args = append(args, *flag.value)
+		} else {
+			conditions = append(conditions, flag.column+" = FALSE")
+		}
+	}
+
+	if q.MinUpvotes > 0 {
+		conditions = append(conditions, "upvotes >= ?")
+		args = append(args, q.MinUpvotes)
+	}
+	if q.MinComments > 0 {
+		conditions = append(conditions, "comment_count >= ?")
+		args = append(args, q.MinComments)
+	}
+
+	for _, filter := range []struct {
+		column, op string
+		values     []string
+	}{
+		{"domain", "IN", q.Domains},
+		{"domain", "NOT IN", q.ExcludeDomains},
+		{"model_name", "IN", q.ModelNames},
+		{"commit_hash", "IN", q.CommitHashes},
+	} {
+		if len(filter.values) > 0 && filter.column != q.facet {
+			placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(filter.values)), ", ")
+			conditions = append(conditions, filter.column+" "+filter.op+" ("+placeholders+")")
+			for _, value := range filter.values {
+				args = append(args, value)
+			}
+		}
+	}
+
+	for _, bound := range []struct {
+		column, op string
+		value      time.Time
+	}{
+		{"created_at", ">=", q.Since},
+		{"created_at", "<", q.Until},
+		{"updated_at", ">=", q.UpdatedSince},
+	} {
+		if !bound.value.IsZero() {
+			condition, arg := d.timeCondition(bound.column, bound.op, bound.value)
+			conditions = append(conditions, condition)
+			args = append(args, arg)
+		}
+	}
+	return conditions, args
+}
+
+// sortExpression returns the SQL expression articles of alias "a" are ordered by under q's sort order
+// before their ID, or an empty string if they are ordered by ID alone. The hot score expression
+// takes q.hotEpoch as its argument.
+func sortExpression(d sqlDialect, q ArticleQuery) (string, []interface{}) {
+	switch q.Sort {
+	case SortUpvotes:
+		return "COALESCE(a.upvotes, 0)", nil
+	case SortComments:
+		return "COALESCE(a.comment_count, 0)", nil
+	case SortRank:
+		return "a.article_rank", nil
+	case SortUpdated:
+		return "a.updated_at", nil
+	case SortHot:
+		return d.hotScore, []interface{}{q.hotEpoch}
+	}
+	return "", nil
+}
+
+// orderByClause returns the ORDER BY expression for the query's sort order, where sortColumn
+// names the selected sort expression, if any.
+func orderByClause(q ArticleQuery, sortColumn string) string {
+	direction := " DESC"
+	if q.Order == Ascending {
+		direction = " ASC"
+	}
+	if sortColumn == "" {
+		return "a.id" + direction
+	}
+	return sortColumn + direction + ", a.id" + direction
+}
+
+// articleColumn is a column of the articles table that queries may leave out.
+type articleColumn struct {
+	name        string // Column name, which is also the JSON name of the Article field.
+	placeholder string // Literal selected in its place when left out; empty if always loaded.
+}
+
+// articleColumns lists the article columns in the order scanned by the SQL stores.
+var articleColumns = []articleColumn{
+	{"id", ""},
+	{"hn_id", "0"},
+	{"title", "''"},
+	{"link", "''"},
+	{"article_rank", "0"},
+	{"content", "''"},
+	{"summary", "NULL"},
+	{"source", "''"},
+	{"upvotes", "NULL"},
+	{"comment_count", "NULL"},
+	{"comment_link", "NULL"},
+	{"flagged", "FALSE"},
+	{"dead", "FALSE"},
+	{"dupe", "FALSE"},
+	{"commit_hash", "''"},
+	{"model_name", "''"},
+	{"created_at", ""},
+	{"updated_at", ""},
+	{"domain", "''"},
+}
+
+// loads reports whether articles returned for q carry column: every column when q names no
+// fields, otherwise the named ones, those always loaded and the one q is sorted by.
+func (q ArticleQuery) loads(column articleColumn) bool {
+	if len(q.Fields) == 0 || column.placeholder == "" || slices.Contains(q.Fields, column.name) {
+		return true
+	}
+	switch q.Sort {
+	case SortUpvotes:
+		return column.name == "upvotes"
+	case SortComments:
+		return column.name == "comment_count"
+	case SortRank:
+		return column.name == "article_rank"
+	}
+	return false
+}
+
+// selectColumns lists the article columns of alias "a" in the order scanned by the SQL stores,
+// selecting placeholders for the columns q does not load.
+func selectColumns(q ArticleQuery) string {
+	columns := make([]string, len(articleColumns))
+	for i, column := range articleColumns {
+		if q.loads(column) {
+			columns[i] = "a." + column.name
+		} else {
+			columns[i] = column.placeholder + " AS " + column.name
+		}
+	}
+	return strings.Join(columns, ", ")
+}
+
+// buildCountQuery renders the count of the articles matched by q's filters, thresholds and time bounds.
+func buildCountQuery(d sqlDialect, q ArticleQuery) (string, []interface{}) {
+	conditions, args := filterConditions(d, q)
+	return `
+		SELECT COUNT(*)
+		FROM articles a
+		WHERE ` + strings.Join(conditions, "\n\t\t  AND ") + `;
+	`, args
+}
+
+// keysetCondition returns the condition and arguments selecting the articles after q's cursor,
+// where expr and exprArgs are the sort expression of q.
+func keysetCondition(q ArticleQuery, expr string, exprArgs []interface{}) (string, []interface{}) {
+	op := "<"
+	if q.Order == Ascending {
+		op = ">"
+	}
+	if expr == "" {
+		return "a.id " + op + " ?", []interface{}{q.after.ID}
+	}
+	var args []interface{}
+	args = append(args, exprArgs...)
+	args = append(args, q.after.Value)
+	args = append(args, exprArgs...)
+	args = append(args, q.after.Value, q.after.ID)
+	return "(" + expr + " " + op + " ? OR (" + expr + " = ? AND a.id " + op + " ?))", args
+}
+
+// buildSelectQuery renders a normalized query as a SELECT statement and its arguments.
+// It selects one article more than the limit, which tells newArticlePage whether more follow.
+// Queries sorted by SortHot select the hot score after the article columns.
+func buildSelectQuery(d sqlDialect, q ArticleQuery) (string, []interface{}) {
+	expr, exprArgs := sortExpression(d, q)
+	columns, sortColumn := selectColumns(q), expr
+	var args []interface{}
+	if q.Sort == SortHot {
+		columns += ", " + expr + " AS hot_score"
+		sortColumn = "hot_score"
+		args = append(args, exprArgs...)
+	}
+
+	conditions, filterArgs := filterConditions(d, q)
+	args = append(args, filterArgs...)
+	if q.after != nil {
+		condition, keysetArgs := keysetCondition(q, expr, exprArgs)
+		conditions = append(conditions, condition)
+		args = append(args, keysetArgs...)
+	}
+	query := `
+		SELECT ` + columns + `
+		FROM articles a
+		WHERE ` + strings.Join(conditions, "\n\t\t  AND ") + `
+		ORDER BY ` + orderByClause(q, sortColumn) + `
+		LIMIT ? OFFSET ?;
+	`
+	args = append(args, q.Limit+1, q.Offset)
+	return query, args
+}
@@ -0,0 +1,285 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// TestBuildSelectQuery_Defaults verifies that unset flag filters exclude flagged, dead and duplicate articles.
+func TestBuildSelectQuery_Defaults(t *testing.T) {
+	q, err := ArticleQuery{}.normalize()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	query, args := buildSelectQuery(mysqlDialect, q)
+
+	for _, condition := range []string{"flagged = FALSE", "dead = FALSE", "dupe = FALSE", "summary IS NOT NULL", "ORDER BY a.id DESC"} {
+		if !strings.Contains(query, condition) {
+			t.Errorf("Expected query to contain %q:\n%s", condition, query)
+		}
+	}
+	if strings.Contains(query, "upvotes >=") || strings.Contains(query, "comment_count >=") {
+		t.Errorf("Expected no threshold conditions:\n%s", query)
+	}
+	// One extra row tells whether another page follows.
+	if len(args) != 2 || args[0] != DefaultLimit+1 || args[1] != 0 {
+		t.Errorf("Expected args [%d 0], got %v", DefaultLimit+1, args)
+	}
+}
+
+// TestArticleQuery_MaxLimit verifies that oversized limits are capped.
+func TestArticleQuery_MaxLimit(t *testing.T) {
+	q, err := ArticleQuery{Limit: 100000}.normalize()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if q.Limit != MaxLimit {
+		t.Errorf("Expected limit %d, got %d", MaxLimit, q.Limit)
+	}
+}
+
+// TestBuildSelectQuery_FiltersAndThresholds verifies that placeholders and arguments stay aligned.
+func TestBuildSelectQuery_FiltersAndThresholds(t *testing.T) {
+	flagged := true
+	dupe := false
+	q, err := ArticleQuery{
+		Flagged:     &flagged,
+		Dupe:        &dupe,
+		MinUpvotes:  10,
+		MinComments: 5,
+		Sort:        SortOldest,
+		Limit:       20,
+		Offset:      40,
+	}.normalize()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	query, args := buildSelectQuery(mysqlDialect, q)
+
+	if got := strings.Count(query, "?"); got != len(args) {
+		t.Fatalf("Expected %d placeholders, got %d", len(args), got)
+	}
+	expected := []interface{}{true, false, 10, 5, 21, 40}
+	for i, arg := range expected {
+		if args[i] != arg {
+			t.Errorf("Expected arg %d to be %v, got %v", i, arg, args[i])
+		}
+	}
+	if strings.Contains(query, "JOIN") {
+		t.Errorf("Expected a single-table query:\n%s", query)
+	}
+	if !strings.Contains(query, "dead = FALSE") || !strings.Contains(query, "ORDER BY a.id ASC") {
+		t.Errorf("Unexpected query:\n%s", query)
+	}
+}
+
+// TestBuildSelectQuery_Postgres verifies that rebinding for PostgreSQL numbers every placeholder.
+func TestBuildSelectQuery_Postgres(t *testing.T) {
+	flagged := true
+	q, err := ArticleQuery{Flagged: &flagged, MinUpvotes: 10}.normalize()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	query, args := buildSelectQuery(postgresDialect, q)
+	query = postgresDialect.rebind(query)
+
+	if strings.Contains(query, "?") {
+		t.Errorf("Expected no ? placeholders:\n%s", query)
+	}
+	for i := 1; i <= len(args); i++ {
+		if !strings.Contains(query, fmt.Sprintf("$%d", i)) {
+			t.Errorf("Expected placeholder $%d:\n%s", i, query)
+		}
+	}
+}
+
+// testCursor returns the cursor resuming the walk of q after article.
+func testCursor(t *testing.T, q ArticleQuery, article *models.Article) string {
+	t.Helper()
+	q, err := q.normalize()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	return encodeCursor(q, article, sortValue(q, article))
+}
+
+// TestBuildSelectQuery_Cursor verifies that cursors become keyset conditions in the query's sort direction.
+func TestBuildSelectQuery_Cursor(t *testing.T) {
+	for _, tc := range []struct {
+		query     ArticleQuery
+		condition string
+		args      []interface{}
+	}{
+		{ArticleQuery{Sort: SortNewest}, "a.id < ?", []interface{}{42}},
+		{ArticleQuery{Sort: SortOldest}, "a.id > ?", []interface{}{42}},
+		{ArticleQuery{Sort: SortNewest, Order: Ascending}, "a.id > ?", []interface{}{42}},
+		{ArticleQuery{Sort: SortUpvotes}, "(COALESCE(a.upvotes, 0) < ? OR (COALESCE(a.upvotes, 0) = ? AND a.id < ?))", []interface{}{int64(7), int64(7), 42}},
+		{ArticleQuery{Sort: SortRank}, "(a.article_rank > ? OR (a.article_rank = ? AND a.id > ?))", []interface{}{int64(3), int64(3), 42}},
+	} {
+		article := &models.Article{ID: 42, ArticleRank: 3, Upvotes: models.NewNullableInt(7)}
+		tc.query.Cursor = testCursor(t, tc.query, article)
+		q, err := tc.query.normalize()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		query, args := buildSelectQuery(mysqlDialect, q)
+		if !strings.Contains(query, tc.condition) {
+			t.Errorf("Expected query to contain %q:\n%s", tc.condition, query)
+		}
+		keyset := args[len(args)-2-len(tc.args) : len(args)-2]
+		if strings.Count(query, "?") != len(args) || !reflect.DeepEqual(keyset, tc.args) {
+			t.Errorf("Expected keyset args %v before LIMIT and OFFSET, got %v", tc.args, args)
+		}
+	}
+}
+
+// TestBuildSelectQuery_Sorts verifies the ORDER BY clause of every sort order and direction.
+func TestBuildSelectQuery_Sorts(t *testing.T) {
+	for _, tc := range []struct {
+		query   ArticleQuery
+		orderBy string
+	}{
+		{ArticleQuery{}, "ORDER BY a.id DESC"},
+		{ArticleQuery{Sort: SortOldest}, "ORDER BY a.id ASC"},
+		{ArticleQuery{Sort: SortUpvotes}, "ORDER BY COALESCE(a.upvotes, 0) DESC, a.id DESC"},
+		{ArticleQuery{Sort: SortComments, Order: Ascending}, "ORDER BY COALESCE(a.comment_count, 0) ASC, a.id ASC"},
+		{ArticleQuery{Sort: SortRank}, "ORDER BY a.article_rank ASC, a.id ASC"},
+		{ArticleQuery{Sort: SortUpdated}, "ORDER BY a.updated_at DESC, a.id DESC"},
+		{ArticleQuery{Sort: SortHot}, "ORDER BY hot_score DESC, a.id DESC"},
+	} {
+		q, err := tc.query.normalize()
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		query, args := buildSelectQuery(sqliteDialect, q)
+		if !strings.Contains(query, tc.orderBy) {
+			t.Errorf("Expected query to contain %q:\n%s", tc.orderBy, query)
+		}
+		if strings.Count(query, "?") != len(args) {
+			t.Errorf("Expected %d args, got %v:\n%s", strings.Count(query, "?"), args, query)
+		}
+	}
+	for _, bad := range []ArticleQuery{{Sort: "sideways"}, {Order: "up"}} {
+		if _, err := bad.normalize(); err == nil {
+			t.Errorf("Expected an error for %+v", bad)
+		}
+	}
+}
+
+// TestDecodeCursor verifies that malformed cursors and cursors of another sort order or direction are rejected.
+func TestDecodeCursor(t *testing.T) {
+	token := testCursor(t, ArticleQuery{}, &models.Article{ID: 7})
+	if c, err := decodeCursor(token, SortNewest, Descending); err != nil || c.ID != 7 {
+		t.Fatalf("Expected cursor at ID 7, got %+v (%v)", c, err)
+	}
+	for _, bad := range []string{"not a cursor!", "e30", token + "x"} {
+		if _, err := decodeCursor(bad, SortNewest, Descending); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor for %q, got %v", bad, err)
+		}
+	}
+	if _, err := decodeCursor(token, SortOldest, Ascending); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor for a cursor of another sort order, got %v", err)
+	}
+	if _, err := decodeCursor(token, SortNewest, Ascending); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ErrInvalidCursor for a cursor of another direction, got %v", err)
+	}
+
+	updated := time.Date(2026, 10, 1, 12, 0, 0, 500, time.UTC)
+	token = testCursor(t, ArticleQuery{Sort: SortUpdated}, &models.Article{ID: 7, UpdatedAt: updated})
+	if c, err := decodeCursor(token, SortUpdated, Descending); err != nil || !c.Value.(time.Time).Equal(updated) {
+		t.Errorf("Expected cursor at %v, got %+v (%v)", updated, c, err)
+	}
+	token = testCursor(t, ArticleQuery{Sort: SortHot}, &models.Article{ID: 7, Upvotes: models.NewNullableInt(50)})
+	if c, err := decodeCursor(token, SortHot, Descending); err != nil || c.HotEpoch == 0 {
+		t.Errorf("Expected hot cursor with its epoch, got %+v (%v)", c, err)
+	}
+}
+
+// TestAfterIDCursor verifies that ID cursors resume an oldest-first walk after the given article.
+func TestAfterIDCursor(t *testing.T) {
+	ms := NewMockStore([]*models.Article{
+		{ID: 1, Title: "One", Summary: models.NewNullableString("Summary")},
+		{ID: 2, Title: "Two", Summary: models.NewNullableString("Summary")},
+		{ID: 3, Title: "Three", Summary: models.NewNullableString("Summary")},
+	}, nil, nil)
+	page, err := ms.Query(context.Background(), ArticleQuery{Sort: SortOldest, Cursor: AfterIDCursor(1)})
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	if len(page.Articles) != 2 || page.Articles[0].ID != 2 || page.Articles[1].ID != 3 {
+		t.Errorf("Expected articles 2 and 3, got %v", page.Articles)
+	}
+	if _, err := decodeCursor(AfterIDCursor(1), SortNewest, Descending); !errors.Is(err, ErrInvalidCursor) {
+		t.Errorf("Expected ID cursors to be rejected for other sort orders, got %v", err)
+	}
+}
+
+// TestArticleQuery_Matches verifies that in-memory matching applies the filters a query selects by.
+func TestArticleQuery_Matches(t *testing.T) {
+	article := &models.Article{
+		ID: 1, Title: "Article", Link: "https://gist.github.com/x", Summary: models.NewNullableString("Summary"),
+		ModelName: "llama3:8b", Upvotes: models.NewNullableInt(10),
+	}
+	yes := true
+	for _, tc := range []struct {
+		q        ArticleQuery
+		expected bool
+	}{
+		{ArticleQuery{}, true},
+		{ArticleQuery{Domains: []string{"WWW.GitHub.com"}}, true},
+		{ArticleQuery{ExcludeDomains: []string{"github.com"}}, false},
+		{ArticleQuery{ModelNames: []string{"mistral"}}, false},
+		{ArticleQuery{MinUpvotes: 11}, false},
+		{ArticleQuery{Flagged: &yes}, false},
+		{ArticleQuery{Limit: 1, Offset: 5, Sort: SortUpvotes}, true},
+	} {
+		if got := tc.q.Matches(article); got != tc.expected {
+			t.Errorf("%+v: expected %v, got %v", tc.q, tc.expected, got)
+		}
+	}
+
+	unsummarized := &models.Article{ID: 2, Title: "Unsummarized", Link: "https://example.com"}
+	if (ArticleQuery{}).Matches(unsummarized) {
+		t.Error("Expected an article without a summary to be excluded by default")
+	}
+	if !(ArticleQuery{Flagged: new(bool)}).Matches(unsummarized) {
+		t.Error("Expected an article without a summary to match a flag filter")
+	}
+	if (ArticleQuery{Flagged: new(bool), MinComments: 1}).Matches(unsummarized) {
+		t.Error("Expected an article without a summary to be excluded with a threshold")
+	}
+}
+
+// TestRebind verifies placeholder rewriting outside of string literals.
+func TestRebind(t *testing.T) {
+	query := "SELECT * FROM articles WHERE title = ? AND summary NOT LIKE 'Why?%' AND id > ?"
+	if got := mysqlDialect.rebind(query); got != query {
+		t.Errorf("Expected MySQL query to be unchanged, got %q", got)
+	}
+	expected := "SELECT * FROM articles WHERE title = $1 AND summary NOT LIKE 'Why?%' AND id > $2"
+	if got := postgresDialect.rebind(query); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+}
+
+// TestUpsertClause verifies the upsert syntax of each dialect.
+func TestUpsertClause(t *testing.T) {
+	expected := "ON DUPLICATE KEY UPDATE upvotes = VALUES(upvotes), summary = COALESCE(NULLIF(VALUES(summary), ''), summary)"
+	if got := mysqlDialect.upsertClause([]string{"upvotes"}, []string{"summary"}); got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+	expected = "ON CONFLICT (article_key) DO UPDATE SET upvotes = excluded.upvotes, " +
+		"summary = COALESCE(NULLIF(excluded.summary, ''), articles.summary)"
+	for _, d := range []sqlDialect{sqliteDialect, postgresDialect} {
+		if got := d.upsertClause([]string{"upvotes"}, []string{"summary"}); got != expected {
+			t.Errorf("Expected %q, got %q", expected, got)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// SaveMode determines how SaveArticles treats articles that cannot be stored.
+type SaveMode int
+
+const (
+	// SaveAtomic stores every article or none of them; the first failure aborts the batch.
+	SaveAtomic SaveMode = iota
+	// SavePartial stores every article it can and reports the rejected ones in a *BatchSaveError.
+	SavePartial
+)
+
+// Column limits shared by the schemas of every dialect.
+const (
+	maxTitleLength = 255
+	maxLinkLength  = 512
+)
+
+// SavedArticle describes an article stored by SaveArticles.
+type SavedArticle struct {
+	Index   int             // Position of the article in the batch passed to SaveArticles.
+	Article *models.Article // The article as stored, with its ID and first sighting.
+	Created bool            // Whether the save created the article rather than updating it.
+}
+
+// ArticleError describes why a single article of a batch was rejected.
+type ArticleError struct {
+	Index int   // Position of the article in the batch passed to SaveArticles.
+	HNID  int   // Hacker News ID of the article, or 0 if unknown.
+	Err   error // Cause of the rejection.
+}
+
+// Error implements the error interface.
+func (e ArticleError) Error() string {
+	return fmt.Sprintf("article %d (HN ID %d): %v", e.Index, e.HNID, e.Err)
+}
+
+// Unwrap returns the cause of the rejection.
+func (e ArticleError) Unwrap() error {
+	return e.Err
+}
+
+// BatchSaveError is returned by SaveArticles in SavePartial mode when some articles were rejected.
+// Every article not listed in Failures was stored.
+type BatchSaveError struct {
+	Total    int            // Number of articles in the batch.
+	Failures []ArticleError // Rejected articles in batch order.
+}
+
+// Error implements the error interface.
+func (e *BatchSaveError) Error() string {
+	if len(e.Failures) == 1 {
+		return fmt.Sprintf("failed to save 1 of %d articles: %v", e.Total, e.Failures[0])
+	}
+	return fmt.Sprintf("failed to save %d of %d articles, first: %v", len(e.Failures), e.Total, e.Failures[0])
+}
+
+// Unwrap returns the causes of every rejection, so errors.Is and errors.As see through the batch.
+func (e *BatchSaveError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure
+	}
+	return errs
+}
+
+// newArticleError describes the rejection of the article at index.
+func newArticleError(index int, article *models.Article, err error) ArticleError {
+	e := ArticleError{Index: index, Err: err}
+	if article != nil {
+		e.HNID = article.HNID
+	}
+	return e
+}
+
+// add records the rejection of the article at index.
+func (e *BatchSaveError) add(index int, article *models.Article, err error) {
+	e.Failures = append(e.Failures, newArticleError(index, article, err))
+}
+
+// errOrNil returns e if any article was rejected and nil otherwise.
+func (e *BatchSaveError) errOrNil() error {
+	if len(e.Failures) == 0 {
+		return nil
+	}
+	return e
+}
+
+// ErrInvalidArticle is wrapped by the errors of articles rejected before reaching the database.
+var ErrInvalidArticle = errors.New("invalid article")
+
+// validateArticle rejects articles that no store can hold.
+func validateArticle(article *models.Article) error {
+	switch {
+	case article == nil:
+		return fmt.Errorf("%w: article is nil", ErrInvalidArticle)
+	case article.Title == "":
+		return fmt.Errorf("%w: title is required", ErrInvalidArticle)
+	case utf8.RuneCountInString(article.Title) > maxTitleLength:
+		return fmt.Errorf("%w: title exceeds %d characters", ErrInvalidArticle, maxTitleLength)
+	case utf8.RuneCountInString(article.Link) > maxLinkLength:
+		return fmt.Errorf("%w: link exceeds %d characters", ErrInvalidArticle, maxLinkLength)
+	case article.HNID < 0:
+		return fmt.Errorf("%w: negative HN ID %d", ErrInvalidArticle, article.HNID)
+	}
+	return nil
+}
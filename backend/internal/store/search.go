@@ -0,0 +1,260 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// SortRelevance orders search results by how well they match, best first. It is only valid in a SearchQuery.
+const SortRelevance SortOrder = "relevance"
+
+// ErrInvalidSearch is wrapped by the errors of search texts that contain nothing to search for.
+var ErrInvalidSearch = errors.New("invalid search")
+
+// SearchQuery describes a full-text search over the title, content and summary of articles.
+// The embedded ArticleQuery supplies the same filters, thresholds and pagination as Query;
+// its Sort defaults to SortRelevance.
+//
+// Text is a list of terms that must all match. A term is a word, a word ending in "*" to match
+// every word starting with it, or a "quoted phrase" whose words must appear in order.
+type SearchQuery struct {
+	Text string
+	ArticleQuery
+}
+
+// searchTerm is a single required term of a search text.
+type searchTerm struct {
+	words  []string // One word, or the words of a phrase in order.
+	prefix bool     // Match words starting with the single word rather than the word itself.
+}
+
+// normalize fills in defaults, parses the search text and rejects searches no store can execute.
+func (q SearchQuery) normalize() (SearchQuery, []searchTerm, error) {
+	if q.Cursor != "" {
+		return q, nil, fmt.Errorf("%w: searches are paginated with offset", ErrInvalidCursor)
+	}
+	relevance := false
+	switch q.Sort {
+	case "", SortRelevance:
+		relevance = true
+		q.Sort = ""
+	case SortNewest, SortOldest:
+	default:
+		return q, nil, fmt.Errorf("unknown sort order %q", q.Sort)
+	}
+	articleQuery, err := q.ArticleQuery.normalize()
+	if err != nil {
+		return q, nil, err
+	}
+	q.ArticleQuery = articleQuery
+	if relevance {
+		q.Sort = SortRelevance
+	}
+
+	terms := parseSearchTerms(q.Text)
+	if len(terms) == 0 {
+		return q, nil, fmt.Errorf("%w: %q contains no searchable words", ErrInvalidSearch, q.Text)
+	}
+	return q, terms, nil
+}
+
+// parseSearchTerms splits text into words, prefix words and quoted phrases. Words are reduced to
+// letters and digits, so search operators of the underlying databases can never be injected.
+func parseSearchTerms(text string) []searchTerm {
+	var terms []searchTerm
+	for i, part := range strings.Split(text, `"`) {
+		if i%2 == 1 {
+			if words := searchWords(part); len(words) > 0 {
+				terms = append(terms, searchTerm{words: words})
+			}
+			continue
+		}
+		for _, field := range strings.Fields(part) {
+			words := searchWords(field)
+			for j, word := range words {
+				prefix := j == len(words)-1 && strings.HasSuffix(field, "*")
+				terms = append(terms, searchTerm{words: []string{word}, prefix: prefix})
+			}
+		}
+	}
+	return terms
+}
+
+// searchWords returns the lower-cased runs of letters and digits in s.
+func searchWords(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// mysqlBooleanQuery renders terms for MATCH ... AGAINST in boolean mode.
+func mysqlBooleanQuery(terms []searchTerm) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		switch {
+		case len(term.words) > 1:
+			parts[i] = `+"` + strings.Join(term.words, " ") + `"`
+		case term.prefix:
+			parts[i] = "+" + term.words[0] + "*"
+		default:
+			parts[i] = "+" + term.words[0]
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// fts5Query renders terms for an SQLite FTS5 MATCH expression.
+func fts5Query(terms []searchTerm) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = `"` + strings.Join(term.words, " ") + `"`
+		if term.prefix {
+			parts[i] += "*"
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// tsQuery renders terms for PostgreSQL's to_tsquery.
+func tsQuery(terms []searchTerm) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		switch {
+		case len(term.words) > 1:
+			parts[i] = "(" + strings.Join(term.words, " <-> ") + ")"
+		case term.prefix:
+			parts[i] = term.words[0] + ":*"
+		default:
+			parts[i] = term.words[0]
+		}
+	}
+	return strings.Join(parts, " & ")
+}
+
+// buildSearchQuery renders a normalized search as a SELECT statement and its arguments.
+// The statement selects the article columns followed by a relevance score, higher being better.
+func buildSearchQuery(d sqlDialect, q SearchQuery, terms []searchTerm) (string, []interface{}) {
+	var score, join, match string
+	var args []interface{}
+	switch d.fullText {
+	case fullTextMatchAgainst:
+		score = "MATCH(a.title, a.content, a.summary) AGAINST (? IN BOOLEAN MODE)"
+		match = score
+		args = append(args, mysqlBooleanQuery(terms), mysqlBooleanQuery(terms))
+	case fullTextFTS5:
+		score = "f.score"
+		join = "INNER JOIN (\n\t\t\tSELECT rowid, -bm25(articles_fts, 10.0, 1.0, 5.0) AS score\n\t\t\tFROM articles_fts\n\t\t\tWHERE articles_fts MATCH ?\n\t\t) f ON f.rowid = a.id"
+		args = append(args, fts5Query(terms))
+	case fullTextTSVector:
+		score = "ts_rank(a.search_vector, to_tsquery('english', ?))"
+		match = "a.search_vector @@ to_tsquery('english', ?)"
+		args = append(args, tsQuery(terms), tsQuery(terms))
+	}
+
+	conditions, filterArgs := filterConditions(d, q.ArticleQuery)
+	if match != "" {
+		conditions = append([]string{match}, conditions...)
+	}
+	args = append(args, filterArgs...)
+
+	orderBy := "relevance DESC, a.id DESC"
+	if q.Sort != SortRelevance {
+		orderBy = orderByClause(q.ArticleQuery, "")
+	}
+	query := `
+		SELECT ` + selectColumns(q.ArticleQuery) + `, ` + score + ` AS relevance
+		FROM articles a
+		` + join + `
+		WHERE ` + strings.Join(conditions, "\n\t\t  AND ") + `
+		ORDER BY ` + orderBy + `
+		LIMIT ? OFFSET ?;
+	`
+	args = append(args, q.Limit, q.Offset)
+	return query, args
+}
+
+// maxSnippetLength is the number of characters of a summary shown around the first match.
+const maxSnippetLength = 200
+
+// termPattern compiles a case-insensitive pattern matching any of terms as whole words or word
+// prefixes, so that plurals and other inflections the databases match by stemming are found too.
+func termPattern(terms []searchTerm) *regexp.Regexp {
+	alternatives := make([]string, len(terms))
+	for i, term := range terms {
+		words := make([]string, len(term.words))
+		for j, word := range term.words {
+			words[j] = regexp.QuoteMeta(word) + `[\pL\pN]*`
+		}
+		alternatives[i] = strings.Join(words, `[^\pL\pN]+`)
+	}
+	return regexp.MustCompile(`(?i)(^|[^\pL\pN])(` + strings.Join(alternatives, "|") + `)`)
+}
+
+// highlightSnippet returns an HTML excerpt of summary around the first match of terms, with every
+// match wrapped in <mark> elements. Long summaries are trimmed to about maxSnippetLength characters.
+func highlightSnippet(summary string, terms []searchTerm) string {
+	if summary == "" {
+		return ""
+	}
+	pattern := termPattern(terms)
+
+	start, end := 0, len(summary)
+	if utf8.RuneCountInString(summary) > maxSnippetLength {
+		first := 0
+		if loc := pattern.FindStringSubmatchIndex(summary); loc != nil {
+			first = loc[4]
+		}
+		start = snippetBoundary(summary, first-maxSnippetLength/4)
+		end = snippetBoundary(summary, start+maxSnippetLength)
+	}
+	excerpt := strings.TrimRight(summary[start:end], " \t\n")
+
+	var b strings.Builder
+	if start > 0 {
+		b.WriteString("…")
+	}
+	last := 0
+	for _, loc := range pattern.FindAllStringSubmatchIndex(excerpt, -1) {
+		b.WriteString(html.EscapeString(excerpt[last:loc[4]]))
+		b.WriteString("<mark>" + html.EscapeString(excerpt[loc[4]:loc[5]]) + "</mark>")
+		last = loc[5]
+	}
+	b.WriteString(html.EscapeString(excerpt[last:]))
+	if end < len(summary) {
+		b.WriteString("…")
+	}
+	return b.String()
+}
+
+// snippetBoundary moves offset to the nearest preceding word boundary of s, clamped to s.
+func snippetBoundary(s string, offset int) int {
+	if offset <= 0 {
+		return 0
+	}
+	if offset >= len(s) {
+		return len(s)
+	}
+	if i := strings.LastIndexAny(s[:offset], " \t\n"); i > 0 {
+		return i + 1
+	}
+	for offset > 0 && !utf8.RuneStart(s[offset]) {
+		offset--
+	}
+	return offset
+}
+
+// newSearchResult pairs article with its relevance and highlighted summary snippet.
+func newSearchResult(article *models.Article, relevance float64, terms []searchTerm) *models.SearchResult {
+	return &models.SearchResult{
+		Article:   article,
+		Relevance: relevance,
+		Snippet:   highlightSnippet(article.Summary.String, terms),
+	}
+}
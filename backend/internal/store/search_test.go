@@ -0,0 +1,101 @@
+package store
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseSearchTerms verifies the parsing of words, prefixes and phrases.
+func TestParseSearchTerms(t *testing.T) {
+	terms := parseSearchTerms(`Rust "Memory  Safety" compil* C++ +"" -x`)
+	expected := []searchTerm{
+		{words: []string{"rust"}},
+		{words: []string{"memory", "safety"}},
+		{words: []string{"compil"}, prefix: true},
+		{words: []string{"c"}},
+		{words: []string{"x"}},
+	}
+	if len(terms) != len(expected) {
+		t.Fatalf("Expected %d terms, got %+v", len(expected), terms)
+	}
+	for i := range expected {
+		if strings.Join(terms[i].words, " ") != strings.Join(expected[i].words, " ") || terms[i].prefix != expected[i].prefix {
+			t.Errorf("Term %d: expected %+v, got %+v", i, expected[i], terms[i])
+		}
+	}
+	if len(parseSearchTerms(`" " * -- ""`)) != 0 {
+		t.Error("Expected no terms for text without words")
+	}
+}
+
+// TestSearchSyntax verifies the rendering of terms for each database.
+func TestSearchSyntax(t *testing.T) {
+	terms := parseSearchTerms(`rust "memory safety" compil*`)
+	if got, expected := mysqlBooleanQuery(terms), `+rust +"memory safety" +compil*`; got != expected {
+		t.Errorf("Expected MySQL query %q, got %q", expected, got)
+	}
+	if got, expected := fts5Query(terms), `"rust" "memory safety" "compil"*`; got != expected {
+		t.Errorf("Expected FTS5 query %q, got %q", expected, got)
+	}
+	if got, expected := tsQuery(terms), `rust & (memory <-> safety) & compil:*`; got != expected {
+		t.Errorf("Expected tsquery %q, got %q", expected, got)
+	}
+}
+
+// TestBuildSearchQuery verifies that placeholders and arguments stay aligned in every dialect.
+func TestBuildSearchQuery(t *testing.T) {
+	flagged := true
+	q, terms, err := SearchQuery{Text: "rust", ArticleQuery: ArticleQuery{Flagged: &flagged, MinUpvotes: 10}}.normalize()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for name, d := range map[string]sqlDialect{"mysql": mysqlDialect, "sqlite": sqliteDialect, "postgres": postgresDialect} {
+		query, args := buildSearchQuery(d, q, terms)
+		if got := strings.Count(query, "?"); got != len(args) {
+			t.Errorf("%s: expected %d placeholders, got %d:\n%s", name, len(args), got, query)
+		}
+		if !strings.Contains(query, "ORDER BY relevance DESC") {
+			t.Errorf("%s: expected relevance ordering:\n%s", name, query)
+		}
+	}
+}
+
+// TestSearchQuery_Normalize verifies sort defaults and the rejection of empty searches.
+func TestSearchQuery_Normalize(t *testing.T) {
+	q, _, err := SearchQuery{Text: "go"}.normalize()
+	if err != nil || q.Sort != SortRelevance || q.Limit != DefaultLimit {
+		t.Errorf("Expected relevance sort and default limit, got %+v (%v)", q, err)
+	}
+	if _, _, err := (SearchQuery{Text: "go", ArticleQuery: ArticleQuery{Sort: SortOldest}}).normalize(); err != nil {
+		t.Errorf("Expected oldest sort to be accepted, got %v", err)
+	}
+	if _, _, err := (SearchQuery{Text: "go", ArticleQuery: ArticleQuery{Sort: "sideways"}}).normalize(); err == nil {
+		t.Error("Expected error for unknown sort order")
+	}
+	if _, err := (ArticleQuery{Sort: SortRelevance}).normalize(); err == nil {
+		t.Error("Expected relevance sort to be rejected outside searches")
+	}
+}
+
+// TestHighlightSnippet verifies highlighting, HTML escaping and trimming of long summaries.
+func TestHighlightSnippet(t *testing.T) {
+	terms := parseSearchTerms(`rust "memory safety"`)
+
+	got := highlightSnippet("Rust brings <memory safety> to systems; rusty tools too.", terms)
+	expected := "<mark>Rust</mark> brings &lt;<mark>memory safety</mark>&gt; to systems; <mark>rusty</mark> tools too."
+	if got != expected {
+		t.Errorf("Expected %q, got %q", expected, got)
+	}
+
+	long := strings.Repeat("filler words here ", 30) + "about Rust today " + strings.Repeat("more trailing text ", 30)
+	got = highlightSnippet(long, terms)
+	if !strings.HasPrefix(got, "…") || !strings.HasSuffix(got, "…") || !strings.Contains(got, "<mark>Rust</mark>") {
+		t.Errorf("Expected a trimmed excerpt around the match, got %q", got)
+	}
+	if len([]rune(got)) > maxSnippetLength+len("<mark></mark>")+2 {
+		t.Errorf("Expected at most about %d characters, got %d", maxSnippetLength, len([]rune(got)))
+	}
+	if highlightSnippet("", terms) != "" {
+		t.Error("Expected an empty snippet for an empty summary")
+	}
+}
@@ -0,0 +1,51 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	_ "modernc.org/sqlite"
+)
+
+// SQLiteScheme prefixes data source names that select the embedded SQLite store.
+const SQLiteScheme = "sqlite://"
+
+// SQLiteStore implements Store using an embedded SQLite database.
+// It shares the MySQL store's queries, so upserts and summary filtering behave identically.
+type SQLiteStore struct {
+	sqlStore
+}
+
+// NewSQLiteStore opens the SQLite database at path, creating it if needed and migrating
+// it to the latest schema, since an embedded database has no separate deployment step.
+// A path of ":memory:" yields a private in-memory database.
+func NewSQLiteStore(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	// SQLite allows a single writer, and every connection to ":memory:" would
+	// otherwise see its own empty database.
+	db.SetMaxOpenConns(1)
+
+	store := &SQLiteStore{sqlStore{db: db, dialect: sqliteDialect}}
+	migrator, err := store.Migrator()
+	if err == nil {
+		err = migrator.Up(context.Background())
+	}
+	if err == nil {
+		_, err = store.BackfillArticleKeys(context.Background())
+	}
+	if err == nil {
+		_, err = store.BackfillSnapshots(context.Background())
+	}
+	if err == nil {
+		_, err = store.BackfillDomains(context.Background())
+	}
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to migrate database: %w", err)
+	}
+	return store, nil
+}
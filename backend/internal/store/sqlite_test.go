@@ -0,0 +1,210 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// newSQLiteTestStore opens a fresh SQLite database in the test's temporary directory.
+func newSQLiteTestStore(t *testing.T) Store {
+	t.Helper()
+	s, err := NewSQLiteStore(filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+	return s
+}
+
+// TestSQLiteStore runs the shared store behavior tests against SQLite.
+func TestSQLiteStore(t *testing.T) {
+	runStoreBehaviorTests(t, newSQLiteTestStore)
+}
+
+// TestOpen_SQLiteScheme verifies that the sqlite:// scheme selects the SQLite store.
+func TestOpen_SQLiteScheme(t *testing.T) {
+	s, err := Open(SQLiteScheme + ":memory:")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	sqliteStore, ok := s.(*SQLiteStore)
+	if !ok {
+		t.Fatalf("Expected *SQLiteStore, got %T", s)
+	}
+	sqliteStore.Close()
+}
+
+// TestSQLiteStore_PartialDatabaseFailure verifies that rows rejected by the database itself are
+// isolated from the rest of a multi-row insert in partial mode and roll back everything otherwise.
+func TestSQLiteStore_PartialDatabaseFailure(t *testing.T) {
+	ctx := context.Background()
+	s := newSQLiteTestStore(t).(*SQLiteStore)
+	if _, err := s.db.Exec(`CREATE TRIGGER reject_articles BEFORE INSERT ON articles
+		WHEN NEW.title = 'Rejected' BEGIN SELECT RAISE(ABORT, 'rejected by trigger'); END`); err != nil {
+		t.Fatalf("Failed to create trigger: %v", err)
+	}
+	batch := func() []*models.Article {
+		return []*models.Article{
+			newTestArticle(1, "Kept", 1, 1),
+			newTestArticle(2, "Rejected", 1, 1),
+			newTestArticle(3, "Also Kept", 1, 1),
+		}
+	}
+
+	if _, err := s.SaveArticles(ctx, batch(), SaveAtomic); err == nil {
+		t.Fatal("Expected atomic save to fail")
+	}
+	if result, err := articlesOf(s.Query(ctx, ArticleQuery{})); err != nil || len(result) != 0 {
+		t.Fatalf("Expected nothing saved, got %v (%v)", titles(result), err)
+	}
+
+	_, err := s.SaveArticles(ctx, batch(), SavePartial)
+	var batchErr *BatchSaveError
+	if !errors.As(err, &batchErr) || len(batchErr.Failures) != 1 || batchErr.Failures[0].Index != 1 || batchErr.Failures[0].HNID != 2 {
+		t.Fatalf("Expected article 1 to be rejected, got %v", err)
+	}
+	result, err := articlesOf(s.Query(ctx, ArticleQuery{}))
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	expectTitles(t, result, "Also Kept", "Kept")
+}
+
+// TestSQLiteTimestamp verifies that timestamps in the driver's layout convert to Unix times in SQL.
+func TestSQLiteTimestamp(t *testing.T) {
+	s, err := NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+	defer s.Close()
+
+	for _, stored := range []time.Time{
+		time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC),
+		time.Date(2026, 10, 1, 12, 0, 0, 250000000, time.FixedZone("PDT", -7*3600)),
+		time.Date(2026, 10, 1, 12, 0, 0, 0, time.FixedZone("IST", 5*3600+1800)),
+	} {
+		var unix int64
+		query := "SELECT CAST(strftime('%s', " + sqliteTimestamp("ts") + ") AS INTEGER) FROM (SELECT ? AS ts)"
+		if err := s.db.QueryRow(query, stored).Scan(&unix); err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		if unix != stored.Unix() {
+			t.Errorf("Expected %d for %v, got %d", stored.Unix(), stored, unix)
+		}
+	}
+}
+
+// TestSQLiteStore_BackfillDomains verifies that articles saved before domains were recorded
+// receive them after migrating, and that older schemas are left alone.
+func TestSQLiteStore_BackfillDomains(t *testing.T) {
+	ctx := context.Background()
+	s := newSQLiteTestStore(t).(*SQLiteStore)
+	first, second := newTestArticle(1, "First", 1, 1), newTestArticle(2, "Second", 1, 1)
+	second.Link = "https://blog.golang.org/intro"
+	saveAll(t, s, first, second)
+	if _, err := s.db.Exec("UPDATE articles SET domain = ''"); err != nil {
+		t.Fatalf("Failed to clear domains: %v", err)
+	}
+
+	if updated, err := s.BackfillDomains(ctx); err != nil || updated != 2 {
+		t.Fatalf("Expected 2 articles updated, got %d (%v)", updated, err)
+	}
+	stored, err := s.ArticleByHNID(ctx, 2)
+	if err != nil || stored.Domain != "golang.org" {
+		t.Fatalf("Expected domain golang.org, got %+v (%v)", stored, err)
+	}
+	if updated, err := s.BackfillDomains(ctx); err != nil || updated != 0 {
+		t.Errorf("Expected nothing left to backfill, got %d (%v)", updated, err)
+	}
+
+	migrator, err := s.Migrator()
+	if err == nil {
+		err = migrator.To(ctx, domainMigration-1)
+	}
+	if err != nil {
+		t.Fatalf("Failed to migrate down: %v", err)
+	}
+	if updated, err := s.BackfillDomains(ctx); err != nil || updated != 0 {
+		t.Errorf("Expected older schemas to be skipped, got %d (%v)", updated, err)
+	}
+}
+
+// TestSQLiteStore_BackfillArticleKeys verifies that articles saved without a key are keyed by their
+// HN ID or link, and that copies of one article are merged into the newest with both histories.
+func TestSQLiteStore_BackfillArticleKeys(t *testing.T) {
+	ctx := context.Background()
+	s := newSQLiteTestStore(t).(*SQLiteStore)
+	older, newer := newTestArticle(0, "Older", 1, 1), newTestArticle(0, "Newer", 5, 1)
+	older.Link, newer.Link = "https://www.example.com/post/", "https://example.com/post?utm_source=hn"
+	older.CreatedAt = newer.CreatedAt.Add(-time.Hour)
+	unrelated := newTestArticle(7, "Unrelated", 1, 1)
+	clearKeys := func() {
+		if _, err := s.db.Exec("UPDATE articles SET article_key = NULL"); err != nil {
+			t.Fatalf("Failed to clear keys: %v", err)
+		}
+	}
+	// Clearing keys between saves stores both copies, as the scraper's plain inserts did.
+	saveAll(t, s, older)
+	clearKeys()
+	saveAll(t, s, newer, unrelated)
+	clearKeys()
+
+	if keyed, err := s.BackfillArticleKeys(ctx); err != nil || keyed != 3 {
+		t.Fatalf("Expected 3 articles keyed, got %d (%v)", keyed, err)
+	}
+	result, err := articlesOf(s.Query(ctx, ArticleQuery{}))
+	if err != nil {
+		t.Fatalf("Query failed: %v", err)
+	}
+	expectTitles(t, result, "Unrelated", "Newer")
+	merged := result[1]
+	if !merged.CreatedAt.Equal(older.CreatedAt) {
+		t.Errorf("Expected the merged article to keep the first sighting %v, got %v", older.CreatedAt, merged.CreatedAt)
+	}
+	if history, err := s.History(ctx, merged.ID); err != nil || len(history) != 2 {
+		t.Errorf("Expected the snapshots of both copies, got %d (%v)", len(history), err)
+	}
+
+	// The backfilled key is the one SaveArticles upserts by.
+	again := newTestArticle(0, "Again", 9, 1)
+	again.Link = "http://example.com/post"
+	saveAll(t, s, again)
+	if result, err := articlesOf(s.Query(ctx, ArticleQuery{})); err != nil || len(result) != 2 {
+		t.Fatalf("Expected the resaved article to update its row, got %v (%v)", titles(result), err)
+	}
+	if keyed, err := s.BackfillArticleKeys(ctx); err != nil || keyed != 0 {
+		t.Errorf("Expected nothing left to backfill, got %d (%v)", keyed, err)
+	}
+}
+
+// TestSQLiteStore_BackfillSnapshots verifies that articles updated around the store are snapshotted once.
+func TestSQLiteStore_BackfillSnapshots(t *testing.T) {
+	ctx := context.Background()
+	s := newSQLiteTestStore(t).(*SQLiteStore)
+	article := newTestArticle(1, "Scraped", 1, 1)
+	saveAll(t, s, article, newTestArticle(2, "Untouched", 1, 1))
+	// Scrapers writing to the database directly updated articles without a snapshot.
+	if _, err := s.db.Exec("UPDATE articles SET upvotes = 9, updated_at = ? WHERE hn_id = 1", article.UpdatedAt.Add(time.Hour)); err != nil {
+		t.Fatalf("Failed to update article: %v", err)
+	}
+
+	if recorded, err := s.BackfillSnapshots(ctx); err != nil || recorded != 1 {
+		t.Fatalf("Expected 1 snapshot recorded, got %d (%v)", recorded, err)
+	}
+	if recorded, err := s.BackfillSnapshots(ctx); err != nil || recorded != 0 {
+		t.Errorf("Expected nothing left to snapshot, got %d (%v)", recorded, err)
+	}
+	stored, err := s.ArticleByHNID(ctx, 1)
+	if err != nil {
+		t.Fatalf("ArticleByHNID failed: %v", err)
+	}
+	history, err := s.History(ctx, stored.ID)
+	if err != nil || len(history) != 2 || history[1].Upvotes.Int64 != 9 {
+		t.Errorf("Expected the direct update to be snapshotted, got %+v (%v)", history, err)
+	}
+}
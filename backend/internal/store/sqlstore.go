@@ -0,0 +1,703 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/migrations"
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// sqlDialect captures the SQL differences between the databases backing the SQL stores.
+// Queries are written with "?" placeholders and MySQL syntax unless a flag says otherwise.
+type sqlDialect struct {
+	migrations           string // Name of the dialect's migration set in the migrations package.
+	numberedPlaceholders bool   // Rewrite "?" placeholders to PostgreSQL's "$1", "$2", ...
+	onConflict           bool   // Upsert with ON CONFLICT instead of ON DUPLICATE KEY UPDATE.
+	returningIDs         bool   // Read generated IDs with RETURNING, as the driver has no LastInsertId.
+	fullText             fullTextSyntax
+	textTimestamps       bool   // Timestamps are stored as text in the layout of the SQLite driver.
+	hotScore             string // Expression computing the hot score of article "a" as of the Unix time argument.
+	saveLock             string // Statement beginning a save transaction, so that concurrent saves cannot both create an article.
+	lockingRead          string // Suffix of the read of existing keys in a save, locking them and the gaps of absent ones.
+}
+
+// fullTextSyntax selects how a dialect searches the text of articles.
+type fullTextSyntax int
+
+const (
+	fullTextMatchAgainst fullTextSyntax = iota // MySQL FULLTEXT index queried with MATCH ... AGAINST.
+	fullTextFTS5                               // SQLite FTS5 table articles_fts ranked with bm25.
+	fullTextTSVector                           // PostgreSQL search_vector column ranked with ts_rank.
+)
+
+var (
+	mysqlDialect = sqlDialect{
+		migrations:  migrations.MySQL,
+		fullText:    fullTextMatchAgainst,
+		hotScore:    "COALESCE(a.upvotes, 0) / POW(GREATEST(? - UNIX_TIMESTAMP(a.created_at), 0) / 3600 + 2, 1.8)",
+		lockingRead: " FOR UPDATE",
+	}
+	sqliteDialect = sqlDialect{
+		migrations:     migrations.SQLite,
+		onConflict:     true,
+		fullText:       fullTextFTS5,
+		textTimestamps: true,
+		hotScore:       "COALESCE(a.upvotes, 0) / pow(MAX(? - CAST(strftime('%s', " + sqliteTimestamp("a.created_at") + ") AS INTEGER), 0) / 3600.0 + 2, 1.8)",
+	}
+	postgresDialect = sqlDialect{
+		migrations:           migrations.Postgres,
+		numberedPlaceholders: true,
+		onConflict:           true,
+		returningIDs:         true,
+		fullText:             fullTextTSVector,
+		hotScore:             "COALESCE(a.upvotes, 0) / POWER(GREATEST(CAST(? AS DOUBLE PRECISION) - FLOOR(EXTRACT(EPOCH FROM a.created_at))::DOUBLE PRECISION, 0) / 3600 + 2, 1.8)",
+		// Row locks cannot cover keys that do not exist yet, so saves take turns.
+		saveLock: "LOCK TABLE articles IN SHARE ROW EXCLUSIVE MODE",
+	}
+)
+
+// sqliteTimestamp returns an expression converting column from the layout in which the SQLite
+// driver stores times, "2006-01-02 15:04:05.999999999 -0700 MST", to one SQLite's date functions
+// understand, "2006-01-02 15:04:05-07:00". Fractional seconds are dropped.
+func sqliteTimestamp(column string) string {
+	zone := "instr(substr(" + column + ", 20), ' ')"
+	return "substr(" + column + ", 1, 19) || substr(" + column + ", " + zone + " + 20, 3) || ':' || substr(" + column + ", " + zone + " + 23, 2)"
+}
+
+// timeCondition returns the condition comparing the timestamp column with op against t and its argument.
+// Timestamps stored as text are compared as Unix times, to the second.
+func (d sqlDialect) timeCondition(column, op string, t time.Time) (string, interface{}) {
+	if d.textTimestamps {
+		return "CAST(strftime('%s', " + sqliteTimestamp(column) + ") AS INTEGER) " + op + " ?", t.Unix()
+	}
+	return column + " " + op + " ?", t
+}
+
+// rebind rewrites the "?" placeholders of query into the dialect's placeholder syntax.
+// Question marks inside single-quoted string literals are left untouched.
+func (d sqlDialect) rebind(query string) string {
+	if !d.numberedPlaceholders {
+		return query
+	}
+	var b strings.Builder
+	b.Grow(len(query) + 16)
+	n, quoted := 0, false
+	for _, r := range query {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case r == '?' && !quoted:
+			n++
+			b.WriteString("$" + strconv.Itoa(n))
+			continue
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// upsertClause returns the clause that turns an INSERT into articles into an update when a row
+// with the same article_key already exists. The refreshed columns take the incoming values, while
+// the filled columns only do so when the incoming value is neither NULL nor empty.
+func (d sqlDialect) upsertClause(refreshed, filled []string) string {
+	assignments := make([]string, 0, len(refreshed)+len(filled))
+	for _, column := range refreshed {
+		assignments = append(assignments, column+" = "+d.incoming(column))
+	}
+	for _, column := range filled {
+		stored := column
+		if d.onConflict {
+			stored = "articles." + column
+		}
+		assignments = append(assignments, column+" = COALESCE(NULLIF("+d.incoming(column)+", ''), "+stored+")")
+	}
+	if d.onConflict {
+		return "ON CONFLICT (article_key) DO UPDATE SET " + strings.Join(assignments, ", ")
+	}
+	return "ON DUPLICATE KEY UPDATE " + strings.Join(assignments, ", ")
+}
+
+// incoming refers to the value an upsert tried to insert into column.
+func (d sqlDialect) incoming(column string) string {
+	if d.onConflict {
+		return "excluded." + column
+	}
+	return "VALUES(" + column + ")"
+}
+
+// insertColumns lists the columns written by SaveArticles, in the order of articleValues.
+var insertColumns = []string{
+	"article_key", "hn_id", "title", "link", "article_rank", "content", "summary", "source",
+	"upvotes", "comment_count", "comment_link", "flagged", "dead", "dupe",
+	"commit_hash", "model_name", "created_at", "updated_at", "domain",
+}
+
+// upsertColumns are refreshed when an already stored article is seen again.
+var upsertColumns = []string{"title", "article_rank", "upvotes", "comment_count", "flagged", "dead", "dupe", "updated_at"}
+
+// fillColumns are updated when an already stored article is seen again with a value for them, so
+// that a summary written on a later run is kept while a run without one leaves it in place.
+// Everything else, including created_at, keeps the values of the first sighting.
+var fillColumns = []string{"content", "summary", "commit_hash", "model_name"}
+
+// maxBatchRows bounds the rows of one multi-row INSERT, keeping its placeholder count well below
+// the limits of every supported database.
+const maxBatchRows = 500
+
+// buildInsertQuery renders a multi-row upsert of n articles.
+func (d sqlDialect) buildInsertQuery(n int) string {
+	row := inList(len(insertColumns))
+	rows := make([]string, n)
+	for i := range rows {
+		rows[i] = row
+	}
+	return d.rebind("INSERT INTO articles (" + strings.Join(insertColumns, ", ") + ")\nVALUES " +
+		strings.Join(rows, ",\n       ") + "\n" + d.upsertClause(upsertColumns, fillColumns))
+}
+
+// inList returns the placeholders of an IN list of n values, such as "(?, ?, ?)".
+func inList(n int) string {
+	return "(?" + strings.Repeat(", ?", n-1) + ")"
+}
+
+// articleValues returns the values of article for the columns in insertColumns.
+func articleValues(article *models.Article) []interface{} {
+	return []interface{}{
+		articleKey(article),
+		article.HNID,
+		article.Title,
+		article.Link,
+		article.ArticleRank,
+		article.Content,
+		article.Summary,
+		article.Source,
+		article.Upvotes,
+		article.CommentCount,
+		article.CommentLink,
+		article.Flagged,
+		article.Dead,
+		article.Dupe,
+		article.CommitHash,
+		article.ModelName,
+		article.CreatedAt,
+		article.UpdatedAt,
+		linkDomain(article.Link),
+	}
+}
+
+// pendingArticle is an article of a batch together with its position in the batch.
+type pendingArticle struct {
+	index   int
+	article *models.Article
+}
+
+// chunkArticles splits a batch into groups of at most size articles that can each be written with
+// one multi-row INSERT. A group never holds two articles with the same key, since PostgreSQL
+// refuses to upsert one row twice in a statement; the later copy starts a new group and wins.
+func chunkArticles(pending []pendingArticle, size int) [][]pendingArticle {
+	var chunks [][]pendingArticle
+	var current []pendingArticle
+	keys := make(map[string]bool)
+	for _, p := range pending {
+		key := articleKey(p.article)
+		if len(current) == size || (key.Valid && keys[key.String]) {
+			chunks = append(chunks, current)
+			current = nil
+			keys = make(map[string]bool)
+		}
+		if key.Valid {
+			keys[key.String] = true
+		}
+		current = append(current, p)
+	}
+	if len(current) > 0 {
+		chunks = append(chunks, current)
+	}
+	return chunks
+}
+
+// sqlStore implements Store on top of database/sql. The MySQL, SQLite and PostgreSQL
+// stores embed it and differ only in their driver and dialect.
+type sqlStore struct {
+	db      *sql.DB
+	dialect sqlDialect
+	planner DeliveryPlanner // Plans the webhook deliveries of every save, if set.
+}
+
+// Close releases the underlying database.
+func (store *sqlStore) Close() error {
+	return store.db.Close()
+}
+
+// Migrator returns a Migrator managing the schema of the underlying database.
+func (store *sqlStore) Migrator() (*migrations.Migrator, error) {
+	return migrations.New(store.db, store.dialect.migrations)
+}
+
+// SaveArticles upserts articles in a single transaction using multi-row inserts. Articles are
+// identified by their Hacker News ID, or by their normalized link when the ID is unknown, so saving
+// an article again updates its rank, engagement counts and flags instead of adding a duplicate row.
+// Every save of an article also appends a snapshot to its history and, with a DeliveryPlanner set,
+// enqueues its webhook deliveries. The stored articles are returned in batch order, telling apart
+// the ones the save created from those it updated.
+//
+// In SaveAtomic mode the first failure rolls back the whole batch and is returned. In SavePartial
+// mode a failing group of rows is retried one article at a time behind savepoints, every other
+// article is committed, and the rejected ones are reported in a *BatchSaveError.
+func (store *sqlStore) SaveArticles(ctx context.Context, articles []*models.Article, mode SaveMode) ([]SavedArticle, error) {
+	batchErr := &BatchSaveError{Total: len(articles)}
+	pending := make([]pendingArticle, 0, len(articles))
+	for i, article := range articles {
+		if err := validateArticle(article); err != nil {
+			if mode == SaveAtomic {
+				return nil, newArticleError(i, article, err)
+			}
+			batchErr.add(i, article, err)
+			continue
+		}
+		pending = append(pending, pendingArticle{index: i, article: article})
+	}
+	if len(pending) == 0 {
+		return nil, batchErr.errOrNil()
+	}
+
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+	if store.dialect.saveLock != "" {
+		if _, err := tx.ExecContext(ctx, store.dialect.saveLock); err != nil {
+			return nil, fmt.Errorf("failed to lock articles: %w", err)
+		}
+	}
+
+	var saved []SavedArticle
+	for _, chunk := range chunkArticles(pending, maxBatchRows) {
+		if mode == SaveAtomic {
+			chunkSaved, err := store.insertChunk(ctx, tx, chunk)
+			if err != nil {
+				return nil, fmt.Errorf("failed to save articles: %w", err)
+			}
+			saved = append(saved, chunkSaved...)
+			continue
+		}
+		chunkSaved, err := store.insertChunkPartial(ctx, tx, chunk, batchErr)
+		if err != nil {
+			return nil, err
+		}
+		saved = append(saved, chunkSaved...)
+	}
+	if err := store.enqueuePlanned(ctx, tx, saved); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit articles: %w", err)
+	}
+	return saved, batchErr.errOrNil()
+}
+
+// insertChunk writes chunk and records a snapshot of every article in it, returning the articles
+// as stored. Keyed articles are upserted with one multi-row INSERT after reading which of their
+// keys exist; articles without a key are inserted one by one to learn their IDs.
+func (store *sqlStore) insertChunk(ctx context.Context, tx *sql.Tx, chunk []pendingArticle) ([]SavedArticle, error) {
+	var keyed []pendingArticle
+	var keys, unkeyedIDs []interface{}
+	for _, p := range chunk {
+		if key := articleKey(p.article); key.Valid {
+			keyed = append(keyed, p)
+			keys = append(keys, key.String)
+		}
+	}
+
+	existing := make(map[string]bool)
+	if len(keyed) > 0 {
+		rows, err := tx.QueryContext(ctx, store.dialect.rebind(
+			"SELECT article_key FROM articles WHERE article_key IN "+inList(len(keys))+store.dialect.lockingRead), keys...)
+		if err != nil {
+			return nil, err
+		}
+		for rows.Next() {
+			var key string
+			if err := rows.Scan(&key); err != nil {
+				rows.Close()
+				return nil, err
+			}
+			existing[key] = true
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return nil, err
+		}
+
+		args := make([]interface{}, 0, len(keyed)*len(insertColumns))
+		for _, p := range keyed {
+			args = append(args, articleValues(p.article)...)
+		}
+		if _, err := tx.ExecContext(ctx, store.dialect.buildInsertQuery(len(keyed)), args...); err != nil {
+			return nil, err
+		}
+	}
+	insertedIDs := make(map[int]int)
+	for i, p := range chunk {
+		if articleKey(p.article).Valid {
+			continue
+		}
+		id, err := store.insertUnkeyed(ctx, tx, p.article)
+		if err != nil {
+			return nil, err
+		}
+		insertedIDs[i] = id
+		unkeyedIDs = append(unkeyedIDs, id)
+	}
+
+	// Read the articles back as stored, with their IDs and first sightings.
+	var conditions []string
+	if len(keys) > 0 {
+		conditions = append(conditions, "a.article_key IN "+inList(len(keys)))
+	}
+	if len(unkeyedIDs) > 0 {
+		conditions = append(conditions, "a.id IN "+inList(len(unkeyedIDs)))
+	}
+	rows, err := tx.QueryContext(ctx, store.dialect.rebind(`
+		SELECT `+selectColumns(ArticleQuery{})+`, a.article_key
+		FROM articles a
+		WHERE `+strings.Join(conditions, " OR ")+`;
+	`), append(keys, unkeyedIDs...)...)
+	if err != nil {
+		return nil, err
+	}
+	byKey := make(map[string]*models.Article)
+	byID := make(map[int]*models.Article)
+	for rows.Next() {
+		var key sql.NullString
+		article, err := scanArticle(rows, &key)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		byID[article.ID] = article
+		if key.Valid {
+			byKey[key.String] = article
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	saved := make([]SavedArticle, len(chunk))
+	ids := make([]interface{}, len(chunk))
+	for i, p := range chunk {
+		key := articleKey(p.article)
+		stored, created := byID[insertedIDs[i]], true
+		if key.Valid {
+			stored, created = byKey[key.String], !existing[key.String]
+		}
+		if stored == nil {
+			return nil, fmt.Errorf("article %d vanished while being saved", p.index)
+		}
+		saved[i] = SavedArticle{Index: p.index, Article: stored, Created: created}
+		ids[i] = stored.ID
+	}
+	_, err = tx.ExecContext(ctx, store.dialect.rebind(`
+		INSERT INTO article_snapshots (article_id, article_rank, upvotes, comment_count, captured_at)
+		SELECT id, article_rank, upvotes, comment_count, updated_at
+		FROM articles
+		WHERE id IN `+inList(len(ids))+`;
+	`), ids...)
+	if err != nil {
+		return nil, err
+	}
+	return saved, nil
+}
+
+// insertUnkeyed inserts an article without a key, which is never upserted, and returns its ID.
+func (store *sqlStore) insertUnkeyed(ctx context.Context, tx *sql.Tx, article *models.Article) (int, error) {
+	query := "INSERT INTO articles (" + strings.Join(insertColumns, ", ") + ") VALUES " + inList(len(insertColumns))
+	if store.dialect.returningIDs {
+		var id int
+		err := tx.QueryRowContext(ctx, store.dialect.rebind(query+" RETURNING id"), articleValues(article)...).Scan(&id)
+		return id, err
+	}
+	result, err := tx.ExecContext(ctx, store.dialect.rebind(query), articleValues(article)...)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	return int(id), err
+}
+
+// insertChunkPartial writes chunk inside a savepoint and returns the articles it stored. If the
+// multi-row INSERT fails, it is rolled back and the articles are retried one by one so that only
+// the offending ones are rejected. The returned error aborts the batch: it is set when the context
+// ends or a savepoint fails.
+func (store *sqlStore) insertChunkPartial(ctx context.Context, tx *sql.Tx, chunk []pendingArticle, batchErr *BatchSaveError) ([]SavedArticle, error) {
+	var saved []SavedArticle
+	insert := func(chunk []pendingArticle) func() error {
+		return func() error {
+			chunkSaved, err := store.insertChunk(ctx, tx, chunk)
+			saved = append(saved, chunkSaved...)
+			return err
+		}
+	}
+	err := withSavepoint(ctx, tx, insert(chunk))
+	if err == nil {
+		return saved, nil
+	}
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return nil, ctxErr
+	}
+	if errors.Is(err, errSavepoint) {
+		return nil, err
+	}
+
+	for _, p := range chunk {
+		err := withSavepoint(ctx, tx, insert([]pendingArticle{p}))
+		if err == nil {
+			continue
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		if errors.Is(err, errSavepoint) {
+			return nil, err
+		}
+		batchErr.add(p.index, p.article, err)
+	}
+	return saved, nil
+}
+
+// errSavepoint marks failures to manage a savepoint, after which the transaction cannot continue.
+var errSavepoint = errors.New("savepoint failed")
+
+// withSavepoint runs fn inside a savepoint of tx and rolls back to it if fn fails,
+// leaving the rest of the transaction intact.
+func withSavepoint(ctx context.Context, tx *sql.Tx, fn func() error) error {
+	if _, err := tx.ExecContext(ctx, "SAVEPOINT save_articles"); err != nil {
+		return fmt.Errorf("%w: %v", errSavepoint, err)
+	}
+	if err := fn(); err != nil {
+		if _, rbErr := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT save_articles"); rbErr != nil {
+			return fmt.Errorf("%w: %v", errSavepoint, rbErr)
+		}
+		return err
+	}
+	if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT save_articles"); err != nil {
+		return fmt.Errorf("%w: %v", errSavepoint, err)
+	}
+	return nil
+}
+
+// History returns the snapshots of the article with the given ID in chronological order,
+// or ErrArticleNotFound if no such article exists.
+func (store *sqlStore) History(ctx context.Context, articleID int) ([]*models.ArticleSnapshot, error) {
+	var count int
+	if err := store.db.QueryRowContext(ctx, store.dialect.rebind("SELECT COUNT(*) FROM articles WHERE id = ?;"), articleID).Scan(&count); err != nil {
+		return nil, fmt.Errorf("failed to look up article: %w", err)
+	}
+	if count == 0 {
+		return nil, ErrArticleNotFound
+	}
+
+	rows, err := store.db.QueryContext(ctx, store.dialect.rebind(`
+		SELECT article_rank, upvotes, comment_count, captured_at
+		FROM article_snapshots
+		WHERE article_id = ?
+		ORDER BY captured_at ASC, id ASC;
+	`), articleID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	snapshots := []*models.ArticleSnapshot{}
+	for rows.Next() {
+		var snapshot models.ArticleSnapshot
+		if err := rows.Scan(&snapshot.ArticleRank, &snapshot.Upvotes, &snapshot.CommentCount, &snapshot.CapturedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan snapshot: %w", err)
+		}
+		snapshots = append(snapshots, &snapshot)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+	return snapshots, nil
+}
+
+// BackfillSnapshots records a snapshot of every article whose latest state has none, returning
+// the number recorded. Saves snapshot every article they write, so this only catches articles
+// inserted or updated around the store, such as by scrapers writing to the database directly.
+func (store *sqlStore) BackfillSnapshots(ctx context.Context) (int, error) {
+	migrator, err := store.Migrator()
+	if err != nil {
+		return 0, err
+	}
+	version, err := migrator.Version(ctx)
+	if err != nil || version < snapshotMigration {
+		return 0, err
+	}
+
+	result, err := store.db.ExecContext(ctx, `
+		INSERT INTO article_snapshots (article_id, article_rank, upvotes, comment_count, captured_at)
+		SELECT a.id, a.article_rank, a.upvotes, a.comment_count, a.updated_at
+		FROM articles a
+		WHERE NOT EXISTS (
+			SELECT 1 FROM article_snapshots s WHERE s.article_id = a.id AND s.captured_at >= a.updated_at
+		);
+	`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to record snapshots: %w", err)
+	}
+	recorded, err := result.RowsAffected()
+	return int(recorded), err
+}
+
+// Query retrieves the page of articles matching q.
+func (store *sqlStore) Query(ctx context.Context, q ArticleQuery) (*ArticlePage, error) {
+	q, err := q.normalize()
+	if err != nil {
+		return nil, err
+	}
+	query, args := buildSelectQuery(store.dialect, q)
+	rows, err := store.db.QueryContext(ctx, store.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	// Hot scores are taken from the database, so that cursors compare equal to what it computes.
+	var articles []*models.Article
+	var hotScores []interface{}
+	for rows.Next() {
+		var extra []interface{}
+		var hotScore float64
+		if q.Sort == SortHot {
+			extra = append(extra, &hotScore)
+		}
+		article, err := scanArticle(rows, extra...)
+		if err != nil {
+			return nil, err
+		}
+		articles = append(articles, article)
+		if q.Sort == SortHot {
+			hotScores = append(hotScores, hotScore)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+	return newArticlePage(q, articles, hotScores), nil
+}
+
+// Count returns the number of articles matching the filters and thresholds of q,
+// ignoring its cursor and pagination.
+func (store *sqlStore) Count(ctx context.Context, q ArticleQuery) (int, error) {
+	q, err := q.normalize()
+	if err != nil {
+		return 0, err
+	}
+	query, args := buildCountQuery(store.dialect, q)
+	var count int
+	if err := store.db.QueryRowContext(ctx, store.dialect.rebind(query), args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count articles: %w", err)
+	}
+	return count, nil
+}
+
+// Search retrieves the articles matching a full-text search, with their relevance and summary snippets.
+func (store *sqlStore) Search(ctx context.Context, q SearchQuery) ([]*models.SearchResult, error) {
+	q, terms, err := q.normalize()
+	if err != nil {
+		return nil, err
+	}
+	query, args := buildSearchQuery(store.dialect, q, terms)
+	rows, err := store.db.QueryContext(ctx, store.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	results := []*models.SearchResult{}
+	for rows.Next() {
+		var relevance float64
+		article, err := scanArticle(rows, &relevance)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, newSearchResult(article, relevance, terms))
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+	return results, nil
+}
+
+// Article returns the article with the given ID, or ErrArticleNotFound if there is none.
+func (store *sqlStore) Article(ctx context.Context, id int) (*models.Article, error) {
+	return store.lookupArticle(ctx, "id", id)
+}
+
+// ArticleByHNID returns the article with the given Hacker News ID, or ErrArticleNotFound if there is none.
+func (store *sqlStore) ArticleByHNID(ctx context.Context, hnID int) (*models.Article, error) {
+	return store.lookupArticle(ctx, "hn_id", hnID)
+}
+
+// lookupArticle returns the most recently stored article whose column equals value.
+// Unlike Query it applies no visibility filters, so flagged or unsummarized articles are found too.
+func (store *sqlStore) lookupArticle(ctx context.Context, column string, value interface{}) (*models.Article, error) {
+	row := store.db.QueryRowContext(ctx, store.dialect.rebind(`
+		SELECT `+selectColumns(ArticleQuery{})+`
+		FROM articles a
+		WHERE a.`+column+` = ?
+		ORDER BY a.id DESC
+		LIMIT 1;
+	`), value)
+	article, err := scanArticle(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrArticleNotFound
+	}
+	return article, err
+}
+
+// rowScanner is satisfied by *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanArticle reads one article selected with selectColumns, followed by any extra columns.
+func scanArticle(row rowScanner, extra ...interface{}) (*models.Article, error) {
+	var article models.Article
+	err := row.Scan(append([]interface{}{
+		&article.ID,
+		&article.HNID,
+		&article.Title,
+		&article.Link,
+		&article.ArticleRank,
+		&article.Content,
+		&article.Summary,
+		&article.Source,
+		&article.Upvotes,
+		&article.CommentCount,
+		&article.CommentLink,
+		&article.Flagged,
+		&article.Dead,
+		&article.Dupe,
+		&article.CommitHash,
+		&article.ModelName,
+		&article.CreatedAt,
+		&article.UpdatedAt,
+		&article.Domain,
+	}, extra...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan article: %w", err)
+	}
+	return &article, nil
+}
@@ -1,401 +1,98 @@
-// Package store defines an interface and an implementation for article storage and retrieval.
+// Package store defines an interface and implementations for article storage and retrieval.
 package store
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
 
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/k-zehnder/gophersignal/backend/internal/migrations"
 	"github.com/k-zehnder/gophersignal/backend/internal/models"
 )
 
-// Store defines methods for article storage and retrieval.
-type Store interface {
-	SaveArticles(articles []*models.Article) error
-	GetArticles(limit, offset int) ([]*models.Article, error)
-	GetFilteredArticles(flagged, dead, dupe *bool, limit, offset int) ([]*models.Article, error)
-	GetArticlesWithThresholds(limit, offset, minUpvotes, minComments int) ([]*models.Article, error)
-	GetArticlesWithThresholdsAndFilters(limit, offset, minUpvotes, minComments int, flagged, dead, dupe *bool) ([]*models.Article, error)
-}
-
-// MySQLStore implements Store using a MySQL database.
-type MySQLStore struct {
-	db *sql.DB
-}
-
-// NewMySQLStore creates a new MySQLStore.
-func NewMySQLStore(dataSourceName string) (*MySQLStore, error) {
-	db, err := sql.Open("mysql", dataSourceName)
-	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+// Open connects to the store selected by the scheme of dataSourceName.
+// DSNs of the form "sqlite://<path>" open an embedded SQLite database,
+// "postgres://" and "postgresql://" URLs open PostgreSQL, and anything else
+// is treated as a MySQL DSN.
+func Open(dataSourceName string) (Store, error) {
+	if path, ok := strings.CutPrefix(dataSourceName, SQLiteScheme); ok {
+		return NewSQLiteStore(path)
 	}
-	if err = db.Ping(); err != nil {
-		return nil, fmt.Errorf("failed to ping database: %w", err)
+	if isPostgresDSN(dataSourceName) {
+		return NewPostgresStore(dataSourceName)
 	}
-	return &MySQLStore{db: db}, nil
+	return NewMySQLStore(dataSourceName)
 }
 
-// SaveArticles inserts articles into the database.
-func (store *MySQLStore) SaveArticles(articles []*models.Article) error {
-	stmt, err := store.db.Prepare(`
-        INSERT INTO articles (
-          hn_id,
-          title,
-          link,
-          article_rank,
-          content,
-          summary,
-          source,
-          upvotes,
-          comment_count,
-          comment_link,
-          flagged,
-          dead,
-          dupe,
-          commit_hash,
-          model_name,
-          created_at,
-          updated_at
-        )
-        VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?);
-	`)
-	if err != nil {
-		return fmt.Errorf("failed to prepare statement: %w", err)
-	}
-	defer stmt.Close()
-
-	for _, article := range articles {
-		_, execErr := stmt.Exec(
-			article.HNID,
-			article.Title,
-			article.Link,
-			article.ArticleRank,
-			article.Content,
-			article.Summary,
-			article.Source,
-			article.Upvotes,
-			article.CommentCount,
-			article.CommentLink,
-			article.Flagged,
-			article.Dead,
-			article.Dupe,
-			article.CommitHash,
-			article.ModelName,
-			article.CreatedAt,
-			article.UpdatedAt,
-		)
-		if execErr != nil {
-			fmt.Printf("Failed for article '%s': %v\n", article.Title, execErr)
-			continue
-		}
-	}
-	return nil
+// Store defines methods for article storage and retrieval.
+// Every method honors cancellation and deadlines of the supplied context.
+type Store interface {
+	// SaveArticles upserts articles. With SaveAtomic either every article is stored or none is;
+	// with SavePartial the valid articles are stored and the rest are reported in a *BatchSaveError.
+	// The stored articles are returned in batch order, also alongside a *BatchSaveError.
+	SaveArticles(ctx context.Context, articles []*models.Article, mode SaveMode) ([]SavedArticle, error)
+	// Query retrieves one page of the articles matching q.
+	Query(ctx context.Context, q ArticleQuery) (*ArticlePage, error)
+	// Count returns the number of articles matching q across all pages.
+	Count(ctx context.Context, q ArticleQuery) (int, error)
+	// Search retrieves the articles matching a full-text search, best match first by default.
+	Search(ctx context.Context, q SearchQuery) ([]*models.SearchResult, error)
+	// Article returns the article with the given ID, or ErrArticleNotFound.
+	Article(ctx context.Context, id int) (*models.Article, error)
+	// ArticleByHNID returns the article with the given Hacker News ID, or ErrArticleNotFound.
+	ArticleByHNID(ctx context.Context, hnID int) (*models.Article, error)
+	// History returns the rank and engagement snapshots of an article in chronological order.
+	History(ctx context.Context, articleID int) ([]*models.ArticleSnapshot, error)
+	// Domains summarizes the articles matching q's filters per linked domain, most articles first.
+	Domains(ctx context.Context, q ArticleQuery) ([]*models.DomainStats, error)
+	// Facets counts the articles matching q's filters per summarizing model, build, source and
+	// flag state, lifting the filter on each facet while counting it.
+	Facets(ctx context.Context, q ArticleQuery) (*models.Facets, error)
 }
 
-// GetArticles retrieves deduplicated articles.
-func (store *MySQLStore) GetArticles(limit, offset int) ([]*models.Article, error) {
-	query := `
-		SELECT a.id, a.hn_id, a.title, a.link, a.article_rank, a.content, a.summary, a.source,
-		       a.upvotes, a.comment_count, a.comment_link, a.flagged,
-		       a.dead, a.dupe, a.commit_hash, a.model_name, a.created_at, a.updated_at
-		FROM articles a
-		INNER JOIN (
-			SELECT title, MAX(id) AS max_id
-			FROM articles
-			WHERE summary IS NOT NULL 
-			  AND TRIM(summary) != ''
-			  AND summary NOT LIKE 'No summary available%'
-			  AND flagged = FALSE
-			  AND dead = FALSE
-			  AND dupe = FALSE
-			GROUP BY title
-		) b ON a.title = b.title AND a.id = b.max_id
-		ORDER BY a.id DESC
-		LIMIT ? OFFSET ?;
-	`
-	rows, err := store.db.Query(query, limit, offset)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
-	}
-	defer rows.Close()
+// ErrArticleNotFound is returned by lookups of articles that do not exist.
+var ErrArticleNotFound = errors.New("article not found")
 
-	var articles []*models.Article
-	for rows.Next() {
-		var article models.Article
-		if err := rows.Scan(
-			&article.ID,
-			&article.HNID,
-			&article.Title,
-			&article.Link,
-			&article.ArticleRank,
-			&article.Content,
-			&article.Summary,
-			&article.Source,
-			&article.Upvotes,
-			&article.CommentCount,
-			&article.CommentLink,
-			&article.Flagged,
-			&article.Dead,
-			&article.Dupe,
-			&article.CommitHash,
-			&article.ModelName,
-			&article.CreatedAt,
-			&article.UpdatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan article: %w", err)
-		}
-		articles = append(articles, &article)
-	}
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("iteration error: %w", err)
-	}
-	return articles, nil
+// Migratable is implemented by stores whose schema is managed by the migrations package.
+type Migratable interface {
+	Migrator() (*migrations.Migrator, error)
 }
 
-// GetFilteredArticles retrieves articles with optional filters.
-func (store *MySQLStore) GetFilteredArticles(flagged, dead, dupe *bool, limit, offset int) ([]*models.Article, error) {
-	innerQuery := `
-		SELECT title, MAX(id) AS max_id
-		FROM articles
-		WHERE 1=1
-	`
-	var conditions []string
-	var args []interface{}
-
-	if flagged != nil {
-		conditions = append(conditions, "flagged = ?")
-		args = append(args, boolToInt(*flagged))
-	} else {
-		conditions = append(conditions, "flagged = FALSE")
-	}
-	if dead != nil {
-		conditions = append(conditions, "dead = ?")
-		args = append(args, boolToInt(*dead))
-	} else {
-		conditions = append(conditions, "dead = FALSE")
-	}
-	if dupe != nil {
-		conditions = append(conditions, "dupe = ?")
-		args = append(args, boolToInt(*dupe))
-	} else {
-		conditions = append(conditions, "dupe = FALSE")
-	}
-
-	innerQuery += " AND " + strings.Join(conditions, " AND ")
-	innerQuery += " GROUP BY title"
-
-	query := `
-		SELECT a.id, a.hn_id, a.title, a.link, a.article_rank, a.content, a.summary, a.source,
-		       a.upvotes, a.comment_count, a.comment_link, a.flagged,
-		       a.dead, a.dupe, a.commit_hash, a.model_name, a.created_at, a.updated_at
-		FROM articles a
-		INNER JOIN (
-	` + innerQuery + `
-		) b ON a.title = b.title AND a.id = b.max_id
-		ORDER BY a.id DESC
-		LIMIT ? OFFSET ?;
-	`
-	args = append(args, limit, offset)
-	rows, err := store.db.Query(query, args...)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute filtered query: %w", err)
-	}
-	defer rows.Close()
-
-	var articles []*models.Article
-	for rows.Next() {
-		var article models.Article
-		if err := rows.Scan(
-			&article.ID,
-			&article.HNID,
-			&article.Title,
-			&article.Link,
-			&article.ArticleRank,
-			&article.Content,
-			&article.Summary,
-			&article.Source,
-			&article.Upvotes,
-			&article.CommentCount,
-			&article.CommentLink,
-			&article.Flagged,
-			&article.Dead,
-			&article.Dupe,
-			&article.CommitHash,
-			&article.ModelName,
-			&article.CreatedAt,
-			&article.UpdatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan article: %w", err)
-		}
-		articles = append(articles, &article)
-	}
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("iteration error: %w", err)
-	}
-	return articles, nil
+// ArticleKeyBackfiller is implemented by stores that key articles saved without an article key,
+// such as those known only by their link. Migrations cannot normalize links, so this runs after migrating.
+type ArticleKeyBackfiller interface {
+	BackfillArticleKeys(ctx context.Context) (int, error)
 }
 
-// GetArticlesWithThresholds retrieves articles using provided minimum upvote and comment thresholds.
-func (store *MySQLStore) GetArticlesWithThresholds(limit, offset, minUpvotes, minComments int) ([]*models.Article, error) {
-	query := `
-		SELECT a.id, a.hn_id, a.title, a.link, a.article_rank, a.content, a.summary, a.source,
-		       a.upvotes, a.comment_count, a.comment_link, a.flagged,
-		       a.dead, a.dupe, a.commit_hash, a.model_name, a.created_at, a.updated_at
-		FROM articles a
-		INNER JOIN (
-			SELECT title, MAX(id) AS max_id
-			FROM articles
-			WHERE summary IS NOT NULL 
-			  AND TRIM(summary) != ''
-			  AND summary NOT LIKE 'No summary available%'
-			  AND flagged = FALSE
-			  AND dead = FALSE
-			  AND dupe = FALSE
-			  AND upvotes >= ?
-			  AND comment_count >= ?
-			GROUP BY title
-		) b ON a.title = b.title AND a.id = b.max_id
-		ORDER BY a.id DESC
-		LIMIT ? OFFSET ?;
-	`
-	rows, err := store.db.Query(query, minUpvotes, minComments, limit, offset)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute query: %w", err)
-	}
-	defer rows.Close()
-
-	var articles []*models.Article
-	for rows.Next() {
-		var article models.Article
-		if err := rows.Scan(
-			&article.ID,
-			&article.HNID,
-			&article.Title,
-			&article.Link,
-			&article.ArticleRank,
-			&article.Content,
-			&article.Summary,
-			&article.Source,
-			&article.Upvotes,
-			&article.CommentCount,
-			&article.CommentLink,
-			&article.Flagged,
-			&article.Dead,
-			&article.Dupe,
-			&article.CommitHash,
-			&article.ModelName,
-			&article.CreatedAt,
-			&article.UpdatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan article: %w", err)
-		}
-		articles = append(articles, &article)
-	}
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("iteration error: %w", err)
-	}
-	return articles, nil
+// SnapshotBackfiller is implemented by stores that snapshot articles written without a snapshot,
+// which happens only when articles are written around the store.
+type SnapshotBackfiller interface {
+	BackfillSnapshots(ctx context.Context) (int, error)
 }
 
-// GetArticlesWithThresholdsAndFilters retrieves articles that satisfy both threshold
-// conditions (minUpvotes and minComments) and additional boolean filters (flagged, dead, dupe).
-func (store *MySQLStore) GetArticlesWithThresholdsAndFilters(
-	limit, offset, minUpvotes, minComments int,
-	flagged, dead, dupe *bool,
-) ([]*models.Article, error) {
-	innerQuery := `
-		SELECT title, MAX(id) AS max_id
-		FROM articles
-		WHERE summary IS NOT NULL 
-		  AND TRIM(summary) != ''
-		  AND summary NOT LIKE 'No summary available%'
-	`
-	if flagged != nil {
-		innerQuery += " AND flagged = ?"
-	} else {
-		innerQuery += " AND flagged = FALSE"
-	}
-	if dead != nil {
-		innerQuery += " AND dead = ?"
-	} else {
-		innerQuery += " AND dead = FALSE"
-	}
-	if dupe != nil {
-		innerQuery += " AND dupe = ?"
-	} else {
-		innerQuery += " AND dupe = FALSE"
-	}
-
-	innerQuery += " AND upvotes >= ? AND comment_count >= ? GROUP BY title"
-
-	fullQuery := `
-		SELECT a.id, a.hn_id, a.title, a.link, a.article_rank, a.content, a.summary, a.source,
-		       a.upvotes, a.comment_count, a.comment_link, a.flagged,
-		       a.dead, a.dupe, a.commit_hash, a.model_name, a.created_at, a.updated_at
-		FROM articles a
-		INNER JOIN (
-	` + innerQuery + `
-		) b ON a.title = b.title AND a.id = b.max_id
-		ORDER BY a.id DESC
-		LIMIT ? OFFSET ?;
-	`
+// DomainBackfiller is implemented by stores that derive the domains of articles saved before
+// domains were recorded. Migrations cannot compute domains, so this runs after migrating.
+type DomainBackfiller interface {
+	BackfillDomains(ctx context.Context) (int, error)
+}
 
-	var args []interface{}
-	if flagged != nil {
-		args = append(args, boolToInt(*flagged))
-	}
-	if dead != nil {
-		args = append(args, boolToInt(*dead))
-	}
-	if dupe != nil {
-		args = append(args, boolToInt(*dupe))
-	}
-	args = append(args, minUpvotes, minComments, limit, offset)
+// MySQLStore implements Store using a MySQL database.
+// Its schema is managed with the "migrate" subcommand.
+type MySQLStore struct {
+	sqlStore
+}
 
-	rows, err := store.db.Query(fullQuery, args...)
+// NewMySQLStore creates a new MySQLStore.
+func NewMySQLStore(dataSourceName string) (*MySQLStore, error) {
+	db, err := sql.Open("mysql", dataSourceName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute combined query: %w", err)
-	}
-	defer rows.Close()
-
-	var articles []*models.Article
-	for rows.Next() {
-		var article models.Article
-		if err := rows.Scan(
-			&article.ID,
-			&article.HNID,
-			&article.Title,
-			&article.Link,
-			&article.ArticleRank,
-			&article.Content,
-			&article.Summary,
-			&article.Source,
-			&article.Upvotes,
-			&article.CommentCount,
-			&article.CommentLink,
-			&article.Flagged,
-			&article.Dead,
-			&article.Dupe,
-			&article.CommitHash,
-			&article.ModelName,
-			&article.CreatedAt,
-			&article.UpdatedAt,
-		); err != nil {
-			return nil, fmt.Errorf("failed to scan article: %w", err)
-		}
-		articles = append(articles, &article)
-	}
-	if err = rows.Err(); err != nil {
-		return nil, fmt.Errorf("iteration error: %w", err)
+		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
-	return articles, nil
-}
-
-// Convert boolean to integer (1 or 0).
-func boolToInt(b bool) int {
-	if b {
-		return 1
+	if err = db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
-	return 0
+	return &MySQLStore{sqlStore{db: db, dialect: mysqlDialect}}, nil
 }
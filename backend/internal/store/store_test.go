@@ -0,0 +1,1028 @@
+// Package store provides an interface and implementations for article storage and retrieval.
+// This file contains the behavioral tests shared by every database-backed Store implementation.
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// storeFactory returns a new, empty Store for a single test.
+type storeFactory func(t *testing.T) Store
+
+// newTestArticle builds a visible article with the given title and engagement counts.
+func newTestArticle(hnID int, title string, upvotes, comments int64) *models.Article {
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	return models.NewArticle(
+		0, hnID, title, fmt.Sprintf("https://example.com/%d", hnID), 1,
+		"Content of "+title, "Summary of "+title, "Hacker News",
+		"abc1234", "test-model", now, now,
+		upvotes, comments, fmt.Sprintf("https://news.ycombinator.com/item?id=%d", hnID),
+		false, false, false,
+	)
+}
+
+// saveAll stores articles one batch at a time so that later articles receive higher IDs.
+func saveAll(t *testing.T, s Store, articles ...*models.Article) {
+	t.Helper()
+	for _, article := range articles {
+		if _, err := s.SaveArticles(context.Background(), []*models.Article{article}, SaveAtomic); err != nil {
+			t.Fatalf("SaveArticles failed: %v", err)
+		}
+	}
+}
+
+// articlesOf returns the articles of a page, passing errors through.
+func articlesOf(page *ArticlePage, err error) ([]*models.Article, error) {
+	if err != nil {
+		return nil, err
+	}
+	return page.Articles, nil
+}
+
+// titles returns the titles of articles in order.
+func titles(articles []*models.Article) []string {
+	result := make([]string, len(articles))
+	for i, article := range articles {
+		result[i] = article.Title
+	}
+	return result
+}
+
+// expectTitles fails the test unless articles carry exactly the expected titles in order.
+func expectTitles(t *testing.T, articles []*models.Article, expected ...string) {
+	t.Helper()
+	got := titles(articles)
+	if len(got) != len(expected) {
+		t.Fatalf("Expected titles %v, got %v", expected, got)
+	}
+	for i := range expected {
+		if got[i] != expected[i] {
+			t.Fatalf("Expected titles %v, got %v", expected, got)
+		}
+	}
+}
+
+// domainSummary renders domain statistics as "domain:count:average" strings.
+func domainSummary(domains []*models.DomainStats) []string {
+	result := make([]string, len(domains))
+	for i, stats := range domains {
+		result[i] = fmt.Sprintf("%s:%d:%g", stats.Domain, stats.ArticleCount, stats.AverageUpvotes)
+	}
+	return result
+}
+
+// runStoreBehaviorTests exercises the query semantics every database-backed Store must share.
+func runStoreBehaviorTests(t *testing.T, newStore storeFactory) {
+	ctx := context.Background()
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		s := newStore(t)
+		article := newTestArticle(101, "Round Trip", 42, 7)
+		saveAll(t, s, article)
+
+		result, err := articlesOf(s.Query(ctx, ArticleQuery{}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Round Trip")
+		got := result[0]
+		if got.ID == 0 || got.HNID != 101 || got.Link != article.Link || got.Summary.String != article.Summary.String {
+			t.Errorf("Unexpected article: %+v", got)
+		}
+		if got.Upvotes.Int64 != 42 || got.CommentCount.Int64 != 7 || got.CommentLink.String != article.CommentLink.String {
+			t.Errorf("Unexpected counts: %+v", got)
+		}
+		if got.CommitHash != "abc1234" || got.ModelName != "test-model" || !got.CreatedAt.Equal(article.CreatedAt) {
+			t.Errorf("Unexpected metadata: %+v", got)
+		}
+	})
+
+	t.Run("NewestFirst", func(t *testing.T) {
+		s := newStore(t)
+		saveAll(t, s, newTestArticle(1, "First", 1, 1), newTestArticle(2, "Second", 1, 1), newTestArticle(3, "Third", 1, 1))
+
+		result, err := articlesOf(s.Query(ctx, ArticleQuery{}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Third", "Second", "First")
+
+		result, err = articlesOf(s.Query(ctx, ArticleQuery{Sort: SortOldest}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "First", "Second", "Third")
+	})
+
+	t.Run("UpsertsByHNID", func(t *testing.T) {
+		s := newStore(t)
+		first := newTestArticle(1, "Repeated", 10, 1)
+		again := newTestArticle(1, "Repeated", 20, 2)
+		again.ArticleRank = 5
+		again.Flagged = true
+		again.CreatedAt = first.CreatedAt.Add(time.Hour)
+		again.UpdatedAt = again.CreatedAt
+		saveAll(t, s, first, newTestArticle(2, "Unique", 1, 1), again)
+
+		flagged := true
+		result, err := articlesOf(s.Query(ctx, ArticleQuery{Flagged: &flagged}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Repeated")
+		got := result[0]
+		if got.Upvotes.Int64 != 20 || got.CommentCount.Int64 != 2 || got.ArticleRank != 5 {
+			t.Errorf("Expected counts and rank from the latest sighting, got %+v", got)
+		}
+		if !got.CreatedAt.Equal(first.CreatedAt) || !got.UpdatedAt.Equal(again.UpdatedAt) {
+			t.Errorf("Expected created_at %v and updated_at %v, got %v and %v",
+				first.CreatedAt, again.UpdatedAt, got.CreatedAt, got.UpdatedAt)
+		}
+
+		result, err = articlesOf(s.Query(ctx, ArticleQuery{Sort: SortOldest}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Unique")
+		if got.ID >= result[0].ID {
+			t.Errorf("Expected the upserted article to keep its original ID, got %d after %d", got.ID, result[0].ID)
+		}
+	})
+
+	t.Run("UpsertFillsSummary", func(t *testing.T) {
+		s := newStore(t)
+		first := newTestArticle(1, "Pending", 10, 1)
+		first.Summary = models.NullableString{}
+		first.Content, first.CommitHash, first.ModelName = "", "", ""
+		again := newTestArticle(1, "Pending", 20, 2)
+		again.CommitHash, again.ModelName = "def5678", "llama3:8b"
+		saveAll(t, s, first)
+
+		result, err := articlesOf(s.Query(ctx, ArticleQuery{}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result)
+
+		saveAll(t, s, again)
+		result, err = articlesOf(s.Query(ctx, ArticleQuery{}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Pending")
+		got := result[0]
+		if got.Summary.String != "Summary of Pending" || got.Content != "Content of Pending" ||
+			got.CommitHash != "def5678" || got.ModelName != "llama3:8b" {
+			t.Errorf("Expected the summary and model metadata of the second save, got %+v", got)
+		}
+
+		// A later save without a summary keeps the stored one.
+		saveAll(t, s, first)
+		result, err = articlesOf(s.Query(ctx, ArticleQuery{}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Pending")
+		if got := result[0]; got.Summary.String != "Summary of Pending" || got.ModelName != "llama3:8b" {
+			t.Errorf("Expected the stored summary and model to be kept, got %+v", got)
+		}
+	})
+
+	t.Run("UpsertsByLinkWithoutHNID", func(t *testing.T) {
+		s := newStore(t)
+		first := newTestArticle(0, "Linked", 10, 1)
+		first.Link = "https://www.example.com/post/"
+		again := newTestArticle(0, "Linked", 30, 3)
+		again.Link = "https://example.com/post?utm_source=hn"
+		saveAll(t, s, first, again)
+
+		result, err := articlesOf(s.Query(ctx, ArticleQuery{}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Linked")
+		if result[0].Upvotes.Int64 != 30 {
+			t.Errorf("Expected 30 upvotes, got %d", result[0].Upvotes.Int64)
+		}
+	})
+
+	t.Run("BatchAtomic", func(t *testing.T) {
+		s := newStore(t)
+		saveAll(t, s, newTestArticle(1, "Existing", 1, 1))
+		batch := []*models.Article{newTestArticle(2, "Valid", 1, 1), newTestArticle(3, "", 1, 1)}
+
+		_, err := s.SaveArticles(ctx, batch, SaveAtomic)
+		var articleErr ArticleError
+		if !errors.As(err, &articleErr) || articleErr.Index != 1 || articleErr.HNID != 3 {
+			t.Fatalf("Expected an error for article 1, got %v", err)
+		}
+		result, err := articlesOf(s.Query(ctx, ArticleQuery{}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Existing")
+	})
+
+	t.Run("BatchPartial", func(t *testing.T) {
+		s := newStore(t)
+		batch := []*models.Article{
+			newTestArticle(1, "First", 1, 1),
+			newTestArticle(2, "", 1, 1),
+			newTestArticle(3, "Third", 1, 1),
+			newTestArticle(4, strings.Repeat("x", maxTitleLength+1), 1, 1),
+		}
+
+		_, err := s.SaveArticles(ctx, batch, SavePartial)
+		var batchErr *BatchSaveError
+		if !errors.As(err, &batchErr) {
+			t.Fatalf("Expected *BatchSaveError, got %v", err)
+		}
+		if batchErr.Total != 4 || len(batchErr.Failures) != 2 ||
+			batchErr.Failures[0].Index != 1 || batchErr.Failures[0].HNID != 2 ||
+			batchErr.Failures[1].Index != 3 || batchErr.Failures[1].HNID != 4 {
+			t.Fatalf("Unexpected failures: %+v", batchErr.Failures)
+		}
+		result, err := articlesOf(s.Query(ctx, ArticleQuery{}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Third", "First")
+	})
+
+	t.Run("SaveResults", func(t *testing.T) {
+		s := newStore(t)
+		saveAll(t, s, newTestArticle(1, "Existing", 1, 1))
+		unkeyed := newTestArticle(0, "Unkeyed", 1, 1)
+		unkeyed.Link = ""
+		batch := []*models.Article{
+			newTestArticle(2, "New", 1, 1),
+			newTestArticle(3, "", 1, 1),
+			newTestArticle(1, "Existing", 5, 1),
+			unkeyed,
+		}
+
+		saved, err := s.SaveArticles(ctx, batch, SavePartial)
+		var batchErr *BatchSaveError
+		if !errors.As(err, &batchErr) || len(batchErr.Failures) != 1 {
+			t.Fatalf("Expected one rejected article, got %v", err)
+		}
+		if len(saved) != 3 {
+			t.Fatalf("Expected 3 saved articles, got %d", len(saved))
+		}
+		expected := []struct {
+			index   int
+			title   string
+			created bool
+		}{{0, "New", true}, {2, "Existing", false}, {3, "Unkeyed", true}}
+		for i, want := range expected {
+			got := saved[i]
+			if got.Index != want.index || got.Article.Title != want.title || got.Created != want.created || got.Article.ID == 0 {
+				t.Errorf("Expected article %d to be %q (created %t), got %+v", want.index, want.title, want.created, got)
+			}
+		}
+		if saved[1].Article.Upvotes.Int64 != 5 {
+			t.Errorf("Expected the stored upvotes, got %d", saved[1].Article.Upvotes.Int64)
+		}
+		history, err := s.History(ctx, saved[2].Article.ID)
+		if err != nil || len(history) != 1 {
+			t.Errorf("Expected a snapshot of the unkeyed article, got %d (%v)", len(history), err)
+		}
+	})
+
+	t.Run("LargeBatch", func(t *testing.T) {
+		s := newStore(t)
+		batch := make([]*models.Article, 0, 2*maxBatchRows+1)
+		for i := 1; i <= 2*maxBatchRows; i++ {
+			batch = append(batch, newTestArticle(i, fmt.Sprintf("Article %d", i), 1, 1))
+		}
+		batch = append(batch, newTestArticle(1, "Article 1", 99, 1))
+		if _, err := s.SaveArticles(ctx, batch, SaveAtomic); err != nil {
+			t.Fatalf("SaveArticles failed: %v", err)
+		}
+
+		count, err := s.Count(ctx, ArticleQuery{})
+		if err != nil {
+			t.Fatalf("Count failed: %v", err)
+		}
+		if count != 2*maxBatchRows {
+			t.Fatalf("Expected %d articles, got %d", 2*maxBatchRows, count)
+		}
+		result, err := articlesOf(s.Query(ctx, ArticleQuery{Sort: SortOldest, Limit: 1}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		if result[0].HNID != 1 || result[0].Upvotes.Int64 != 99 {
+			t.Errorf("Expected the repeated article to be updated in place, got %+v", result[0])
+		}
+	})
+
+	t.Run("History", func(t *testing.T) {
+		s := newStore(t)
+		first := newTestArticle(1, "Climbing", 10, 1)
+		first.ArticleRank = 30
+		second := newTestArticle(1, "Climbing", 80, 25)
+		second.ArticleRank = 3
+		second.UpdatedAt = first.UpdatedAt.Add(time.Hour)
+		saveAll(t, s, first, newTestArticle(2, "Other", 1, 1), second)
+
+		result, err := articlesOf(s.Query(ctx, ArticleQuery{Sort: SortOldest}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Climbing", "Other")
+
+		history, err := s.History(ctx, result[0].ID)
+		if err != nil {
+			t.Fatalf("History failed: %v", err)
+		}
+		if len(history) != 2 {
+			t.Fatalf("Expected 2 snapshots, got %d", len(history))
+		}
+		if history[0].ArticleRank != 30 || history[0].Upvotes.Int64 != 10 || !history[0].CapturedAt.Equal(first.UpdatedAt) {
+			t.Errorf("Unexpected first snapshot: %+v", history[0])
+		}
+		if history[1].ArticleRank != 3 || history[1].Upvotes.Int64 != 80 || history[1].CommentCount.Int64 != 25 {
+			t.Errorf("Unexpected second snapshot: %+v", history[1])
+		}
+
+		if _, err := s.History(ctx, result[1].ID+100); !errors.Is(err, ErrArticleNotFound) {
+			t.Errorf("Expected ErrArticleNotFound, got %v", err)
+		}
+	})
+
+	t.Run("Lookups", func(t *testing.T) {
+		s := newStore(t)
+		hidden := newTestArticle(2, "Hidden", 1, 1)
+		hidden.Flagged = true
+		saveAll(t, s, newTestArticle(1, "Visible", 1, 1), hidden)
+
+		byHNID, err := s.ArticleByHNID(ctx, 2)
+		if err != nil {
+			t.Fatalf("ArticleByHNID failed: %v", err)
+		}
+		if byHNID.Title != "Hidden" || !byHNID.Flagged {
+			t.Errorf("Expected the flagged article, got %+v", byHNID)
+		}
+		byID, err := s.Article(ctx, byHNID.ID)
+		if err != nil {
+			t.Fatalf("Article failed: %v", err)
+		}
+		if byID.HNID != 2 || byID.Link != hidden.Link {
+			t.Errorf("Expected HN ID 2, got %+v", byID)
+		}
+
+		if _, err := s.Article(ctx, byHNID.ID+100); !errors.Is(err, ErrArticleNotFound) {
+			t.Errorf("Expected ErrArticleNotFound for a missing ID, got %v", err)
+		}
+		if _, err := s.ArticleByHNID(ctx, 3); !errors.Is(err, ErrArticleNotFound) {
+			t.Errorf("Expected ErrArticleNotFound for a missing HN ID, got %v", err)
+		}
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		s := newStore(t)
+		inTitle := newTestArticle(1, "Rust compilers explained", 10, 1)
+		inTitle.Summary = models.NewNullableString("A tour of how Rust compilers turn source into machine code.")
+		inContent := newTestArticle(2, "Systems notes", 500, 1)
+		inContent.Content = "Some notes that mention rust once, deep in the body."
+		inContent.Summary = models.NewNullableString("Assorted notes on operating systems.")
+		phrase := newTestArticle(3, "Memory safety in practice", 20, 1)
+		phrase.Summary = models.NewNullableString("Why memory safety matters for rust and other languages.")
+		flagged := newTestArticle(4, "Flagged rust story", 1, 1)
+		flagged.Flagged = true
+		saveAll(t, s, inTitle, inContent, phrase, flagged, newTestArticle(5, "Unrelated", 1, 1))
+
+		search := func(q SearchQuery) []*models.SearchResult {
+			t.Helper()
+			results, err := s.Search(ctx, q)
+			if err != nil {
+				t.Fatalf("Search failed: %v", err)
+			}
+			return results
+		}
+		resultTitles := func(results []*models.SearchResult) []*models.Article {
+			articles := make([]*models.Article, len(results))
+			for i, result := range results {
+				articles[i] = result.Article
+			}
+			return articles
+		}
+
+		results := search(SearchQuery{Text: "rust"})
+		expectTitles(t, resultTitles(results), "Rust compilers explained", "Memory safety in practice", "Systems notes")
+		if results[0].Relevance <= results[2].Relevance {
+			t.Errorf("Expected title matches to rank above content matches, got %v and %v", results[0].Relevance, results[2].Relevance)
+		}
+		if !strings.Contains(results[0].Snippet, "<mark>Rust</mark> compilers") {
+			t.Errorf("Expected a highlighted snippet, got %q", results[0].Snippet)
+		}
+
+		expectTitles(t, resultTitles(search(SearchQuery{Text: `"memory safety"`})), "Memory safety in practice")
+		expectTitles(t, resultTitles(search(SearchQuery{Text: `"safety memory"`})))
+		expectTitles(t, resultTitles(search(SearchQuery{Text: "compil*"})), "Rust compilers explained")
+		expectTitles(t, resultTitles(search(SearchQuery{Text: "rust", ArticleQuery: ArticleQuery{MinUpvotes: 100}})), "Systems notes")
+
+		yes := true
+		expectTitles(t, resultTitles(search(SearchQuery{Text: "rust", ArticleQuery: ArticleQuery{Flagged: &yes}})), "Flagged rust story")
+		expectTitles(t, resultTitles(search(SearchQuery{Text: "rust", ArticleQuery: ArticleQuery{Sort: SortOldest, Limit: 2}})),
+			"Rust compilers explained", "Systems notes")
+		expectTitles(t, resultTitles(search(SearchQuery{Text: "rust", ArticleQuery: ArticleQuery{Limit: 1, Offset: 1}})),
+			"Memory safety in practice")
+
+		if _, err := s.Search(ctx, SearchQuery{Text: "?!"}); !errors.Is(err, ErrInvalidSearch) {
+			t.Errorf("Expected ErrInvalidSearch, got %v", err)
+		}
+	})
+
+	t.Run("SearchAfterUpdate", func(t *testing.T) {
+		s := newStore(t)
+		saveAll(t, s, newTestArticle(1, "Original headline", 1, 1))
+		edited := newTestArticle(1, "Original headline", 2, 1)
+		edited.ArticleRank = 2
+		saveAll(t, s, edited)
+
+		results, err := s.Search(ctx, SearchQuery{Text: "headline"})
+		if err != nil {
+			t.Fatalf("Search failed: %v", err)
+		}
+		if len(results) != 1 || results[0].Article.Upvotes.Int64 != 2 {
+			t.Errorf("Expected the updated article once, got %d results", len(results))
+		}
+	})
+
+	t.Run("ExcludesUnsummarized", func(t *testing.T) {
+		s := newStore(t)
+		missing := newTestArticle(1, "Missing", 1, 1)
+		missing.Summary = models.NullableString{}
+		blank := newTestArticle(2, "Blank", 1, 1)
+		blank.Summary = models.NewNullableString("   ")
+		placeholder := newTestArticle(3, "Placeholder", 1, 1)
+		placeholder.Summary = models.NewNullableString("No summary available for this article.")
+		saveAll(t, s, missing, blank, placeholder, newTestArticle(4, "Summarized", 1, 1))
+
+		result, err := articlesOf(s.Query(ctx, ArticleQuery{}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Summarized")
+
+		// Filtering by flag status alone selects unsummarized articles too, unless a threshold is set.
+		unflagged := false
+		result, err = articlesOf(s.Query(ctx, ArticleQuery{Flagged: &unflagged}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Summarized", "Placeholder", "Blank", "Missing")
+		if count, err := s.Count(ctx, ArticleQuery{Flagged: &unflagged}); err != nil || count != 4 {
+			t.Errorf("Expected 4 articles counted, got %d (%v)", count, err)
+		}
+		result, err = articlesOf(s.Query(ctx, ArticleQuery{Flagged: &unflagged, MinUpvotes: 1}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Summarized")
+	})
+
+	t.Run("FlagFilters", func(t *testing.T) {
+		s := newStore(t)
+		flagged := newTestArticle(1, "Flagged", 1, 1)
+		flagged.Flagged = true
+		dead := newTestArticle(2, "Dead", 1, 1)
+		dead.Dead = true
+		dupe := newTestArticle(3, "Dupe", 1, 1)
+		dupe.Dupe = true
+		flaggedDead := newTestArticle(4, "Flagged Dead", 1, 1)
+		flaggedDead.Flagged = true
+		flaggedDead.Dead = true
+		saveAll(t, s, flagged, dead, dupe, flaggedDead, newTestArticle(5, "Clean", 1, 1))
+
+		yes, no := true, false
+		cases := []struct {
+			name     string
+			query    ArticleQuery
+			expected []string
+		}{
+			{"Defaults", ArticleQuery{}, []string{"Clean"}},
+			{"Flagged", ArticleQuery{Flagged: &yes}, []string{"Flagged"}},
+			{"FlaggedDead", ArticleQuery{Flagged: &yes, Dead: &yes}, []string{"Flagged Dead"}},
+			{"Dupe", ArticleQuery{Dupe: &yes}, []string{"Dupe"}},
+			{"ExplicitFalse", ArticleQuery{Flagged: &no, Dead: &no, Dupe: &no}, []string{"Clean"}},
+		}
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				result, err := articlesOf(s.Query(ctx, tc.query))
+				if err != nil {
+					t.Fatalf("Query failed: %v", err)
+				}
+				expectTitles(t, result, tc.expected...)
+			})
+		}
+	})
+
+	t.Run("Thresholds", func(t *testing.T) {
+		s := newStore(t)
+		saveAll(t, s,
+			newTestArticle(1, "Quiet", 5, 2),
+			newTestArticle(2, "Popular", 50, 2),
+			newTestArticle(3, "Discussed", 5, 40),
+			newTestArticle(4, "Hot", 80, 60),
+		)
+
+		result, err := articlesOf(s.Query(ctx, ArticleQuery{MinUpvotes: 50}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Hot", "Popular")
+
+		result, err = articlesOf(s.Query(ctx, ArticleQuery{MinUpvotes: 50, MinComments: 40}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Hot")
+	})
+
+	t.Run("Pagination", func(t *testing.T) {
+		s := newStore(t)
+		for i := 1; i <= 5; i++ {
+			saveAll(t, s, newTestArticle(i, fmt.Sprintf("Article %d", i), 1, 1))
+		}
+
+		result, err := articlesOf(s.Query(ctx, ArticleQuery{Limit: 2, Offset: 1}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Article 4", "Article 3")
+
+		result, err = articlesOf(s.Query(ctx, ArticleQuery{Limit: 2, Offset: 10}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result)
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		s := newStore(t)
+		flagged := newTestArticle(3, "Flagged", 50, 1)
+		flagged.Flagged = true
+		saveAll(t, s, newTestArticle(1, "Quiet", 5, 1), newTestArticle(2, "Popular", 50, 1), flagged)
+
+		yes := true
+		for _, tc := range []struct {
+			query    ArticleQuery
+			expected int
+		}{
+			{ArticleQuery{}, 2},
+			{ArticleQuery{Limit: 1, Offset: 1}, 2},
+			{ArticleQuery{MinUpvotes: 10}, 1},
+			{ArticleQuery{Flagged: &yes}, 1},
+		} {
+			count, err := s.Count(ctx, tc.query)
+			if err != nil {
+				t.Fatalf("Count failed: %v", err)
+			}
+			if count != tc.expected {
+				t.Errorf("Expected %d articles for %+v, got %d", tc.expected, tc.query, count)
+			}
+		}
+	})
+
+	t.Run("CursorPagination", func(t *testing.T) {
+		s := newStore(t)
+		for i := 1; i <= 5; i++ {
+			saveAll(t, s, newTestArticle(i, fmt.Sprintf("Article %d", i), 1, 1))
+		}
+
+		page, err := s.Query(ctx, ArticleQuery{Limit: 2})
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, page.Articles, "Article 5", "Article 4")
+		if !page.HasMore || page.NextCursor == "" {
+			t.Fatalf("Expected more pages, got %+v", page)
+		}
+
+		// Articles arriving between page loads must not shift the next page.
+		saveAll(t, s, newTestArticle(6, "Article 6", 1, 1))
+		page, err = s.Query(ctx, ArticleQuery{Limit: 2, Cursor: page.NextCursor})
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, page.Articles, "Article 3", "Article 2")
+
+		page, err = s.Query(ctx, ArticleQuery{Limit: 2, Cursor: page.NextCursor})
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, page.Articles, "Article 1")
+		if page.HasMore || page.NextCursor != "" {
+			t.Errorf("Expected the last page, got %+v", page)
+		}
+
+		page, err = s.Query(ctx, ArticleQuery{Sort: SortOldest, Limit: 3})
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		page, err = s.Query(ctx, ArticleQuery{Sort: SortOldest, Limit: 3, Cursor: page.NextCursor})
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, page.Articles, "Article 4", "Article 5", "Article 6")
+		if page.HasMore {
+			t.Error("Expected no more pages")
+		}
+
+		if _, err := s.Query(ctx, ArticleQuery{Cursor: "garbage"}); !errors.Is(err, ErrInvalidCursor) {
+			t.Errorf("Expected ErrInvalidCursor, got %v", err)
+		}
+	})
+
+	t.Run("TimeBounds", func(t *testing.T) {
+		s := newStore(t)
+		day := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+		pacific := time.FixedZone("PDT", -7*3600)
+		for _, a := range []struct {
+			title   string
+			created time.Time
+			updated time.Time
+		}{
+			{"Before", day.Add(-time.Minute), day.Add(48 * time.Hour)},
+			{"Morning", day.Add(9 * time.Hour), day.Add(9 * time.Hour)},
+			// Stored with an offset: 16:00 PDT on October 1st is 23:00 UTC.
+			{"Evening", day.Add(23 * time.Hour).In(pacific), day.Add(23 * time.Hour)},
+			{"After", day.Add(24 * time.Hour), day.Add(30 * time.Hour)},
+		} {
+			article := newTestArticle(int(a.title[0]), a.title, 1, 1)
+			article.CreatedAt = a.created
+			article.UpdatedAt = a.updated
+			saveAll(t, s, article)
+		}
+
+		for _, tc := range []struct {
+			query    ArticleQuery
+			expected []string
+		}{
+			{ArticleQuery{Since: day}, []string{"After", "Evening", "Morning"}},
+			{ArticleQuery{Until: day.Add(23 * time.Hour)}, []string{"Morning", "Before"}},
+			{ArticleQuery{Since: day, Until: day.Add(24 * time.Hour)}, []string{"Evening", "Morning"}},
+			{ArticleQuery{UpdatedSince: day.Add(24 * time.Hour)}, []string{"After", "Before"}},
+			{ArticleQuery{Since: day, UpdatedSince: day.Add(24 * time.Hour), Sort: SortOldest}, []string{"After"}},
+		} {
+			result, err := articlesOf(s.Query(ctx, tc.query))
+			if err != nil {
+				t.Fatalf("Query failed: %v", err)
+			}
+			expectTitles(t, result, tc.expected...)
+			if count, err := s.Count(ctx, tc.query); err != nil || count != len(tc.expected) {
+				t.Errorf("Expected a count of %d, got %d (%v)", len(tc.expected), count, err)
+			}
+		}
+	})
+
+	t.Run("Domains", func(t *testing.T) {
+		s := newStore(t)
+		for i, a := range []struct {
+			title, link string
+			upvotes     int64
+		}{
+			{"Go", "https://github.com/golang/go", 10},
+			{"Gist", "https://gist.github.com/someone/1", 20},
+			{"LWN", "https://lwn.net/Articles/1/", 30},
+			{"BBC", "http://www.bbc.co.uk/news/1", 5},
+			{"Relative", "item?id=5", 50},
+		} {
+			article := newTestArticle(i+1, a.title, a.upvotes, 1)
+			article.Link = a.link
+			saveAll(t, s, article)
+		}
+
+		stored, err := s.ArticleByHNID(ctx, 2)
+		if err != nil || stored.Domain != "github.com" {
+			t.Fatalf("Expected the gist to be stored under github.com, got %+v (%v)", stored, err)
+		}
+
+		for _, tc := range []struct {
+			query    ArticleQuery
+			expected []string
+		}{
+			{ArticleQuery{Domains: []string{"github.com"}}, []string{"Gist", "Go"}},
+			{ArticleQuery{Domains: []string{"www.lwn.net", "bbc.co.uk"}}, []string{"BBC", "LWN"}},
+			{ArticleQuery{ExcludeDomains: []string{"github.com", "bbc.co.uk"}}, []string{"Relative", "LWN"}},
+			{ArticleQuery{Domains: []string{"github.com"}, ExcludeDomains: []string{"gist.github.com"}}, nil},
+		} {
+			result, err := articlesOf(s.Query(ctx, tc.query))
+			if err != nil {
+				t.Fatalf("Query failed: %v", err)
+			}
+			expectTitles(t, result, tc.expected...)
+			if count, err := s.Count(ctx, tc.query); err != nil || count != len(tc.expected) {
+				t.Errorf("Expected a count of %d, got %d (%v)", len(tc.expected), count, err)
+			}
+		}
+
+		domains, err := s.Domains(ctx, ArticleQuery{})
+		if err != nil {
+			t.Fatalf("Domains failed: %v", err)
+		}
+		if got := fmt.Sprint(domainSummary(domains)); got != "[github.com:2:15 bbc.co.uk:1:5 lwn.net:1:30]" {
+			t.Errorf("Unexpected domain summary %s", got)
+		}
+		domains, err = s.Domains(ctx, ArticleQuery{MinUpvotes: 10, Limit: 1, Offset: 1})
+		if err != nil {
+			t.Fatalf("Domains failed: %v", err)
+		}
+		if got := fmt.Sprint(domainSummary(domains)); got != "[lwn.net:1:30]" {
+			t.Errorf("Unexpected filtered domain summary %s", got)
+		}
+	})
+
+	t.Run("Facets", func(t *testing.T) {
+		s := newStore(t)
+		for i, a := range []struct {
+			model, commit string
+			dead          bool
+		}{
+			{"llama3:8b", "abc1234", false},
+			{"llama3:8b", "def5678", false},
+			{"mistral", "def5678", false},
+			{"mistral", "def5678", true},
+		} {
+			article := newTestArticle(i+1, fmt.Sprintf("Article %d", i+1), 1, 1)
+			article.ModelName, article.CommitHash, article.Dead = a.model, a.commit, a.dead
+			saveAll(t, s, article)
+		}
+
+		query := ArticleQuery{ModelNames: []string{"mistral"}, CommitHashes: []string{"def5678"}}
+		result, err := articlesOf(s.Query(ctx, query))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, result, "Article 3")
+
+		facets, err := s.Facets(ctx, query)
+		if err != nil {
+			t.Fatalf("Facets failed: %v", err)
+		}
+		counts := func(values []*models.FacetCount) string {
+			var result []string
+			for _, value := range values {
+				result = append(result, fmt.Sprintf("%s:%d", value.Value, value.Count))
+			}
+			return fmt.Sprint(result)
+		}
+		if got := counts(facets.ModelName); got != "[llama3:8b:1 mistral:1]" {
+			t.Errorf("Unexpected model facet %s", got)
+		}
+		if got := counts(facets.CommitHash); got != "[def5678:1]" {
+			t.Errorf("Unexpected commit facet %s", got)
+		}
+		if got := counts(facets.Source); got != "[Hacker News:1]" {
+			t.Errorf("Unexpected source facet %s", got)
+		}
+		if facets.Dead != (models.FlagCounts{True: 1, False: 1}) || facets.Flagged != (models.FlagCounts{False: 1}) {
+			t.Errorf("Unexpected flag facets: dead %+v, flagged %+v", facets.Dead, facets.Flagged)
+		}
+	})
+
+	t.Run("Fields", func(t *testing.T) {
+		s := newStore(t)
+		for i := 1; i <= 3; i++ {
+			saveAll(t, s, newTestArticle(i, fmt.Sprintf("Article %d", i), int64(10*i), int64(i)))
+		}
+
+		page, err := s.Query(ctx, ArticleQuery{Fields: []string{"title", "summary"}, Sort: SortUpvotes, Limit: 2})
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, page.Articles, "Article 3", "Article 2")
+		article := page.Articles[0]
+		if article.ID == 0 || !article.Summary.Valid || article.CreatedAt.IsZero() {
+			t.Errorf("Expected the ID, summary and timestamps to be loaded, got %+v", article)
+		}
+		if article.Content != "" || article.Link != "" || article.HNID != 0 || article.CommentCount.Valid {
+			t.Errorf("Expected unselected fields to be zero, got %+v", article)
+		}
+		if article.Upvotes.Int64 != 30 {
+			t.Errorf("Expected the sort column to be loaded, got %+v", article.Upvotes)
+		}
+
+		next, err := articlesOf(s.Query(ctx, ArticleQuery{Fields: []string{"title"}, Sort: SortUpvotes, Limit: 2, Cursor: page.NextCursor}))
+		if err != nil {
+			t.Fatalf("Query failed: %v", err)
+		}
+		expectTitles(t, next, "Article 1")
+
+		if _, err := s.Query(ctx, ArticleQuery{Fields: []string{"body"}}); err == nil {
+			t.Error("Expected an unknown field to be rejected")
+		}
+	})
+
+	t.Run("Sorts", func(t *testing.T) {
+		s := newStore(t)
+		now := time.Now().UTC()
+		updated := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		for _, a := range []struct {
+			title             string
+			upvotes, comments int64
+			rank              int
+			age               time.Duration
+			updated           time.Duration
+		}{
+			{"A", 10, 5, 3, time.Hour, 3 * time.Minute},
+			{"B", 50, 1, 1, 48 * time.Hour, time.Minute},
+			{"C", 30, 9, 2, 2 * time.Hour, 2 * time.Minute},
+			{"D", 10, 0, 4, 10 * time.Hour, 0},
+		} {
+			article := newTestArticle(int(a.title[0]), a.title, a.upvotes, a.comments)
+			article.ArticleRank = a.rank
+			article.CreatedAt = now.Add(-a.age)
+			article.UpdatedAt = updated.Add(a.updated)
+			saveAll(t, s, article)
+		}
+
+		for _, tc := range []struct {
+			query    ArticleQuery
+			expected []string
+		}{
+			{ArticleQuery{Sort: SortNewest}, []string{"D", "C", "B", "A"}},
+			{ArticleQuery{Sort: SortOldest}, []string{"A", "B", "C", "D"}},
+			{ArticleQuery{Sort: SortUpvotes}, []string{"B", "C", "D", "A"}},
+			{ArticleQuery{Sort: SortUpvotes, Order: Ascending}, []string{"A", "D", "C", "B"}},
+			{ArticleQuery{Sort: SortComments}, []string{"C", "A", "B", "D"}},
+			{ArticleQuery{Sort: SortRank}, []string{"B", "C", "A", "D"}},
+			{ArticleQuery{Sort: SortRank, Order: Descending}, []string{"D", "A", "C", "B"}},
+			{ArticleQuery{Sort: SortHot}, []string{"C", "A", "D", "B"}},
+			{ArticleQuery{Sort: SortUpdated}, []string{"A", "C", "B", "D"}},
+			{ArticleQuery{Sort: SortUpdated, Order: Ascending, MinUpvotes: 20}, []string{"B", "C"}},
+		} {
+			result, err := articlesOf(s.Query(ctx, tc.query))
+			if err != nil {
+				t.Fatalf("Query %+v failed: %v", tc.query, err)
+			}
+			expectTitles(t, result, tc.expected...)
+
+			// Walking the same order one article at a time by cursor visits every article once.
+			var walked []*models.Article
+			q := tc.query
+			q.Limit = 1
+			for {
+				page, err := s.Query(ctx, q)
+				if err != nil {
+					t.Fatalf("Query %+v failed: %v", q, err)
+				}
+				walked = append(walked, page.Articles...)
+				if !page.HasMore || len(walked) > len(tc.expected) {
+					break
+				}
+				q.Cursor = page.NextCursor
+			}
+			expectTitles(t, walked, tc.expected...)
+		}
+	})
+
+	t.Run("Webhooks", func(t *testing.T) {
+		s := newStore(t)
+		ws, ok := s.(WebhookStore)
+		if !ok {
+			t.Fatal("Expected the store to keep webhooks")
+		}
+		saveAll(t, s, newTestArticle(1, "Delivered", 300, 10))
+		article, err := s.ArticleByHNID(ctx, 1)
+		if err != nil {
+			t.Fatalf("ArticleByHNID failed: %v", err)
+		}
+
+		webhook := &models.Webhook{URL: "https://example.com/hook", Filter: "upvotes>=200", Secret: "s3cret", Active: true}
+		if err := ws.CreateWebhook(ctx, webhook); err != nil || webhook.ID == 0 {
+			t.Fatalf("CreateWebhook failed: %v (ID %d)", err, webhook.ID)
+		}
+		webhook.Filter = "upvotes>=300"
+		if err := ws.UpdateWebhook(ctx, webhook); err != nil {
+			t.Fatalf("UpdateWebhook failed: %v", err)
+		}
+		if got, err := ws.Webhook(ctx, webhook.ID); err != nil || got.Filter != "upvotes>=300" || got.Secret != "s3cret" || !got.Active {
+			t.Errorf("Expected the updated webhook, got %+v (%v)", got, err)
+		}
+		if err := ws.UpdateWebhook(ctx, &models.Webhook{ID: webhook.ID + 1}); !errors.Is(err, ErrWebhookNotFound) {
+			t.Errorf("Expected ErrWebhookNotFound, got %v", err)
+		}
+
+		// A second delivery of the same article to the same webhook is skipped.
+		now := time.Now().UTC()
+		delivery := func() *models.WebhookDelivery {
+			return &models.WebhookDelivery{WebhookID: webhook.ID, ArticleID: article.ID, Event: "article.created", Payload: []byte(`{"event":"article.created"}`), NextAttemptAt: now}
+		}
+		if n, err := ws.EnqueueDeliveries(ctx, []*models.WebhookDelivery{delivery()}); err != nil || n != 1 {
+			t.Fatalf("Expected 1 delivery enqueued, got %d (%v)", n, err)
+		}
+		if n, err := ws.EnqueueDeliveries(ctx, []*models.WebhookDelivery{delivery()}); err != nil || n != 0 {
+			t.Fatalf("Expected the repeated delivery to be skipped, got %d (%v)", n, err)
+		}
+
+		due, err := ws.DueDeliveries(ctx, now.Add(time.Second), 10)
+		if err != nil || len(due) != 1 {
+			t.Fatalf("Expected 1 due delivery, got %d (%v)", len(due), err)
+		}
+		if got := due[0]; got.Status != models.DeliveryPending || got.Attempts != 0 || string(got.Payload) != `{"event":"article.created"}` {
+			t.Errorf("Unexpected due delivery: %+v", got)
+		}
+
+		// A failed attempt scheduled later is no longer due.
+		due[0].Attempts, due[0].LastError, due[0].ResponseStatus = 1, "unexpected response status 500", 500
+		due[0].NextAttemptAt = now.Add(time.Hour)
+		if err := ws.UpdateDelivery(ctx, due[0]); err != nil {
+			t.Fatalf("UpdateDelivery failed: %v", err)
+		}
+		if due, err := ws.DueDeliveries(ctx, now.Add(time.Second), 10); err != nil || len(due) != 0 {
+			t.Errorf("Expected no due deliveries, got %d (%v)", len(due), err)
+		}
+
+		delivered := now.Truncate(time.Second)
+		due[0].Status, due[0].DeliveredAt = models.DeliveryDelivered, &delivered
+		if err := ws.UpdateDelivery(ctx, due[0]); err != nil {
+			t.Fatalf("UpdateDelivery failed: %v", err)
+		}
+		entries, err := ws.Deliveries(ctx, DeliveryQuery{WebhookID: webhook.ID, Status: models.DeliveryDelivered})
+		if err != nil || len(entries) != 1 {
+			t.Fatalf("Expected 1 delivered delivery, got %d (%v)", len(entries), err)
+		}
+		if got := entries[0]; got.Attempts != 1 || got.ResponseStatus != 500 || got.DeliveredAt == nil || !got.DeliveredAt.Equal(delivered) {
+			t.Errorf("Unexpected logged delivery: %+v", got)
+		}
+		if got, err := ws.Delivery(ctx, entries[0].ID); err != nil || got.ID != entries[0].ID {
+			t.Errorf("Expected delivery %d, got %+v (%v)", entries[0].ID, got, err)
+		}
+
+		// Deactivated webhooks hold their deliveries.
+		due[0].Status, due[0].NextAttemptAt = models.DeliveryPending, now
+		if err := ws.UpdateDelivery(ctx, due[0]); err != nil {
+			t.Fatalf("UpdateDelivery failed: %v", err)
+		}
+		webhook.Active = false
+		if err := ws.UpdateWebhook(ctx, webhook); err != nil {
+			t.Fatalf("UpdateWebhook failed: %v", err)
+		}
+		if due, err := ws.DueDeliveries(ctx, now.Add(time.Second), 10); err != nil || len(due) != 0 {
+			t.Errorf("Expected no due deliveries of an inactive webhook, got %d (%v)", len(due), err)
+		}
+
+		if err := ws.DeleteWebhook(ctx, webhook.ID); err != nil {
+			t.Fatalf("DeleteWebhook failed: %v", err)
+		}
+		if _, err := ws.Delivery(ctx, entries[0].ID); !errors.Is(err, ErrDeliveryNotFound) {
+			t.Errorf("Expected the deliveries to be deleted with the webhook, got %v", err)
+		}
+		if err := ws.DeleteWebhook(ctx, webhook.ID); !errors.Is(err, ErrWebhookNotFound) {
+			t.Errorf("Expected ErrWebhookNotFound, got %v", err)
+		}
+	})
+
+	t.Run("DeliveryPlanner", func(t *testing.T) {
+		s := newStore(t)
+		ws := s.(WebhookStore)
+		webhook := &models.Webhook{URL: "https://example.com/hook", Secret: "s3cret", Active: true}
+		if err := ws.CreateWebhook(ctx, webhook); err != nil {
+			t.Fatalf("CreateWebhook failed: %v", err)
+		}
+		var planErr error
+		ws.SetDeliveryPlanner(func(webhooks []*models.Webhook, saved []SavedArticle) ([]*models.WebhookDelivery, error) {
+			var deliveries []*models.WebhookDelivery
+			for _, result := range saved {
+				deliveries = append(deliveries, &models.WebhookDelivery{
+					WebhookID: webhooks[0].ID, ArticleID: result.Article.ID, Event: "article.created",
+					Payload: []byte(`{}`), NextAttemptAt: time.Now().UTC(),
+				})
+			}
+			return deliveries, planErr
+		})
+
+		saveAll(t, s, newTestArticle(1, "Planned", 1, 1))
+		if entries, err := ws.Deliveries(ctx, DeliveryQuery{}); err != nil || len(entries) != 1 {
+			t.Fatalf("Expected the save to enqueue 1 delivery, got %d (%v)", len(entries), err)
+		}
+
+		// A failure to enqueue the deliveries rolls back the save.
+		planErr = errors.New("planner failed")
+		if _, err := s.SaveArticles(ctx, []*models.Article{newTestArticle(2, "Unplanned", 1, 1)}, SaveAtomic); err == nil {
+			t.Fatal("Expected the planner error")
+		}
+		if _, err := s.ArticleByHNID(ctx, 2); !errors.Is(err, ErrArticleNotFound) {
+			t.Errorf("Expected the article not to be saved, got %v", err)
+		}
+	})
+
+	t.Run("CancelledContext", func(t *testing.T) {
+		s := newStore(t)
+		cancelled, cancel := context.WithCancel(ctx)
+		cancel()
+		if _, err := articlesOf(s.Query(cancelled, ArticleQuery{})); err == nil {
+			t.Fatal("Expected an error for a cancelled context")
+		}
+	})
+}
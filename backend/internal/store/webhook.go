@@ -0,0 +1,406 @@
+package store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// WebhookStore is implemented by stores that keep registered webhooks and their persistent
+// delivery queue, which doubles as the delivery log.
+type WebhookStore interface {
+	// CreateWebhook stores a new webhook, setting its ID and timestamps.
+	CreateWebhook(ctx context.Context, webhook *models.Webhook) error
+	// Webhook returns the webhook with the given ID, or ErrWebhookNotFound.
+	Webhook(ctx context.Context, id int) (*models.Webhook, error)
+	// Webhooks returns every webhook, oldest first.
+	Webhooks(ctx context.Context) ([]*models.Webhook, error)
+	// UpdateWebhook replaces the URL, filter, secret and active state of a webhook, or returns ErrWebhookNotFound.
+	UpdateWebhook(ctx context.Context, webhook *models.Webhook) error
+	// DeleteWebhook removes a webhook and its deliveries, or returns ErrWebhookNotFound.
+	DeleteWebhook(ctx context.Context, id int) error
+	// EnqueueDeliveries stores new deliveries, skipping articles already delivered to the same
+	// webhook, and returns the number stored.
+	EnqueueDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) (int, error)
+	// SetDeliveryPlanner makes every later save of articles enqueue the deliveries planned for
+	// them in the same transaction, so that no saved article misses its deliveries.
+	SetDeliveryPlanner(planner DeliveryPlanner)
+	// DueDeliveries returns up to limit pending deliveries of active webhooks due by now, oldest first.
+	DueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error)
+	// UpdateDelivery records the status and attempt bookkeeping of a delivery, or returns ErrDeliveryNotFound.
+	UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error
+	// Delivery returns the delivery with the given ID, or ErrDeliveryNotFound.
+	Delivery(ctx context.Context, id int) (*models.WebhookDelivery, error)
+	// Deliveries returns the deliveries matching q, newest first.
+	Deliveries(ctx context.Context, q DeliveryQuery) ([]*models.WebhookDelivery, error)
+}
+
+// DeliveryPlanner returns the deliveries announcing saved articles to the active webhooks whose
+// filters they match. It runs inside the save transaction, so it must not use the store.
+type DeliveryPlanner func(webhooks []*models.Webhook, saved []SavedArticle) ([]*models.WebhookDelivery, error)
+
+var (
+	// ErrWebhookNotFound is returned by lookups and changes of webhooks that do not exist.
+	ErrWebhookNotFound = errors.New("webhook not found")
+	// ErrDeliveryNotFound is returned by lookups and changes of deliveries that do not exist.
+	ErrDeliveryNotFound = errors.New("delivery not found")
+)
+
+// DeliveryQuery selects entries of the delivery log.
+type DeliveryQuery struct {
+	WebhookID int    // Deliveries to this webhook; 0 for every webhook.
+	Status    string // Deliveries with this status; empty for any status.
+	Limit     int    // Maximum number of deliveries; non-positive for DefaultLimit.
+}
+
+// webhookColumns and deliveryColumns list the columns read by scanWebhook and queryDeliveries.
+const (
+	webhookColumns  = "id, url, filter_expression, secret, active, created_at, updated_at"
+	deliveryColumns = "id, webhook_id, article_id, event, payload, status, attempts, next_attempt_at, last_error, response_status, delivered_at, created_at, updated_at"
+)
+
+// insertID runs an INSERT and returns the ID generated for the new row.
+func (store *sqlStore) insertID(ctx context.Context, query string, args ...interface{}) (int, error) {
+	if store.dialect.returningIDs {
+		var id int
+		err := store.db.QueryRowContext(ctx, store.dialect.rebind(query+" RETURNING id"), args...).Scan(&id)
+		return id, err
+	}
+	result, err := store.db.ExecContext(ctx, store.dialect.rebind(query), args...)
+	if err != nil {
+		return 0, err
+	}
+	id, err := result.LastInsertId()
+	return int(id), err
+}
+
+// updateRow runs an UPDATE of the row of table with the given ID, returning notFound if there is
+// no such row. MySQL counts only rows whose values changed, so an unaffected row is looked up.
+func (store *sqlStore) updateRow(ctx context.Context, table string, id int, notFound error, query string, args ...interface{}) error {
+	result, err := store.db.ExecContext(ctx, store.dialect.rebind(query), args...)
+	if err != nil {
+		return err
+	}
+	if n, err := result.RowsAffected(); err != nil || n > 0 {
+		return err
+	}
+	var count int
+	if err := store.db.QueryRowContext(ctx, store.dialect.rebind("SELECT COUNT(*) FROM "+table+" WHERE id = ?;"), id).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		return notFound
+	}
+	return nil
+}
+
+// CreateWebhook stores webhook and sets its ID and timestamps.
+func (store *sqlStore) CreateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	now := time.Now().UTC()
+	id, err := store.insertID(ctx, `
+		INSERT INTO webhooks (url, filter_expression, secret, active, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?)`,
+		webhook.URL, webhook.Filter, webhook.Secret, webhook.Active, now, now)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+	webhook.ID, webhook.CreatedAt, webhook.UpdatedAt = id, now, now
+	return nil
+}
+
+// Webhook returns the webhook with the given ID, or ErrWebhookNotFound if there is none.
+func (store *sqlStore) Webhook(ctx context.Context, id int) (*models.Webhook, error) {
+	row := store.db.QueryRowContext(ctx, store.dialect.rebind("SELECT "+webhookColumns+" FROM webhooks WHERE id = ?;"), id)
+	webhook, err := scanWebhook(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrWebhookNotFound
+	}
+	return webhook, err
+}
+
+// Webhooks returns every webhook in the order they were created.
+func (store *sqlStore) Webhooks(ctx context.Context) ([]*models.Webhook, error) {
+	rows, err := store.db.QueryContext(ctx, "SELECT "+webhookColumns+" FROM webhooks ORDER BY id ASC;")
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	webhooks := []*models.Webhook{}
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+	return webhooks, nil
+}
+
+// UpdateWebhook stores the URL, filter, secret and active state of webhook and refreshes its UpdatedAt.
+func (store *sqlStore) UpdateWebhook(ctx context.Context, webhook *models.Webhook) error {
+	now := time.Now().UTC()
+	err := store.updateRow(ctx, "webhooks", webhook.ID, ErrWebhookNotFound, `
+		UPDATE webhooks
+		SET url = ?, filter_expression = ?, secret = ?, active = ?, updated_at = ?
+		WHERE id = ?;`,
+		webhook.URL, webhook.Filter, webhook.Secret, webhook.Active, now, webhook.ID)
+	if err != nil {
+		if errors.Is(err, ErrWebhookNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to update webhook: %w", err)
+	}
+	webhook.UpdatedAt = now
+	return nil
+}
+
+// DeleteWebhook removes the webhook with the given ID together with its deliveries.
+// They are deleted explicitly, since SQLite only cascades with foreign keys enabled.
+func (store *sqlStore) DeleteWebhook(ctx context.Context, id int) error {
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, store.dialect.rebind("DELETE FROM webhook_deliveries WHERE webhook_id = ?;"), id); err != nil {
+		return fmt.Errorf("failed to delete deliveries: %w", err)
+	}
+	result, err := tx.ExecContext(ctx, store.dialect.rebind("DELETE FROM webhooks WHERE id = ?;"), id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	}
+	if n, err := result.RowsAffected(); err != nil {
+		return fmt.Errorf("failed to delete webhook: %w", err)
+	} else if n == 0 {
+		return ErrWebhookNotFound
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit webhook deletion: %w", err)
+	}
+	return nil
+}
+
+// EnqueueDeliveries stores deliveries in one transaction and returns how many were new. A delivery
+// of an article that was already enqueued for the same webhook is skipped, so an article that keeps
+// matching a filter as it is scraped again is only sent once.
+func (store *sqlStore) EnqueueDeliveries(ctx context.Context, deliveries []*models.WebhookDelivery) (int, error) {
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+	tx, err := store.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	enqueued, err := store.enqueueDeliveries(ctx, tx, deliveries)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit deliveries: %w", err)
+	}
+	return enqueued, nil
+}
+
+// enqueueDeliveries stores deliveries in tx, skipping already enqueued ones, and returns how many were new.
+func (store *sqlStore) enqueueDeliveries(ctx context.Context, tx *sql.Tx, deliveries []*models.WebhookDelivery) (int, error) {
+	conflict := "ON DUPLICATE KEY UPDATE id = id"
+	if store.dialect.onConflict {
+		conflict = "ON CONFLICT (webhook_id, article_id) DO NOTHING"
+	}
+	query := store.dialect.rebind(`
+		INSERT INTO webhook_deliveries
+			(webhook_id, article_id, event, payload, status, attempts, next_attempt_at, last_error, response_status, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, 0, ?, '', 0, ?, ?)
+		` + conflict + ";")
+
+	now := time.Now().UTC()
+	enqueued := 0
+	for _, d := range deliveries {
+		result, err := tx.ExecContext(ctx, query, d.WebhookID, d.ArticleID, d.Event, string(d.Payload), models.DeliveryPending, d.NextAttemptAt, now, now)
+		if err != nil {
+			return 0, fmt.Errorf("failed to enqueue delivery: %w", err)
+		}
+		if n, err := result.RowsAffected(); err != nil {
+			return 0, fmt.Errorf("failed to enqueue delivery: %w", err)
+		} else if n > 0 {
+			enqueued++
+		}
+	}
+	return enqueued, nil
+}
+
+// SetDeliveryPlanner registers the planner consulted by every later save. Call it before saving.
+func (store *sqlStore) SetDeliveryPlanner(planner DeliveryPlanner) {
+	store.planner = planner
+}
+
+// enqueuePlanned enqueues the deliveries planned for saved in tx, the transaction that saved them.
+func (store *sqlStore) enqueuePlanned(ctx context.Context, tx *sql.Tx, saved []SavedArticle) error {
+	if store.planner == nil || len(saved) == 0 {
+		return nil
+	}
+	rows, err := tx.QueryContext(ctx, store.dialect.rebind("SELECT "+webhookColumns+" FROM webhooks WHERE active = ? ORDER BY id ASC;"), true)
+	if err != nil {
+		return fmt.Errorf("failed to read webhooks: %w", err)
+	}
+	var webhooks []*models.Webhook
+	for rows.Next() {
+		webhook, err := scanWebhook(rows)
+		if err != nil {
+			rows.Close()
+			return err
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iteration error: %w", err)
+	}
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	deliveries, err := store.planner(webhooks, saved)
+	if err != nil {
+		return fmt.Errorf("failed to plan deliveries: %w", err)
+	}
+	_, err = store.enqueueDeliveries(ctx, tx, deliveries)
+	return err
+}
+
+// DueDeliveries returns up to limit pending deliveries whose next attempt is due by now, in the
+// order they were enqueued. Deliveries of deactivated webhooks stay queued until reactivation.
+func (store *sqlStore) DueDeliveries(ctx context.Context, now time.Time, limit int) ([]*models.WebhookDelivery, error) {
+	due, dueArg := store.dialect.timeCondition("d.next_attempt_at", "<=", now)
+	return store.queryDeliveries(ctx, `
+		SELECT d.`+strings.ReplaceAll(deliveryColumns, ", ", ", d.")+`
+		FROM webhook_deliveries d
+		JOIN webhooks w ON w.id = d.webhook_id
+		WHERE d.status = ? AND w.active = ? AND `+due+`
+		ORDER BY d.id ASC
+		LIMIT ?;`,
+		models.DeliveryPending, true, dueArg, limit)
+}
+
+// UpdateDelivery records the status, attempt count, schedule and outcome of delivery's latest attempt.
+func (store *sqlStore) UpdateDelivery(ctx context.Context, delivery *models.WebhookDelivery) error {
+	now := time.Now().UTC()
+	var deliveredAt sql.NullTime
+	if delivery.DeliveredAt != nil {
+		deliveredAt = sql.NullTime{Time: *delivery.DeliveredAt, Valid: true}
+	}
+	err := store.updateRow(ctx, "webhook_deliveries", delivery.ID, ErrDeliveryNotFound, `
+		UPDATE webhook_deliveries
+		SET status = ?, attempts = ?, next_attempt_at = ?, last_error = ?, response_status = ?, delivered_at = ?, updated_at = ?
+		WHERE id = ?;`,
+		delivery.Status, delivery.Attempts, delivery.NextAttemptAt, delivery.LastError, delivery.ResponseStatus, deliveredAt, now, delivery.ID)
+	if err != nil {
+		if errors.Is(err, ErrDeliveryNotFound) {
+			return err
+		}
+		return fmt.Errorf("failed to update delivery: %w", err)
+	}
+	delivery.UpdatedAt = now
+	return nil
+}
+
+// Delivery returns the delivery with the given ID, or ErrDeliveryNotFound if there is none.
+func (store *sqlStore) Delivery(ctx context.Context, id int) (*models.WebhookDelivery, error) {
+	deliveries, err := store.queryDeliveries(ctx, "SELECT "+deliveryColumns+" FROM webhook_deliveries WHERE id = ?;", id)
+	if err != nil {
+		return nil, err
+	}
+	if len(deliveries) == 0 {
+		return nil, ErrDeliveryNotFound
+	}
+	return deliveries[0], nil
+}
+
+// Deliveries returns the deliveries matching q, most recently enqueued first.
+func (store *sqlStore) Deliveries(ctx context.Context, q DeliveryQuery) ([]*models.WebhookDelivery, error) {
+	conditions := []string{"1 = 1"}
+	var args []interface{}
+	if q.WebhookID > 0 {
+		conditions = append(conditions, "webhook_id = ?")
+		args = append(args, q.WebhookID)
+	}
+	if q.Status != "" {
+		conditions = append(conditions, "status = ?")
+		args = append(args, q.Status)
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultLimit
+	}
+	args = append(args, q.Limit)
+	return store.queryDeliveries(ctx, `
+		SELECT `+deliveryColumns+`
+		FROM webhook_deliveries
+		WHERE `+strings.Join(conditions, " AND ")+`
+		ORDER BY id DESC
+		LIMIT ?;`, args...)
+}
+
+// queryDeliveries runs a query selecting deliveryColumns and scans its rows.
+func (store *sqlStore) queryDeliveries(ctx context.Context, query string, args ...interface{}) ([]*models.WebhookDelivery, error) {
+	rows, err := store.db.QueryContext(ctx, store.dialect.rebind(query), args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []*models.WebhookDelivery{}
+	for rows.Next() {
+		var delivery models.WebhookDelivery
+		var payload []byte
+		var deliveredAt sql.NullTime
+		err := rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.ArticleID,
+			&delivery.Event,
+			&payload,
+			&delivery.Status,
+			&delivery.Attempts,
+			&delivery.NextAttemptAt,
+			&delivery.LastError,
+			&delivery.ResponseStatus,
+			&deliveredAt,
+			&delivery.CreatedAt,
+			&delivery.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan delivery: %w", err)
+		}
+		delivery.Payload = payload
+		if deliveredAt.Valid {
+			delivery.DeliveredAt = &deliveredAt.Time
+		}
+		deliveries = append(deliveries, &delivery)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iteration error: %w", err)
+	}
+	return deliveries, nil
+}
+
+// scanWebhook reads one webhook selected with webhookColumns.
+func scanWebhook(row rowScanner) (*models.Webhook, error) {
+	var webhook models.Webhook
+	err := row.Scan(&webhook.ID, &webhook.URL, &webhook.Filter, &webhook.Secret, &webhook.Active, &webhook.CreatedAt, &webhook.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan webhook: %w", err)
+	}
+	return &webhook, nil
+}
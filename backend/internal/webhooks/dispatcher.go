@@ -0,0 +1,380 @@
+// Package webhooks delivers saved articles to registered webhooks. Every saved article is matched
+// against the filter of each active webhook, and matches are written to a persistent delivery
+// queue in the transaction saving the article, from which they are POSTed as signed JSON and
+// retried with exponential backoff until the receiver acknowledges them or the attempts run out.
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/config"
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/pubsub"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+const (
+	// wakeBuffer is the number of announced articles buffered between two checks of the queue.
+	wakeBuffer = 64
+	// deliveryBatch is the number of due deliveries read from the queue at a time.
+	deliveryBatch = 50
+	// deliveryWorkers is the number of webhooks delivered to concurrently.
+	deliveryWorkers = 8
+	// pollInterval bounds how long a due retry waits to be noticed.
+	pollInterval = 5 * time.Second
+	// maxBackoff caps the delay between two attempts of a delivery.
+	maxBackoff = 6 * time.Hour
+	// maxErrorLength bounds the stored reason of a failed attempt, as the last_error column does.
+	maxErrorLength = 1024
+	// maxResponseBody is the most of a response body read before the connection is reused.
+	maxResponseBody = 64 << 10
+	// userAgent identifies webhook requests.
+	userAgent = "GopherSignal-Webhooks/1"
+)
+
+// Dispatcher plans the deliveries of saved articles to the webhooks they match and delivers the
+// queue. Deliveries live in the store, so retries survive restarts. A single dispatcher should
+// run against a database, since concurrent ones would send due deliveries twice.
+type Dispatcher struct {
+	Store        store.WebhookStore // Store keeps the webhooks and the delivery queue.
+	Client       *http.Client       // Client sends the deliveries.
+	MaxAttempts  int                // Attempts after which a delivery is marked failed.
+	Backoff      time.Duration      // Delay before the first retry, doubling with every further one.
+	PollInterval time.Duration      // Interval at which the queue is checked for due retries.
+	Workers      int                // Number of webhooks delivered to concurrently.
+
+	wake chan struct{}
+}
+
+// NewDispatcher creates a Dispatcher delivering the webhooks of s with the timeout, attempts and
+// backoff of cfg, and makes every later save to s enqueue the deliveries planned by Plan.
+// Redirects are not followed, since they would turn the POST into a GET.
+func NewDispatcher(s store.WebhookStore, cfg *config.AppConfig) *Dispatcher {
+	d := &Dispatcher{
+		Store: s,
+		Client: &http.Client{
+			Timeout: cfg.WebhookTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		MaxAttempts:  cfg.WebhookAttempts,
+		Backoff:      cfg.WebhookBackoff,
+		PollInterval: pollInterval,
+		Workers:      deliveryWorkers,
+		wake:         make(chan struct{}, 1),
+	}
+	s.SetDeliveryPlanner(d.Plan)
+	return d
+}
+
+// Run delivers the queue until ctx ends, checking it whenever an article is announced on hub.
+// Deliveries are enqueued by the saves themselves, so a dispatcher falling behind the hub only
+// resubscribes, leaving the poll interval to pick up the deliveries of the missed announcements.
+func (d *Dispatcher) Run(ctx context.Context, hub *pubsub.Hub) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d.deliverLoop(ctx)
+	}()
+	defer func() { <-done }()
+
+	for ctx.Err() == nil {
+		if !d.consume(ctx, hub.Subscribe(wakeBuffer)) {
+			return
+		}
+	}
+}
+
+// consume wakes the dispatcher on the events of sub until it or ctx ends, reporting whether sub
+// lagged behind.
+func (d *Dispatcher) consume(ctx context.Context, sub *pubsub.Subscription) bool {
+	defer sub.Close()
+	for {
+		select {
+		case <-ctx.Done():
+			return false
+		case _, ok := <-sub.Events():
+			if !ok {
+				return sub.Lagged()
+			}
+			d.Wake()
+		}
+	}
+}
+
+// deliverLoop sends due deliveries whenever some are enqueued and at every poll interval.
+func (d *Dispatcher) deliverLoop(ctx context.Context) {
+	ticker := time.NewTicker(d.PollInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := d.DeliverDue(ctx); err != nil && ctx.Err() == nil {
+			log.Printf("Failed to deliver webhooks: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-d.wake:
+		}
+	}
+}
+
+// Wake makes a running dispatcher check the queue for due deliveries now.
+func (d *Dispatcher) Wake() {
+	select {
+	case d.wake <- struct{}{}:
+	default:
+	}
+}
+
+// eventName returns the webhook event announcing an article saved as kind.
+func eventName(kind pubsub.EventKind) string {
+	return "article." + string(kind)
+}
+
+// Plan returns a delivery of every saved article to each of webhooks whose filter it matches,
+// implementing store.DeliveryPlanner. The payload is fixed now, so retries and replays send the
+// same body. Webhooks with an invalid filter are skipped.
+func (d *Dispatcher) Plan(webhooks []*models.Webhook, saved []store.SavedArticle) ([]*models.WebhookDelivery, error) {
+	filters := make(map[int]*Filter, len(webhooks))
+	for _, webhook := range webhooks {
+		filter, err := ParseFilter(webhook.Filter)
+		if err != nil {
+			log.Printf("Skipping webhook %d with an invalid filter: %v", webhook.ID, err)
+			continue
+		}
+		filters[webhook.ID] = filter
+	}
+
+	now := time.Now().UTC()
+	var deliveries []*models.WebhookDelivery
+	for _, result := range saved {
+		event := eventName(pubsub.Updated)
+		if result.Created {
+			event = eventName(pubsub.Created)
+		}
+		// Content is scraped page text, which receivers can fetch from the link if they need it.
+		article := *result.Article
+		article.Content = ""
+		for _, webhook := range webhooks {
+			filter, ok := filters[webhook.ID]
+			if !ok || !webhook.Active || !filter.Match(&article) {
+				continue
+			}
+			payload, err := json.Marshal(models.WebhookPayload{
+				Event:      event,
+				WebhookID:  webhook.ID,
+				OccurredAt: now,
+				Article:    &article,
+			})
+			if err != nil {
+				return nil, err
+			}
+			deliveries = append(deliveries, &models.WebhookDelivery{
+				WebhookID:     webhook.ID,
+				ArticleID:     article.ID,
+				Event:         event,
+				Payload:       payload,
+				Status:        models.DeliveryPending,
+				NextAttemptAt: now,
+			})
+		}
+	}
+	return deliveries, nil
+}
+
+// DeliverDue attempts every due delivery once and records the outcomes, returning the number of
+// attempts made. The deliveries of each webhook are sent in order, while up to Workers webhooks
+// are delivered to concurrently, so that a slow receiver holds up only its own deliveries within
+// a batch. Deliveries interrupted by the end of ctx are left as they were.
+func (d *Dispatcher) DeliverDue(ctx context.Context) (int, error) {
+	attempted := 0
+	for {
+		due, err := d.Store.DueDeliveries(ctx, time.Now().UTC(), deliveryBatch)
+		if err != nil {
+			return attempted, err
+		}
+		queues, err := d.queueByWebhook(ctx, due)
+		if err != nil {
+			return attempted, err
+		}
+		n, err := d.deliverQueues(ctx, queues)
+		attempted += n
+		if err != nil {
+			return attempted, err
+		}
+		if len(due) < deliveryBatch {
+			return attempted, nil
+		}
+	}
+}
+
+// webhookQueue holds due deliveries to one webhook, in the order they were enqueued.
+type webhookQueue struct {
+	webhook    *models.Webhook
+	deliveries []*models.WebhookDelivery
+}
+
+// queueByWebhook groups due by webhook, skipping the deliveries of webhooks deleted meanwhile.
+func (d *Dispatcher) queueByWebhook(ctx context.Context, due []*models.WebhookDelivery) ([]*webhookQueue, error) {
+	var queues []*webhookQueue
+	byWebhook := make(map[int]*webhookQueue)
+	for _, delivery := range due {
+		queue, ok := byWebhook[delivery.WebhookID]
+		if !ok {
+			webhook, err := d.Store.Webhook(ctx, delivery.WebhookID)
+			if errors.Is(err, store.ErrWebhookNotFound) {
+				byWebhook[delivery.WebhookID] = nil // Deleted meanwhile, together with its deliveries.
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			queue = &webhookQueue{webhook: webhook}
+			byWebhook[delivery.WebhookID] = queue
+			queues = append(queues, queue)
+		}
+		if queue != nil {
+			queue.deliveries = append(queue.deliveries, delivery)
+		}
+	}
+	return queues, nil
+}
+
+// deliverQueues delivers queues with up to Workers of them at a time, returning the number of
+// attempts made and the first error.
+func (d *Dispatcher) deliverQueues(ctx context.Context, queues []*webhookQueue) (int, error) {
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		attempted int
+		firstErr  error
+	)
+	slots := make(chan struct{}, max(d.Workers, 1))
+	for _, queue := range queues {
+		slots <- struct{}{}
+		wg.Add(1)
+		go func() {
+			defer func() {
+				<-slots
+				wg.Done()
+			}()
+			n, err := d.deliverQueue(ctx, queue)
+			mu.Lock()
+			defer mu.Unlock()
+			attempted += n
+			if err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}()
+	}
+	wg.Wait()
+	return attempted, firstErr
+}
+
+// deliverQueue attempts the deliveries of queue in order, returning the number of attempts made.
+func (d *Dispatcher) deliverQueue(ctx context.Context, queue *webhookQueue) (int, error) {
+	attempted := 0
+	for _, delivery := range queue.deliveries {
+		d.attempt(ctx, queue.webhook, delivery)
+		if err := ctx.Err(); err != nil {
+			return attempted, err
+		}
+		attempted++
+		if err := d.Store.UpdateDelivery(ctx, delivery); err != nil && !errors.Is(err, store.ErrDeliveryNotFound) {
+			return attempted, err
+		}
+	}
+	return attempted, nil
+}
+
+// attempt sends delivery to webhook and updates its status, attempt count, schedule and outcome.
+func (d *Dispatcher) attempt(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) {
+	delivery.Attempts++
+	status, err := d.post(ctx, webhook, delivery)
+	delivery.ResponseStatus = status
+	now := time.Now().UTC()
+	if err == nil {
+		delivery.Status = models.DeliveryDelivered
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if len(delivery.LastError) > maxErrorLength {
+		delivery.LastError = delivery.LastError[:maxErrorLength]
+	}
+	if delivery.Attempts >= d.MaxAttempts {
+		delivery.Status = models.DeliveryFailed
+		return
+	}
+	delivery.NextAttemptAt = now.Add(d.backoff(delivery.Attempts))
+}
+
+// post sends the payload of delivery to webhook, returning the response status, if any, and an
+// error unless the receiver answered with a 2xx status.
+func (d *Dispatcher) post(ctx context.Context, webhook *models.Webhook, delivery *models.WebhookDelivery) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(EventHeader, delivery.Event)
+	req.Header.Set(DeliveryHeader, strconv.Itoa(delivery.ID))
+	req.Header.Set(TimestampHeader, timestamp)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxResponseBody))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("unexpected response status %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
+
+// backoff returns the delay after the given number of failed attempts: Backoff after the first,
+// doubling with every further one, up to maxBackoff.
+func (d *Dispatcher) backoff(attempts int) time.Duration {
+	delay := d.Backoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	return min(delay, maxBackoff)
+}
+
+// Replay queues a delivery to be sent again now, whatever its status, with a fresh attempt count.
+func (d *Dispatcher) Replay(ctx context.Context, id int) (*models.WebhookDelivery, error) {
+	delivery, err := d.Store.Delivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	delivery.Status = models.DeliveryPending
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = time.Now().UTC()
+	delivery.LastError = ""
+	delivery.ResponseStatus = 0
+	delivery.DeliveredAt = nil
+	if err := d.Store.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	d.Wake()
+	return delivery, nil
+}
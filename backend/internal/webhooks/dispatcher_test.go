@@ -0,0 +1,321 @@
+package webhooks
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/config"
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/pubsub"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// receivedRequest is a webhook request recorded by a test receiver.
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// receiver is a local webhook endpoint answering with queued statuses, then 204 No Content.
+type receiver struct {
+	mu       sync.Mutex
+	statuses []int
+	requests []receivedRequest
+	received chan struct{}
+}
+
+// newReceiver starts a receiver answering its first requests with statuses.
+func newReceiver(t *testing.T, statuses ...int) (*receiver, *httptest.Server) {
+	rec := &receiver{statuses: statuses, received: make(chan struct{}, 16)}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		rec.mu.Lock()
+		rec.requests = append(rec.requests, receivedRequest{header: r.Header.Clone(), body: body})
+		status := http.StatusNoContent
+		if len(rec.statuses) > 0 {
+			status, rec.statuses = rec.statuses[0], rec.statuses[1:]
+		}
+		rec.mu.Unlock()
+		w.WriteHeader(status)
+		rec.received <- struct{}{}
+	}))
+	t.Cleanup(srv.Close)
+	return rec, srv
+}
+
+// all returns the requests received so far.
+func (rec *receiver) all() []receivedRequest {
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	return append([]receivedRequest(nil), rec.requests...)
+}
+
+// newTestDispatcher returns a dispatcher over an empty SQLite store with a webhook posting to url
+// with filter, and one article saved after the webhook was created.
+func newTestDispatcher(t *testing.T, url, filter string) (*Dispatcher, *models.Webhook, *models.Article) {
+	t.Helper()
+	s, err := store.NewSQLiteStore(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+	t.Cleanup(func() { s.Close() })
+
+	ctx := context.Background()
+	webhook := &models.Webhook{URL: url, Filter: filter, Secret: "s3cret", Active: true}
+	if err := s.CreateWebhook(ctx, webhook); err != nil {
+		t.Fatalf("CreateWebhook failed: %v", err)
+	}
+	cfg := &config.AppConfig{WebhookTimeout: time.Second, WebhookAttempts: 3, WebhookBackoff: time.Minute}
+	d := NewDispatcher(s, cfg)
+	article := &models.Article{HNID: 1, Title: "High Signal", Link: "https://go.dev/blog", Source: "Hacker News", Upvotes: models.NewNullableInt(300)}
+	if _, err := s.SaveArticles(ctx, []*models.Article{article}, store.SaveAtomic); err != nil {
+		t.Fatalf("SaveArticles failed: %v", err)
+	}
+	if article, err = s.ArticleByHNID(ctx, 1); err != nil {
+		t.Fatalf("ArticleByHNID failed: %v", err)
+	}
+	return d, webhook, article
+}
+
+// save stores articles in the store of d.
+func save(t *testing.T, d *Dispatcher, articles ...*models.Article) {
+	t.Helper()
+	if _, err := d.Store.(store.Store).SaveArticles(context.Background(), articles, store.SaveAtomic); err != nil {
+		t.Fatalf("SaveArticles failed: %v", err)
+	}
+}
+
+// deliveries returns the delivery log of webhook.
+func deliveries(t *testing.T, d *Dispatcher, webhook *models.Webhook) []*models.WebhookDelivery {
+	t.Helper()
+	entries, err := d.Store.Deliveries(context.Background(), store.DeliveryQuery{WebhookID: webhook.ID})
+	if err != nil {
+		t.Fatalf("Deliveries failed: %v", err)
+	}
+	return entries
+}
+
+// TestSignature tests that signatures verify only with the same secret, timestamp and body.
+func TestSignature(t *testing.T) {
+	body := []byte(`{"event":"article.created"}`)
+	signature := Sign("s3cret", "1700000000", body)
+	if !Verify("s3cret", "1700000000", body, signature) {
+		t.Error("Expected the signature to verify")
+	}
+	for name, ok := range map[string]bool{
+		"secret":    Verify("other", "1700000000", body, signature),
+		"timestamp": Verify("s3cret", "1700000001", body, signature),
+		"body":      Verify("s3cret", "1700000000", []byte(`{}`), signature),
+		"prefix":    Verify("s3cret", "1700000000", body, signature[len("sha256="):]),
+	} {
+		if ok {
+			t.Errorf("Expected a different %s to fail verification", name)
+		}
+	}
+}
+
+// TestDispatcher_DeliversSignedPayload tests that matching articles are POSTed once with a signed payload.
+func TestDispatcher_DeliversSignedPayload(t *testing.T) {
+	rec, srv := newReceiver(t)
+	d, webhook, article := newTestDispatcher(t, srv.URL, "min_upvotes>=200 AND NOT flagged")
+	ctx := context.Background()
+
+	// Saving the article again and saving a flagged one enqueue nothing more.
+	again := *article
+	save(t, d, &again, &models.Article{HNID: 2, Title: "Flagged", Source: "Hacker News", Upvotes: models.NewNullableInt(300), Flagged: true})
+	if entries := deliveries(t, d, webhook); len(entries) != 1 || entries[0].ArticleID != article.ID {
+		t.Fatalf("Expected 1 delivery of article %d enqueued by the save, got %+v", article.ID, entries)
+	}
+
+	if n, err := d.DeliverDue(ctx); err != nil || n != 1 {
+		t.Fatalf("Expected 1 attempt, got %d (%v)", n, err)
+	}
+	requests := rec.all()
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(requests))
+	}
+	req := requests[0]
+	if !Verify(webhook.Secret, req.header.Get(TimestampHeader), req.body, req.header.Get(SignatureHeader)) {
+		t.Errorf("Expected a valid signature, got %q", req.header.Get(SignatureHeader))
+	}
+	if req.header.Get(EventHeader) != "article.created" || req.header.Get("Content-Type") != "application/json" {
+		t.Errorf("Unexpected headers: %v", req.header)
+	}
+	var payload models.WebhookPayload
+	if err := json.Unmarshal(req.body, &payload); err != nil {
+		t.Fatalf("Failed to decode payload: %v", err)
+	}
+	if payload.Event != "article.created" || payload.WebhookID != webhook.ID || payload.Article == nil || payload.Article.ID != article.ID {
+		t.Errorf("Unexpected payload: %s", req.body)
+	}
+
+	entries := deliveries(t, d, webhook)
+	if len(entries) != 1 || entries[0].Status != models.DeliveryDelivered || entries[0].Attempts != 1 || entries[0].ResponseStatus != http.StatusNoContent || entries[0].DeliveredAt == nil {
+		t.Fatalf("Unexpected delivery log: %+v", entries)
+	}
+	if req.header.Get(DeliveryHeader) != strconv.Itoa(entries[0].ID) {
+		t.Errorf("Expected delivery ID %d, got %q", entries[0].ID, req.header.Get(DeliveryHeader))
+	}
+	if n, err := d.DeliverDue(ctx); err != nil || n != 0 {
+		t.Errorf("Expected nothing left to deliver, got %d (%v)", n, err)
+	}
+}
+
+// TestDispatcher_RetriesWithBackoff tests that failed deliveries are retried after doubling delays
+// and marked failed once the attempts run out.
+func TestDispatcher_RetriesWithBackoff(t *testing.T) {
+	rec, srv := newReceiver(t, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable)
+	d, webhook, _ := newTestDispatcher(t, srv.URL, "")
+	ctx := context.Background()
+
+	for attempt, delay := range []time.Duration{time.Minute, 2 * time.Minute} {
+		before := time.Now()
+		if n, err := d.DeliverDue(ctx); err != nil || n != 1 {
+			t.Fatalf("Attempt %d: expected 1 attempt, got %d (%v)", attempt+1, n, err)
+		}
+		delivery := deliveries(t, d, webhook)[0]
+		if delivery.Status != models.DeliveryPending || delivery.Attempts != attempt+1 || delivery.LastError == "" {
+			t.Fatalf("Attempt %d: unexpected delivery %+v", attempt+1, delivery)
+		}
+		if next := delivery.NextAttemptAt.Sub(before); next < delay-time.Second || next > delay+time.Second {
+			t.Errorf("Attempt %d: expected a retry in %s, got %s", attempt+1, delay, next)
+		}
+		if n, err := d.DeliverDue(ctx); err != nil || n != 0 {
+			t.Fatalf("Attempt %d: expected no attempt before the backoff, got %d (%v)", attempt+1, n, err)
+		}
+
+		// Let the backoff elapse.
+		delivery.NextAttemptAt = time.Now().UTC().Add(-time.Second)
+		if err := d.Store.UpdateDelivery(ctx, delivery); err != nil {
+			t.Fatalf("UpdateDelivery failed: %v", err)
+		}
+	}
+
+	if n, err := d.DeliverDue(ctx); err != nil || n != 1 {
+		t.Fatalf("Expected a final attempt, got %d (%v)", n, err)
+	}
+	delivery := deliveries(t, d, webhook)[0]
+	if delivery.Status != models.DeliveryFailed || delivery.Attempts != 3 || delivery.ResponseStatus != http.StatusServiceUnavailable {
+		t.Errorf("Expected a failed delivery after 3 attempts, got %+v", delivery)
+	}
+	if len(rec.all()) != 3 {
+		t.Errorf("Expected 3 requests, got %d", len(rec.all()))
+	}
+}
+
+// TestDispatcher_Backoff tests that delays double per attempt up to the cap.
+func TestDispatcher_Backoff(t *testing.T) {
+	d := &Dispatcher{Backoff: 30 * time.Second}
+	for attempts, expected := range map[int]time.Duration{1: 30 * time.Second, 2: time.Minute, 4: 4 * time.Minute, 40: maxBackoff} {
+		if got := d.backoff(attempts); got != expected {
+			t.Errorf("backoff(%d) = %s, want %s", attempts, got, expected)
+		}
+	}
+}
+
+// TestDispatcher_DeliversConcurrently tests that a slow receiver does not hold up other webhooks.
+func TestDispatcher_DeliversConcurrently(t *testing.T) {
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	t.Cleanup(slow.Close)
+	defer close(release)
+	rec, fast := newReceiver(t)
+	d, _, _ := newTestDispatcher(t, slow.URL, "")
+	ctx := context.Background()
+	if err := d.Store.CreateWebhook(ctx, &models.Webhook{URL: fast.URL, Secret: "s3cret", Active: true}); err != nil {
+		t.Fatalf("CreateWebhook failed: %v", err)
+	}
+	save(t, d, &models.Article{HNID: 2, Title: "Second", Source: "Hacker News"})
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		d.DeliverDue(ctx)
+	}()
+	select {
+	case <-rec.received:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the fast receiver")
+	}
+	release <- struct{}{}
+	release <- struct{}{}
+	<-done
+}
+
+// TestDispatcher_Replay tests that replayed deliveries are sent again with the same ID and body.
+func TestDispatcher_Replay(t *testing.T) {
+	rec, srv := newReceiver(t)
+	d, webhook, _ := newTestDispatcher(t, srv.URL, "")
+	ctx := context.Background()
+	if _, err := d.DeliverDue(ctx); err != nil {
+		t.Fatalf("DeliverDue failed: %v", err)
+	}
+
+	delivery := deliveries(t, d, webhook)[0]
+	replayed, err := d.Replay(ctx, delivery.ID)
+	if err != nil || replayed.Status != models.DeliveryPending || replayed.Attempts != 0 || replayed.DeliveredAt != nil {
+		t.Fatalf("Expected a pending delivery, got %+v (%v)", replayed, err)
+	}
+	if n, err := d.DeliverDue(ctx); err != nil || n != 1 {
+		t.Fatalf("Expected the replay to be attempted, got %d (%v)", n, err)
+	}
+	requests := rec.all()
+	if len(requests) != 2 || string(requests[0].body) != string(requests[1].body) || requests[0].header.Get(DeliveryHeader) != requests[1].header.Get(DeliveryHeader) {
+		t.Errorf("Expected the same delivery twice, got %d requests", len(requests))
+	}
+	if _, err := d.Replay(ctx, delivery.ID+1); !errors.Is(err, store.ErrDeliveryNotFound) {
+		t.Errorf("Expected ErrDeliveryNotFound, got %v", err)
+	}
+}
+
+// TestDispatcher_Run tests that articles saved through a publishing store reach the receiver as
+// soon as they are announced, without waiting for the poll interval.
+func TestDispatcher_Run(t *testing.T) {
+	rec, srv := newReceiver(t)
+	d, _, _ := newTestDispatcher(t, srv.URL, "upvotes >= 100")
+	if n, err := d.DeliverDue(context.Background()); err != nil || n != 1 {
+		t.Fatalf("Expected the article saved by the setup to be delivered, got %d (%v)", n, err)
+	}
+	<-rec.received
+	d.PollInterval = time.Hour
+	hub := pubsub.NewHub()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		d.Run(ctx, hub)
+		close(done)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+	for !hub.HasSubscribers() {
+		time.Sleep(time.Millisecond)
+	}
+
+	publishing := pubsub.NewPublishingStore(d.Store.(store.Store), hub)
+	article := &models.Article{HNID: 2, Title: "Fresh", Link: "https://example.com/fresh", Source: "Hacker News", Upvotes: models.NewNullableInt(150)}
+	if _, err := publishing.SaveArticles(ctx, []*models.Article{article}, store.SaveAtomic); err != nil {
+		t.Fatalf("SaveArticles failed: %v", err)
+	}
+	select {
+	case <-rec.received:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Timed out waiting for the delivery")
+	}
+	var payload models.WebhookPayload
+	if err := json.Unmarshal(rec.all()[1].body, &payload); err != nil || payload.Article == nil || payload.Article.HNID != 2 {
+		t.Errorf("Expected the saved article, got %+v (%v)", payload, err)
+	}
+}
@@ -0,0 +1,346 @@
+package webhooks
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// ErrInvalidFilter is wrapped by the errors of filter expressions that cannot be parsed.
+var ErrInvalidFilter = errors.New("invalid filter")
+
+// Filter is a parsed filter expression selecting the articles delivered to a webhook, such as
+//
+//	min_upvotes>=200 AND NOT flagged
+//	(domain = github.com OR title ~ "golang") AND comments > 50
+//
+// An expression combines conditions with AND, OR, NOT and parentheses, binding in that order from
+// tightest to loosest: NOT, AND, OR. Keywords are case-insensitive. A condition is one of
+//
+//   - a number field compared with =, !=, <, <=, > or >= to an integer: upvotes, comments and rank.
+//     Unknown upvotes and comment counts compare as 0. The list parameter names min_upvotes,
+//     max_upvotes, min_comments and max_comments are accepted for upvotes and comments.
+//   - a text field compared with = or != for case-insensitive equality, or with ~ or !~ for
+//     case-insensitive containment: domain, source, model, title and link. Values containing
+//     spaces or operators are quoted with double or single quotes.
+//   - a flag on its own, or compared with = or != to true or false: flagged, dead, dupe and
+//     summarized.
+//
+// The empty expression matches every article.
+type Filter struct {
+	expr string
+	root node
+}
+
+// node is a parsed subexpression.
+type node func(article *models.Article) bool
+
+// ParseFilter parses expr, returning an error wrapping ErrInvalidFilter if it is malformed.
+func ParseFilter(expr string) (*Filter, error) {
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return nil, err
+	}
+	f := &Filter{expr: strings.TrimSpace(expr)}
+	if len(tokens) == 0 {
+		return f, nil
+	}
+	p := &parser{tokens: tokens}
+	if f.root, err = p.or(); err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, p.errorf("unexpected %s", p.tokens[p.pos])
+	}
+	return f, nil
+}
+
+// Match reports whether article satisfies the filter.
+func (f *Filter) Match(article *models.Article) bool {
+	return f.root == nil || f.root(article)
+}
+
+// String returns the expression the filter was parsed from.
+func (f *Filter) String() string {
+	return f.expr
+}
+
+// tokenKind classifies the tokens of a filter expression.
+type tokenKind int
+
+const (
+	tokenWord   tokenKind = iota // Field name, keyword, number or unquoted value.
+	tokenString                  // Quoted value.
+	tokenOp                      // Comparison operator.
+	tokenLParen
+	tokenRParen
+)
+
+// token is a lexical element of a filter expression and its byte offset.
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// String describes t for error messages.
+func (t token) String() string {
+	if t.kind == tokenString {
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// operators lists the comparison operators, longest first so that "<=" is not read as "<".
+var operators = []string{">=", "<=", "!=", "!~", "==", "=", "<", ">", "~"}
+
+// tokenize splits expr into tokens.
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{tokenLParen, "(", i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{tokenRParen, ")", i})
+			i++
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(expr[i+1:], c)
+			if end < 0 {
+				return nil, fmt.Errorf("%w: unterminated string at offset %d", ErrInvalidFilter, i)
+			}
+			tokens = append(tokens, token{tokenString, expr[i+1 : i+1+end], i})
+			i += end + 2
+		case strings.ContainsRune("=!<>~", rune(c)):
+			op := ""
+			for _, candidate := range operators {
+				if strings.HasPrefix(expr[i:], candidate) {
+					op = candidate
+					break
+				}
+			}
+			if op == "" {
+				return nil, fmt.Errorf("%w: unknown operator at offset %d", ErrInvalidFilter, i)
+			}
+			tokens = append(tokens, token{tokenOp, op, i})
+			i += len(op)
+		default:
+			start := i
+			for i < len(expr) && !strings.ContainsRune(" \t\n\r()\"'=!<>~", rune(expr[i])) {
+				i++
+			}
+			tokens = append(tokens, token{tokenWord, expr[start:i], start})
+		}
+	}
+	return tokens, nil
+}
+
+// parser is a recursive descent parser over the tokens of a filter expression.
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// errorf returns an ErrInvalidFilter error located at the current token.
+func (p *parser) errorf(format string, args ...interface{}) error {
+	where := "at end of filter"
+	if p.pos < len(p.tokens) {
+		where = fmt.Sprintf("at offset %d", p.tokens[p.pos].pos)
+	}
+	return fmt.Errorf("%w: %s %s", ErrInvalidFilter, fmt.Sprintf(format, args...), where)
+}
+
+// keyword consumes the next token if it is the case-insensitive keyword word.
+func (p *parser) keyword(word string) bool {
+	if p.pos < len(p.tokens) && p.tokens[p.pos].kind == tokenWord && strings.EqualFold(p.tokens[p.pos].text, word) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// or parses operands joined by OR.
+func (p *parser) or() (node, error) {
+	left, err := p.and()
+	for err == nil && p.keyword("OR") {
+		var right node
+		if right, err = p.and(); err == nil {
+			l := left
+			left = func(a *models.Article) bool { return l(a) || right(a) }
+		}
+	}
+	return left, err
+}
+
+// and parses operands joined by AND.
+func (p *parser) and() (node, error) {
+	left, err := p.unary()
+	for err == nil && p.keyword("AND") {
+		var right node
+		if right, err = p.unary(); err == nil {
+			l := left
+			left = func(a *models.Article) bool { return l(a) && right(a) }
+		}
+	}
+	return left, err
+}
+
+// unary parses an operand, possibly negated with NOT.
+func (p *parser) unary() (node, error) {
+	if p.keyword("NOT") {
+		operand, err := p.unary()
+		if err != nil {
+			return nil, err
+		}
+		return func(a *models.Article) bool { return !operand(a) }, nil
+	}
+	return p.primary()
+}
+
+// primary parses a parenthesized expression or a condition.
+func (p *parser) primary() (node, error) {
+	if p.pos >= len(p.tokens) {
+		return nil, p.errorf("expected a condition")
+	}
+	tok := p.tokens[p.pos]
+	switch tok.kind {
+	case tokenLParen:
+		p.pos++
+		inner, err := p.or()
+		if err != nil {
+			return nil, err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenRParen {
+			return nil, p.errorf("expected %q", ")")
+		}
+		p.pos++
+		return inner, nil
+	case tokenWord:
+		if isKeyword(tok.text) {
+			return nil, p.errorf("expected a condition, got %s", tok)
+		}
+		p.pos++
+		return p.condition(strings.ToLower(tok.text))
+	default:
+		return nil, p.errorf("expected a condition, got %s", tok)
+	}
+}
+
+// isKeyword reports whether word is one of the reserved words of the filter language.
+func isKeyword(word string) bool {
+	return strings.EqualFold(word, "AND") || strings.EqualFold(word, "OR") || strings.EqualFold(word, "NOT")
+}
+
+// Fields of the filter language, keyed by name and alias.
+var (
+	numberFields = map[string]func(*models.Article) int64{
+		"upvotes":       func(a *models.Article) int64 { return a.Upvotes.Int64 },
+		"min_upvotes":   func(a *models.Article) int64 { return a.Upvotes.Int64 },
+		"max_upvotes":   func(a *models.Article) int64 { return a.Upvotes.Int64 },
+		"comments":      func(a *models.Article) int64 { return a.CommentCount.Int64 },
+		"comment_count": func(a *models.Article) int64 { return a.CommentCount.Int64 },
+		"min_comments":  func(a *models.Article) int64 { return a.CommentCount.Int64 },
+		"max_comments":  func(a *models.Article) int64 { return a.CommentCount.Int64 },
+		"rank":          func(a *models.Article) int64 { return int64(a.ArticleRank) },
+		"article_rank":  func(a *models.Article) int64 { return int64(a.ArticleRank) },
+	}
+	textFields = map[string]func(*models.Article) string{
+		"domain":     func(a *models.Article) string { return a.Domain },
+		"source":     func(a *models.Article) string { return a.Source },
+		"model":      func(a *models.Article) string { return a.ModelName },
+		"model_name": func(a *models.Article) string { return a.ModelName },
+		"title":      func(a *models.Article) string { return a.Title },
+		"link":       func(a *models.Article) string { return a.Link },
+	}
+	flagFields = map[string]func(*models.Article) bool{
+		"flagged":    func(a *models.Article) bool { return a.Flagged },
+		"dead":       func(a *models.Article) bool { return a.Dead },
+		"dupe":       func(a *models.Article) bool { return a.Dupe },
+		"summarized": func(a *models.Article) bool { return a.Summary.Valid && a.Summary.String != "" },
+	}
+)
+
+// condition parses the rest of a condition on field.
+func (p *parser) condition(field string) (node, error) {
+	flag, isFlag := flagFields[field]
+	if isFlag && (p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenOp) {
+		return func(a *models.Article) bool { return flag(a) }, nil
+	}
+	number, isNumber := numberFields[field]
+	text, isText := textFields[field]
+	if !isFlag && !isNumber && !isText {
+		p.pos--
+		return nil, p.errorf("unknown field %q", field)
+	}
+
+	if p.pos >= len(p.tokens) || p.tokens[p.pos].kind != tokenOp {
+		return nil, p.errorf("expected an operator after %q", field)
+	}
+	op := p.tokens[p.pos].text
+	if op == "==" {
+		op = "="
+	}
+	p.pos++
+	if p.pos >= len(p.tokens) || (p.tokens[p.pos].kind != tokenWord && p.tokens[p.pos].kind != tokenString) {
+		return nil, p.errorf("expected a value after %q", op)
+	}
+	value := p.tokens[p.pos]
+
+	switch {
+	case isFlag:
+		want, err := strconv.ParseBool(value.text)
+		if err != nil || (op != "=" && op != "!=") {
+			return nil, p.errorf("%q compares with = or != to true or false", field)
+		}
+		p.pos++
+		if op == "!=" {
+			want = !want
+		}
+		return func(a *models.Article) bool { return flag(a) == want }, nil
+	case isNumber:
+		n, err := strconv.ParseInt(value.text, 10, 64)
+		if err != nil || value.kind != tokenWord {
+			return nil, p.errorf("%q compares to an integer, got %s", field, value)
+		}
+		cmp, ok := numberComparisons[op]
+		if !ok {
+			return nil, p.errorf("%q does not support %q", field, op)
+		}
+		p.pos++
+		return func(a *models.Article) bool { return cmp(number(a), n) }, nil
+	default:
+		want := strings.ToLower(value.text)
+		var match func(string) bool
+		switch op {
+		case "=":
+			match = func(s string) bool { return strings.ToLower(s) == want }
+		case "!=":
+			match = func(s string) bool { return strings.ToLower(s) != want }
+		case "~":
+			match = func(s string) bool { return strings.Contains(strings.ToLower(s), want) }
+		case "!~":
+			match = func(s string) bool { return !strings.Contains(strings.ToLower(s), want) }
+		default:
+			return nil, p.errorf("%q does not support %q", field, op)
+		}
+		p.pos++
+		return func(a *models.Article) bool { return match(text(a)) }, nil
+	}
+}
+
+// numberComparisons implements the operators of number fields.
+var numberComparisons = map[string]func(a, b int64) bool{
+	"=":  func(a, b int64) bool { return a == b },
+	"!=": func(a, b int64) bool { return a != b },
+	"<":  func(a, b int64) bool { return a < b },
+	"<=": func(a, b int64) bool { return a <= b },
+	">":  func(a, b int64) bool { return a > b },
+	">=": func(a, b int64) bool { return a >= b },
+}
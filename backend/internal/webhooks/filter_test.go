@@ -0,0 +1,89 @@
+package webhooks
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+)
+
+// TestParseFilter_Match tests evaluating filter expressions against an article.
+func TestParseFilter_Match(t *testing.T) {
+	article := &models.Article{
+		Title:        "Go 1.26 is released",
+		Link:         "https://go.dev/blog/go1.26",
+		Domain:       "go.dev",
+		Source:       "Hacker News",
+		ModelName:    "qwen3:8b",
+		ArticleRank:  3,
+		Upvotes:      models.NewNullableInt(250),
+		CommentCount: models.NewNullableInt(80),
+		Summary:      models.NewNullableString("A summary."),
+		Dupe:         true,
+	}
+	tests := []struct {
+		expr     string
+		expected bool
+	}{
+		{"", true},
+		{"min_upvotes>=200 AND NOT flagged", true},
+		{"upvotes > 250", false},
+		{"upvotes = 250 and comments < 100", true},
+		{"rank <= 2 OR domain = GO.DEV", true},
+		{"rank <= 2 OR domain != go.dev", false},
+		{"NOT (flagged OR dead) AND dupe", true},
+		{"dupe = false", false},
+		{"flagged != true AND summarized", true},
+		{`title ~ "go 1.26" AND model == 'qwen3:8b'`, true},
+		{"title !~ release", false},
+		{"link ~ go.dev/blog AND source = 'hacker news'", true},
+		{"upvotes >= 100 OR upvotes >= 1000 AND flagged", true},
+		{"(upvotes >= 100 OR upvotes >= 1000) AND flagged", false},
+		{"max_comments < 50", false},
+	}
+	for _, tc := range tests {
+		filter, err := ParseFilter(tc.expr)
+		if err != nil {
+			t.Errorf("ParseFilter(%q) failed: %v", tc.expr, err)
+			continue
+		}
+		if got := filter.Match(article); got != tc.expected {
+			t.Errorf("ParseFilter(%q).Match = %v, want %v", tc.expr, got, tc.expected)
+		}
+	}
+}
+
+// TestParseFilter_UnknownUpvotes tests that articles without upvotes compare as having none.
+func TestParseFilter_UnknownUpvotes(t *testing.T) {
+	filter, err := ParseFilter("upvotes < 1")
+	if err != nil {
+		t.Fatalf("ParseFilter failed: %v", err)
+	}
+	if !filter.Match(&models.Article{}) {
+		t.Error("Expected unknown upvotes to compare as 0")
+	}
+}
+
+// TestParseFilter_Invalid tests that malformed expressions are rejected with ErrInvalidFilter.
+func TestParseFilter_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		"score > 5",
+		"upvotes >",
+		"upvotes > many",
+		"upvotes ~ 5",
+		"domain < go.dev",
+		"flagged = maybe",
+		"flagged AND",
+		"(upvotes > 5",
+		"upvotes > 5)",
+		"NOT",
+		"title = 'unterminated",
+		"upvotes > 5 comments > 5",
+		"upvotes ! 5",
+		"AND flagged",
+	} {
+		if _, err := ParseFilter(expr); !errors.Is(err, ErrInvalidFilter) {
+			t.Errorf("ParseFilter(%q): expected ErrInvalidFilter, got %v", expr, err)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Headers of webhook requests.
+const (
+	// SignatureHeader carries "sha256=" followed by the hex HMAC-SHA256 of the timestamp, a dot
+	// and the body, keyed with the webhook's secret.
+	SignatureHeader = "X-GopherSignal-Signature"
+	// TimestampHeader carries the Unix time of the attempt, letting receivers reject replays.
+	TimestampHeader = "X-GopherSignal-Timestamp"
+	// EventHeader carries the event of the payload, "article.created" or "article.updated".
+	EventHeader = "X-GopherSignal-Event"
+	// DeliveryHeader carries the delivery ID, which stays the same across retries.
+	DeliveryHeader = "X-GopherSignal-Delivery"
+)
+
+// signaturePrefix names the algorithm of a signature.
+const signaturePrefix = "sha256="
+
+// Sign returns the signature of a request with the given timestamp and body.
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify reports whether signature is the signature of timestamp and body, comparing in constant time.
+func Verify(secret, timestamp string, body []byte, signature string) bool {
+	if !strings.HasPrefix(signature, signaturePrefix) {
+		return false
+	}
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
+
+// NewSecret generates a random signing secret.
+func NewSecret() (string, error) {
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		return "", err
+	}
+	return "whsec_" + hex.EncodeToString(key), nil
+}
@@ -3,32 +3,72 @@
 // @description API server for the GopherSignal application.
 // @version 1
 // @BasePath /api/v1
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description "Bearer <ADMIN_TOKEN>" for the admin API, "Bearer <INGEST_TOKEN>" for article ingest
 package main
 
 import (
+	"context"
 	"log"
+	"os"
 
 	"github.com/k-zehnder/gophersignal/backend/config"
 	"github.com/k-zehnder/gophersignal/backend/internal/api/router"
 	"github.com/k-zehnder/gophersignal/backend/internal/api/server"
+	"github.com/k-zehnder/gophersignal/backend/internal/pubsub"
 	"github.com/k-zehnder/gophersignal/backend/internal/store"
+	"github.com/k-zehnder/gophersignal/backend/internal/webhooks"
 )
 
-// main initializes and launches the API server.
+// main initializes and launches the API server, or runs the
+// "migrate" subcommand when invoked as "main migrate ...".
 func main() {
 	// Load server configuration
 	cfg := config.NewConfig()
 
 	// Initialize the database store
-	store, err := store.NewMySQLStore(cfg.DataSourceName)
+	articleStore, err := store.Open(cfg.DataSourceName)
 	if err != nil {
 		log.Fatalf("Failed to create store: %v", err)
 	}
 
-	// Create the router
-	router := router.NewRouter(store, cfg)
+	// Run schema migrations instead of the server if requested
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(context.Background(), articleStore, os.Args[2:], os.Stdout); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
+	// Refuse to serve against an outdated schema if configured
+	if cfg.RequireSchema {
+		if err := checkSchema(context.Background(), articleStore); err != nil {
+			log.Fatalf("Database schema is not current: %v", err)
+		}
+	}
+
+	// Key, snapshot and record the domains of articles written around the store, such as by older scrapers
+	if err := backfill(context.Background(), articleStore, os.Stdout); err != nil {
+		log.Printf("Failed to backfill articles: %v", err)
+	}
+
+	// Enqueue webhook deliveries with every save and deliver them, if the store keeps webhooks
+	hub := pubsub.NewHub()
+	ctx, stop := context.WithCancel(context.Background())
+	var dispatcher *webhooks.Dispatcher
+	if webhookStore, ok := articleStore.(store.WebhookStore); ok {
+		dispatcher = webhooks.NewDispatcher(webhookStore, cfg)
+		go dispatcher.Run(ctx, hub)
+	}
+
+	// Create the router, announcing saved articles to streaming clients through the hub
+	router := router.NewRouter(articleStore, hub, dispatcher, cfg)
 
-	// Start the HTTP server
+	// Start the HTTP server, ending article streams and webhook delivery when it shuts down
 	srv := server.StartServer(cfg.ServerAddress, router)
+	srv.RegisterOnShutdown(hub.Close)
+	srv.RegisterOnShutdown(stop)
 	defer server.GracefulShutdown(srv)
 }
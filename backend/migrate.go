@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/migrations"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// migrateUsage describes the migrate subcommand.
+const migrateUsage = "usage: migrate up|down|status|to <version>"
+
+// migratorFor returns the Migrator of s, or an error if its schema is not migration-managed.
+func migratorFor(s store.Store) (*migrations.Migrator, error) {
+	migratable, ok := s.(store.Migratable)
+	if !ok {
+		return nil, fmt.Errorf("store %T does not support migrations", s)
+	}
+	return migratable.Migrator()
+}
+
+// runMigrate executes the migrate subcommand against s, writing progress to out.
+func runMigrate(ctx context.Context, s store.Store, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	migrator, err := migratorFor(s)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		err = migrator.Up(ctx)
+	case "down":
+		err = migrator.Down(ctx)
+	case "to":
+		if len(args) != 2 {
+			return errors.New(migrateUsage)
+		}
+		target, convErr := strconv.Atoi(args[1])
+		if convErr != nil {
+			return fmt.Errorf("invalid target version %q", args[1])
+		}
+		err = migrator.To(ctx, target)
+	case "status":
+		return printMigrationStatus(ctx, migrator, out)
+	default:
+		return errors.New(migrateUsage)
+	}
+	if err != nil {
+		return err
+	}
+	if err := backfill(ctx, s, out); err != nil {
+		return err
+	}
+
+	version, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Schema is at version %d of %d\n", version, migrator.Latest())
+	return nil
+}
+
+// backfill fills in the article keys and domains that migrations leave to Go, and the snapshots
+// of articles written around the store, reporting the articles updated to out. Each only touches
+// articles stored without them, so it is cheap to run at every start, which also covers articles
+// written around the API by older scrapers.
+func backfill(ctx context.Context, s store.Store, out io.Writer) error {
+	if backfiller, ok := s.(store.ArticleKeyBackfiller); ok {
+		keyed, err := backfiller.BackfillArticleKeys(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to backfill article keys: %w", err)
+		}
+		if keyed > 0 {
+			fmt.Fprintf(out, "Keyed %d articles saved without an article key\n", keyed)
+		}
+	}
+	if backfiller, ok := s.(store.SnapshotBackfiller); ok {
+		recorded, err := backfiller.BackfillSnapshots(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to backfill article snapshots: %w", err)
+		}
+		if recorded > 0 {
+			fmt.Fprintf(out, "Recorded snapshots of %d articles written without one\n", recorded)
+		}
+	}
+	if backfiller, ok := s.(store.DomainBackfiller); ok {
+		updated, err := backfiller.BackfillDomains(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to backfill article domains: %w", err)
+		}
+		if updated > 0 {
+			fmt.Fprintf(out, "Recorded the domains of %d articles\n", updated)
+		}
+	}
+	return nil
+}
+
+// printMigrationStatus writes a table of every known migration and whether it has been applied.
+func printMigrationStatus(ctx context.Context, migrator *migrations.Migrator, out io.Writer) error {
+	statuses, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	w := tabwriter.NewWriter(out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		state, appliedAt := "pending", "-"
+		if status.Applied {
+			state, appliedAt = "applied", status.AppliedAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	return w.Flush()
+}
+
+// checkSchema returns an error if s has pending migrations.
+// Stores without managed schemas are always considered current.
+func checkSchema(ctx context.Context, s store.Store) error {
+	if _, ok := s.(store.Migratable); !ok {
+		return nil
+	}
+	migrator, err := migratorFor(s)
+	if err != nil {
+		return err
+	}
+	version, err := migrator.Version(ctx)
+	if err != nil {
+		return err
+	}
+	if version < migrator.Latest() {
+		return fmt.Errorf("schema is at version %d but version %d is required; run \"migrate up\"", version, migrator.Latest())
+	}
+	return nil
+}
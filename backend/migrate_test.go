@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/k-zehnder/gophersignal/backend/internal/models"
+	"github.com/k-zehnder/gophersignal/backend/internal/store"
+)
+
+// TestRunMigrate exercises the migrate subcommand against an embedded SQLite store.
+func TestRunMigrate(t *testing.T) {
+	ctx := context.Background()
+	s, err := store.NewSQLiteStore(filepath.Join(t.TempDir(), "migrate.db"))
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+	defer s.Close()
+
+	var out bytes.Buffer
+	if err := runMigrate(ctx, s, []string{"to", "0"}, &out); err != nil {
+		t.Fatalf("migrate to 0 failed: %v", err)
+	}
+	if err := checkSchema(ctx, s); err == nil {
+		t.Fatal("Expected checkSchema to fail with pending migrations")
+	}
+
+	out.Reset()
+	if err := runMigrate(ctx, s, []string{"status"}, &out); err != nil {
+		t.Fatalf("migrate status failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "pending") || strings.Contains(out.String(), "applied") {
+		t.Errorf("Expected only pending migrations, got:\n%s", out.String())
+	}
+
+	if err := runMigrate(ctx, s, []string{"up"}, &out); err != nil {
+		t.Fatalf("migrate up failed: %v", err)
+	}
+	if err := checkSchema(ctx, s); err != nil {
+		t.Fatalf("Expected current schema after migrate up, got %v", err)
+	}
+
+	for _, args := range [][]string{nil, {"sideways"}, {"to"}, {"to", "latest"}} {
+		if err := runMigrate(ctx, s, args, &out); err == nil {
+			t.Errorf("Expected error for arguments %q", args)
+		}
+	}
+}
+
+// TestBackfill verifies that articles written around the store, as older scrapers did, are keyed
+// and given their domain.
+func TestBackfill(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "backfill.db")
+	s, err := store.NewSQLiteStore(path)
+	if err != nil {
+		t.Fatalf("NewSQLiteStore failed: %v", err)
+	}
+	defer s.Close()
+	article := &models.Article{HNID: 1, Title: "Scraped", Link: "https://blog.golang.org/go1"}
+	if _, err := s.SaveArticles(ctx, []*models.Article{article}, store.SaveAtomic); err != nil {
+		t.Fatalf("SaveArticles failed: %v", err)
+	}
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+	if _, err := db.Exec("UPDATE articles SET article_key = NULL, domain = ''"); err != nil {
+		t.Fatalf("Failed to clear article: %v", err)
+	}
+
+	var out bytes.Buffer
+	if err := backfill(ctx, s, &out); err != nil {
+		t.Fatalf("backfill failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "Keyed 1 articles") || !strings.Contains(out.String(), "domains of 1 articles") {
+		t.Errorf("Expected the article to be keyed and given its domain, got:\n%s", out.String())
+	}
+	if stored, err := s.ArticleByHNID(ctx, 1); err != nil || stored.Domain != "golang.org" {
+		t.Errorf("Expected domain golang.org, got %+v (%v)", stored, err)
+	}
+}
+
+// TestCheckSchema_UnmanagedStore verifies that stores without migrations are always current.
+func TestCheckSchema_UnmanagedStore(t *testing.T) {
+	if err := checkSchema(context.Background(), store.NewMockStore(nil, nil, nil)); err != nil {
+		t.Fatalf("Expected no error, got %v", err)
+	}
+}
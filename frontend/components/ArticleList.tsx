@@ -4,8 +4,9 @@ import ArticleListItem from './ArticleListItem';
 import List from '@mui/joy/List';
 import { Article } from '../types';
 
-function ArticleList() {
-  const articles: Article[] = useArticles();
+// ArticleList renders the latest articles, optionally only those created since a time or duration ago.
+function ArticleList({ since }: { since?: string }) {
+  const articles: Article[] = useArticles(since);
 
   if (!Array.isArray(articles)) {
     return <div>No articles available</div>;
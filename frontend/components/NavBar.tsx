@@ -20,6 +20,8 @@ const rssUrl =
 // Define navigation links for the NavBar.
 const navLinks = [
   { name: 'Home', path: '/' },
+  { name: 'Daily', path: '/daily' },
+  { name: 'Weekly', path: '/weekly' },
   { name: 'About', path: '/about' },
   { name: 'API', path: apiUrl },
   { name: 'RSS', path: rssUrl },
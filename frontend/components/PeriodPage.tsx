@@ -0,0 +1,43 @@
+import React from 'react';
+import Layout from './Layout';
+import ArticleList from './ArticleList';
+import Typography from '@mui/joy/Typography';
+import Footer from './Footer';
+
+type PeriodPageProps = {
+  title: string; // Heading of the page.
+  since: string; // Window of the list, as a duration accepted by the API's since parameter.
+};
+
+// PeriodPage renders the articles created within a rolling window, such as the last day or week.
+const PeriodPage: React.FC<PeriodPageProps> = ({ title, since }) => {
+  return (
+    <Layout>
+      <div
+        style={{
+          display: 'flex',
+          flexDirection: 'column',
+          minHeight: '100vh',
+        }}
+      >
+        <div style={{ flex: 1 }}>
+          <Typography
+            level="h2"
+            component="h2"
+            sx={{ fontWeight: 'bold', mb: 4, fontSize: '2rem' }}
+          >
+            {title}
+          </Typography>
+
+          <div style={{ marginBottom: '16px' }}>
+            <ArticleList since={since} />
+          </div>
+        </div>
+
+        <Footer />
+      </div>
+    </Layout>
+  );
+};
+
+export default PeriodPage;
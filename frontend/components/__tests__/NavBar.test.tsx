@@ -31,6 +31,12 @@ describe('NavBar Component', () => {
     expect(homeLink).toBeInTheDocument();
     expect(homeLink).toHaveAttribute('href', '/');
 
+    const dailyLink = screen.getByRole('link', { name: 'Daily' });
+    expect(dailyLink).toHaveAttribute('href', '/daily');
+
+    const weeklyLink = screen.getByRole('link', { name: 'Weekly' });
+    expect(weeklyLink).toHaveAttribute('href', '/weekly');
+
     const aboutLink = screen.getByRole('link', { name: 'About' });
     expect(aboutLink).toBeInTheDocument();
     expect(aboutLink).toHaveAttribute('href', '/about');
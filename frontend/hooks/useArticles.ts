@@ -2,16 +2,38 @@ import { useState, useEffect } from 'react';
 import { processSummary, formatDate } from '../lib/stringUtils';
 import { Article, ArticlesResponseSchema } from '../types';
 
-// Custom React hook to fetch and manage a list of articles
-const useArticles = () => {
+// Article fields the list renders; the API leaves the rest, such as the
+// full content, out of the response.
+const ARTICLE_FIELDS = [
+  'id',
+  'title',
+  'source',
+  'created_at',
+  'updated_at',
+  'summary',
+  'link',
+  'upvotes',
+  'comment_count',
+  'comment_link',
+].join(',');
+
+// Custom React hook to fetch and manage a list of articles.
+// An optional since bound (an RFC 3339 time, a date or a duration such as
+// '24h' or '7d') limits the list to articles created within that window.
+const useArticles = (since?: string) => {
   const [articles, setArticles] = useState<Article[]>([]);
 
   useEffect(() => {
     // Determine the API URL based on the environment
-    const apiUrl =
+    const baseUrl =
       process.env.NEXT_PUBLIC_ENV === 'development'
         ? 'http://localhost:8080/api/v1/articles'
         : 'https://gophersignal.com/api/v1/articles';
+    const params = new URLSearchParams({ fields: ARTICLE_FIELDS });
+    if (since) {
+      params.set('since', since);
+    }
+    const apiUrl = `${baseUrl}?${params.toString()}`;
 
     const fetchArticles = async () => {
       try {
@@ -54,7 +76,7 @@ const useArticles = () => {
     };
 
     fetchArticles();
-  }, []);
+  }, [since]);
 
   return articles;
 };
@@ -0,0 +1,7 @@
+import React from 'react';
+import PeriodPage from '../components/PeriodPage';
+
+// Daily renders the articles of the last 24 hours.
+const Daily: React.FC = () => <PeriodPage title="Last 24 Hours" since="24h" />;
+
+export default Daily;
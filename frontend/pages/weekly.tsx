@@ -0,0 +1,7 @@
+import React from 'react';
+import PeriodPage from '../components/PeriodPage';
+
+// Weekly renders the articles of the last 7 days.
+const Weekly: React.FC = () => <PeriodPage title="Last 7 Days" since="7d" />;
+
+export default Weekly;
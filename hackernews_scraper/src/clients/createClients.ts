@@ -1,17 +1,17 @@
 // Initializes infrastructure clients.
 
 import { createBrowserClient } from '../clients/puppeteer';
-import { createMySqlClient } from '../database/mysql';
 import { createOpenAIClient } from '../clients/openAI';
 import { createInstructorClient } from '../clients/instructor';
 import { createGitHubClient } from './github';
+import { createApiClient } from './gophersignal';
 import createArticleHelpers from '../utils/article';
 import { createTimeUtil } from '../utils/time';
 import { Config } from '../types/config';
 
 export const createClients = async (config: Config) => {
   const browser = await createBrowserClient();
-  const db = await createMySqlClient(config);
+  const api = createApiClient(config.ingest);
   const openaiClient = createOpenAIClient();
   const instructorClient = createInstructorClient(openaiClient);
   const githubClient = createGitHubClient();
@@ -20,7 +20,7 @@ export const createClients = async (config: Config) => {
 
   return {
     browser,
-    db,
+    api,
     openaiClient,
     instructorClient,
     githubClient,
@@ -0,0 +1,121 @@
+// Provides a client saving articles through the GopherSignal ingest API.
+
+import axios from 'axios';
+import { Article, ApiClient, IngestConfig } from '../types';
+
+// Largest batch the ingest endpoint accepts in one request
+const MAX_INGEST_BATCH = 500;
+
+// Longest content sent per article
+const MAX_CONTENT_LENGTH = 45000;
+
+// Deadline of one ingest request in milliseconds
+const REQUEST_TIMEOUT = 30000;
+
+// Outcome of one article of an ingested batch, as reported by the backend
+interface IngestResult {
+  index: number;
+  hn_id: number;
+  status: 'saved' | 'rejected';
+  error?: string;
+}
+
+interface IngestResponse {
+  saved: number;
+  rejected: number;
+  results: IngestResult[];
+}
+
+// Keeps the last copy of every Hacker News ID: the backend rejects repeats
+// within a batch, and later copies of an article carry its summary.
+const dedupeArticles = (articles: Article[]): Article[] => {
+  const lastIndex = new Map<number, number>();
+  articles.forEach(({ hnId }, i) => {
+    if (hnId > 0) lastIndex.set(hnId, i);
+  });
+  return articles.filter(
+    ({ hnId }, i) => !(hnId > 0) || lastIndex.get(hnId) === i
+  );
+};
+
+// Maps an article to the JSON accepted by POST /api/v1/articles
+const toIngestArticle = ({
+  hnId,
+  title,
+  link,
+  articleRank,
+  content = '',
+  summary,
+  upvotes = 0,
+  commentCount = 0,
+  commentLink = '',
+  flagged = false,
+  dead = false,
+  dupe = false,
+  commitHash,
+  modelName,
+}: Article) => ({
+  hn_id: hnId,
+  title,
+  link: link.startsWith('http') ? link : '',
+  article_rank: articleRank,
+  content:
+    content.length > MAX_CONTENT_LENGTH
+      ? content.slice(0, MAX_CONTENT_LENGTH)
+      : content,
+  summary: summary ?? null,
+  source: 'Hacker News',
+  upvotes,
+  comment_count: commentCount,
+  comment_link: commentLink.startsWith('http') ? commentLink : null,
+  flagged,
+  dead,
+  dupe,
+  commit_hash: commitHash ?? '',
+  model_name: modelName ?? '',
+});
+
+// Creates a client saving articles through the GopherSignal ingest API
+const createApiClient = (config: IngestConfig): ApiClient => {
+  const http = axios.create({
+    baseURL: config.url,
+    headers: { Authorization: `Bearer ${config.token}` },
+    timeout: REQUEST_TIMEOUT,
+    // 207 and 422 carry per-article results and are handled below
+    validateStatus: (status) =>
+      (status >= 200 && status < 300) || status === 422,
+  });
+
+  // Saves articles in batches, logging every article the backend rejected.
+  // Throws if a whole batch was rejected or the request failed.
+  const saveArticles = async (articles: Article[]): Promise<void> => {
+    const unique = dedupeArticles(articles);
+    for (let start = 0; start < unique.length; start += MAX_INGEST_BATCH) {
+      const batch = unique.slice(start, start + MAX_INGEST_BATCH);
+      const { status, data } = await http.post<IngestResponse>(
+        '',
+        batch.map(toIngestArticle)
+      );
+
+      data.results
+        .filter((result) => result.status === 'rejected')
+        .forEach((result) =>
+          console.warn(
+            `Article ${result.hn_id} (${batch[result.index]?.title}) ` +
+              `rejected: ${result.error}`
+          )
+        );
+
+      if (status === 422) {
+        throw new Error(
+          `All ${data.rejected} articles of the batch were rejected`
+        );
+      }
+      console.log(`Saved ${data.saved} of ${batch.length} articles`);
+    }
+  };
+
+  return { saveArticles };
+};
+
+export { createApiClient, dedupeArticles, toIngestArticle };
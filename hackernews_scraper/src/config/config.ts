@@ -7,11 +7,8 @@ import { Config } from '../types/index';
 dotenv.config();
 
 const envSchema = z.object({
-  MYSQL_HOST: z.string().default('localhost'),
-  MYSQL_PORT: z.string().default('3306'),
-  MYSQL_USER: z.string().default('user'),
-  MYSQL_PASSWORD: z.string().default(''),
-  MYSQL_DATABASE: z.string().default('database_name'),
+  INGEST_URL: z.string().default('http://localhost:8080/api/v1/articles'),
+  INGEST_TOKEN: z.string().default(''),
 
   OLLAMA_BASE_URL: z.string().default('http://localhost:11434/api/generate'),
   OLLAMA_MODEL: z.string().default('qwen3:8b'),
@@ -29,12 +26,9 @@ const envSchema = z.object({
 const env = envSchema.parse(process.env);
 
 const config: Config = {
-  mysql: {
-    host: env.MYSQL_HOST,
-    port: parseInt(env.MYSQL_PORT, 10),
-    user: env.MYSQL_USER,
-    password: env.MYSQL_PASSWORD,
-    database: env.MYSQL_DATABASE,
+  ingest: {
+    url: env.INGEST_URL,
+    token: env.INGEST_TOKEN,
   },
   ollama: {
     baseUrl: env.OLLAMA_BASE_URL,
@@ -0,0 +1,5 @@
+import { Article } from './article';
+
+export interface ApiClient {
+  saveArticles: (articles: Article[]) => Promise<void>;
+}
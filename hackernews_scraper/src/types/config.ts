@@ -1,9 +1,6 @@
-export interface MySQLConfig {
-  host: string;
-  port: number;
-  user: string;
-  password: string;
-  database: string;
+export interface IngestConfig {
+  url: string; // POST /api/v1/articles endpoint of the backend
+  token: string; // Bearer token matching the backend's INGEST_TOKEN
 }
 
 export interface OllamaConfig {
@@ -23,7 +20,7 @@ export interface GitHubConfig {
 }
 
 export interface Config {
-  mysql: MySQLConfig;
+  ingest: IngestConfig;
   ollama: OllamaConfig;
   github: GitHubConfig;
 }
@@ -1,4 +1,4 @@
-import { DBClient } from './db';
+import { ApiClient } from './api';
 import { TimeUtil } from '../utils/time';
 import { InstructorClient } from '../clients/instructor';
 import { BrowserClient } from '../clients/puppeteer';
@@ -10,7 +10,7 @@ import {
 } from './services';
 
 export interface Dependencies {
-  db: DBClient;
+  api: ApiClient;
   browser: BrowserClient;
   timeUtil: TimeUtil;
   instructorClient: InstructorClient;
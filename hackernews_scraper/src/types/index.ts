@@ -1,4 +1,4 @@
 export * from './article';
 export * from './config';
 export * from './services';
-export * from './db';
+export * from './api';
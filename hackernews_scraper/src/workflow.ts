@@ -90,8 +90,8 @@ export const createWorkflow = (services: Services) => {
         commitHash,
       }));
 
-      // Persist to DB
-      await services.db.saveArticles(articlesWithMeta);
+      // Persist through the backend's ingest API
+      await services.api.saveArticles(articlesWithMeta);
       console.info(
         `Workflow completed. Saved ${articlesWithMeta.length} articles @ ${commitHash}`
       );
@@ -104,10 +104,7 @@ export const createWorkflow = (services: Services) => {
   // Clean up resources
   const shutdown = async (): Promise<void> => {
     try {
-      await Promise.all([
-        services.db.closeDatabaseConnection(),
-        services.browser.close(),
-      ]);
+      await services.browser.close();
       console.info('Resources released successfully');
     } catch (error) {
       console.error('Error during shutdown:', error);
@@ -0,0 +1,55 @@
+/// <reference types="jest" />
+
+import { dedupeArticles, toIngestArticle } from '../src/clients/gophersignal';
+import { Article } from '../src/types';
+
+const article = (overrides: Partial<Article>): Article => ({
+  title: 'Title',
+  link: 'https://example.com/post',
+  hnId: 1,
+  articleRank: 1,
+  flagged: false,
+  dead: false,
+  dupe: false,
+  upvotes: 10,
+  commentCount: 2,
+  commentLink: 'https://news.ycombinator.com/item?id=1',
+  commitHash: 'abcdef0',
+  modelName: 'qwen3:8b',
+  ...overrides,
+});
+
+describe('GopherSignal client', () => {
+  it('keeps the last copy of every Hacker News ID', () => {
+    const articles = [
+      article({ hnId: 1, title: 'first' }),
+      article({ hnId: 2 }),
+      article({ hnId: 1, title: 'summarized', summary: 'A summary' }),
+      article({ hnId: 0, title: 'unknown' }),
+      article({ hnId: 0, title: 'also unknown' }),
+    ];
+
+    expect(dedupeArticles(articles).map((a) => a.title)).toEqual([
+      'Title',
+      'summarized',
+      'unknown',
+      'also unknown',
+    ]);
+  });
+
+  it('maps articles to the ingest JSON', () => {
+    const body = toIngestArticle(
+      article({ link: 'No link', commentLink: 'No comments link' })
+    );
+
+    expect(body).toMatchObject({
+      hn_id: 1,
+      link: '',
+      comment_link: null,
+      summary: null,
+      source: 'Hacker News',
+      commit_hash: 'abcdef0',
+      model_name: 'qwen3:8b',
+    });
+  });
+});
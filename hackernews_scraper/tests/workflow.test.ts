@@ -28,9 +28,8 @@ describe('Workflow', () => {
       githubService: {
         getCommitHash: jest.fn().mockResolvedValue('abcdef0'),
       },
-      db: {
+      api: {
         saveArticles: jest.fn().mockResolvedValue(undefined),
-        closeDatabaseConnection: jest.fn().mockResolvedValue(undefined),
       },
       timeUtil: {
         today: '2024-02-07',
@@ -57,13 +56,12 @@ describe('Workflow', () => {
       mockServices.articleSummarizer.summarizeArticles
     ).toHaveBeenCalledTimes(2);
     expect(mockServices.githubService.getCommitHash).toHaveBeenCalledTimes(1);
-    expect(mockServices.db.saveArticles).toHaveBeenCalledTimes(1);
+    expect(mockServices.api.saveArticles).toHaveBeenCalledTimes(1);
   });
 
   it('should handle shutdown gracefully', async () => {
     await expect(workflow.shutdown()).resolves.not.toThrow();
 
-    expect(mockServices.db.closeDatabaseConnection).toHaveBeenCalledTimes(1);
     expect(mockServices.browser.close).toHaveBeenCalledTimes(1);
   });
 });
@@ -14,6 +14,7 @@ use serde::Deserialize;
 use url::Url;
 
 // Filters for RSS feed.
+// Time bounds take RFC 3339 times, dates or durations such as 24h and are passed to the API as is.
 #[derive(Deserialize, Debug, Clone)]
 pub struct RssQuery {
     pub flagged: Option<bool>,
@@ -21,6 +22,44 @@ pub struct RssQuery {
     pub dupe: Option<bool>,
     pub min_upvotes: Option<u32>,
     pub min_comments: Option<u32>,
+    pub since: Option<String>,
+    pub until: Option<String>,
+    pub updated_since: Option<String>,
+    pub period: Option<Period>,
+}
+
+// Rolling time windows for daily and weekly feeds.
+#[derive(Deserialize, Debug, Clone, Copy, PartialEq)]
+#[serde(rename_all = "lowercase")]
+pub enum Period {
+    Daily,
+    Weekly,
+}
+
+impl Period {
+    // Duration ago at which the window starts, in the API's syntax.
+    fn since(self) -> &'static str {
+        match self {
+            Period::Daily => "24h",
+            Period::Weekly => "7d",
+        }
+    }
+
+    fn label(self) -> &'static str {
+        match self {
+            Period::Daily => "Daily",
+            Period::Weekly => "Weekly",
+        }
+    }
+}
+
+impl RssQuery {
+    // Lower time bound: an explicit since wins over the period's window.
+    pub fn effective_since(&self) -> Option<String> {
+        self.since
+            .clone()
+            .or_else(|| self.period.map(|p| p.since().to_string()))
+    }
 }
 
 /// Generate the RSS feed based on query filters.
@@ -60,6 +99,9 @@ fn build_rss_channel(mut articles: Vec<Article>, query: &RssQuery) -> rss::Chann
 // Build RSS title from active filters.
 fn build_feed_title(query: &RssQuery) -> String {
     let mut parts = Vec::new();
+    if let Some(period) = query.period {
+        parts.push(period.label());
+    }
     if query.flagged.unwrap_or(false) {
         parts.push("Flagged");
     }
@@ -69,7 +111,12 @@ fn build_feed_title(query: &RssQuery) -> String {
     if query.dupe.unwrap_or(false) {
         parts.push("Dupe");
     }
-    if query.min_upvotes.unwrap_or(0) > 0 || query.min_comments.unwrap_or(0) > 0 {
+    if query.min_upvotes.unwrap_or(0) > 0
+        || query.min_comments.unwrap_or(0) > 0
+        || query.since.is_some()
+        || query.until.is_some()
+        || query.updated_since.is_some()
+    {
         parts.push("Filtered");
     }
 
@@ -209,3 +256,45 @@ fn build_rss_response(channel: rss::Channel) -> Result<Response<String>, AppErro
         .body(channel.to_string())
         .map_err(Into::into)
 }
+
+#[cfg(test)]
+mod tests {
+    use super::*;
+
+    fn query(json: &str) -> RssQuery {
+        serde_json::from_str(json).unwrap()
+    }
+
+    #[test]
+    fn test_period_sets_since() {
+        assert_eq!(
+            query(r#"{"period": "daily"}"#).effective_since().as_deref(),
+            Some("24h")
+        );
+        assert_eq!(
+            query(r#"{"period": "weekly"}"#).effective_since().as_deref(),
+            Some("7d")
+        );
+        assert_eq!(
+            query(r#"{"period": "weekly", "since": "2026-10-01"}"#)
+                .effective_since()
+                .as_deref(),
+            Some("2026-10-01")
+        );
+        assert_eq!(query("{}").effective_since(), None);
+        assert!(serde_json::from_str::<RssQuery>(r#"{"period": "hourly"}"#).is_err());
+    }
+
+    #[test]
+    fn test_feed_title_names_period() {
+        assert_eq!(
+            build_feed_title(&query(r#"{"period": "weekly"}"#)),
+            "Gopher Signal - Weekly"
+        );
+        assert_eq!(
+            build_feed_title(&query(r#"{"period": "daily", "min_upvotes": 50}"#)),
+            "Gopher Signal - Daily, Filtered"
+        );
+        assert_eq!(build_feed_title(&query("{}")), "Gopher Signal");
+    }
+}
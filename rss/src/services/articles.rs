@@ -25,10 +25,10 @@ impl ArticlesClient for HttpArticlesClient {
     ) -> Result<Vec<Article>, Box<dyn std::error::Error + Send + Sync>> {
         let client = Client::new();
         let backend_url = config.api_url.clone();
-        let mut request = client.get(&backend_url);
 
-        // Build query parameters based on RssQuery.
-        let mut params = Vec::new();
+        // Build query parameters based on RssQuery. Feeds never show the full
+        // content, so leave it out of the response.
+        let mut params = vec![("exclude", "content".to_string())];
         if let Some(flagged) = query.flagged {
             params.push(("flagged", flagged.to_string()));
         }
@@ -44,11 +44,17 @@ impl ArticlesClient for HttpArticlesClient {
         if let Some(min_comments) = query.min_comments {
             params.push(("min_comments", min_comments.to_string()));
         }
-        if !params.is_empty() {
-            request = request.query(&params);
+        if let Some(since) = query.effective_since() {
+            params.push(("since", since));
+        }
+        if let Some(until) = &query.until {
+            params.push(("until", until.clone()));
+        }
+        if let Some(updated_since) = &query.updated_since {
+            params.push(("updated_since", updated_since.clone()));
         }
 
-        let response = request.send().await?;
+        let response = client.get(&backend_url).query(&params).send().await?;
         let api_response: ApiResponse = response.json().await?;
         Ok(api_response.articles.unwrap_or_default())
     }